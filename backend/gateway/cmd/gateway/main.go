@@ -11,7 +11,7 @@ import (
 
 func main() {
 	// Создаем и настраиваем Gateway
-	gateway := httpgateway.NewGateway("localhost:50051", ":8888")
+	gateway := httpgateway.NewGateway("localhost:50051", ":8888", "http://localhost:8080/.well-known/jwks.json")
 
 	// Настраиваем маршруты
 	if err := gateway.SetupRoutes(); err != nil {
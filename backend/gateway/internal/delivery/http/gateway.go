@@ -31,6 +31,7 @@ type Gateway struct {
 	grpcMgr  *GRPCConnectionManager
 	router   *mux.Router
 	httpAddr string
+	jwks     *JWKSProxy
 	ctx      context.Context
 	cancel   context.CancelFunc
 }
@@ -132,14 +133,16 @@ func (m *GRPCConnectionManager) Close() {
 	}
 }
 
-// NewGateway создает новый Gateway
-func NewGateway(grpcAddr, httpAddr string) *Gateway {
+// NewGateway создает новый Gateway.
+// jwksURL - адрес набора открытых ключей user-service.
+func NewGateway(grpcAddr, httpAddr, jwksURL string) *Gateway {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Gateway{
 		grpcMgr:  NewGRPCConnectionManager(grpcAddr),
 		router:   mux.NewRouter(),
 		httpAddr: httpAddr,
+		jwks:     NewJWKSProxy(jwksURL),
 		ctx:      ctx,
 		cancel:   cancel,
 	}
@@ -178,6 +181,9 @@ func (g *Gateway) SetupRoutes() error {
 	g.router.HandleFunc("/", g.homeHandler)
 	g.router.HandleFunc("/health", g.healthHandler)
 
+	// Открытые ключи для офлайн-проверки токенов другими сервисами
+	g.router.Handle("/.well-known/jwks.json", g.jwks).Methods(http.MethodGet, http.MethodHead)
+
 	// Все запросы к /v1/ передаем в gRPC Gateway
 	g.router.PathPrefix("/v1/").Handler(gwmux)
 
@@ -220,6 +226,7 @@ func (g *Gateway) homeHandler(w http.ResponseWriter, r *http.Request) {
 			<li><a href="/v1/hello/world">GET /v1/hello/world</a></li>
 			<li>POST /v1/hello with JSON: {"name": "world"}</li>
 			<li><a href="/health">GET /health</a></li>
+			<li><a href="/.well-known/jwks.json">GET /.well-known/jwks.json</a></li>
 		</ul>
 	`)
 }
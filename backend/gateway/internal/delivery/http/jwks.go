@@ -0,0 +1,88 @@
+package httpgateway
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// jwksCacheTTL - сколько gateway отдает закешированный набор ключей
+const jwksCacheTTL = 5 * time.Minute
+
+// JWKSProxy отдает набор открытых ключей user-service по /.well-known/jwks.json.
+// Ответ кешируется; при недоступности user-service отдается последняя копия.
+type JWKSProxy struct {
+	mu        sync.RWMutex
+	sourceURL string
+	client    *http.Client
+	body      []byte
+	fetchedAt time.Time
+}
+
+// NewJWKSProxy создает прокси JWKS
+func NewJWKSProxy(sourceURL string) *JWKSProxy {
+	return &JWKSProxy{
+		sourceURL: sourceURL,
+		client:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (p *JWKSProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := p.get()
+	if err != nil {
+		log.Printf("JWKS fetch failed: %v", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error": "jwks unavailable"}`))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(jwksCacheTTL.Seconds())))
+	w.Write(body)
+}
+
+// get возвращает набор ключей из кеша или загружает его заново
+func (p *JWKSProxy) get() ([]byte, error) {
+	p.mu.RLock()
+	body, fetchedAt := p.body, p.fetchedAt
+	p.mu.RUnlock()
+
+	if body != nil && time.Since(fetchedAt) < jwksCacheTTL {
+		return body, nil
+	}
+
+	fresh, err := p.fetch()
+	if err != nil {
+		if body != nil {
+			// Лучше отдать устаревший набор, чем сломать проверку токенов
+			return body, nil
+		}
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.body = fresh
+	p.fetchedAt = time.Now()
+	p.mu.Unlock()
+
+	return fresh, nil
+}
+
+// fetch загружает набор ключей из user-service
+func (p *JWKSProxy) fetch() ([]byte, error) {
+	resp, err := p.client.Get(p.sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, p.sourceURL)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
@@ -1,23 +1,36 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 	users "userservice/gen/v1"
 	"userservice/internal/config"
 	"userservice/internal/delivery/grpch"
+	"userservice/internal/delivery/httph"
+	"userservice/internal/domain"
+	"userservice/internal/notification"
+	"userservice/internal/repository/memory"
 	"userservice/internal/repository/mongodb"
 	"userservice/internal/repository/postgres"
+	redisstore "userservice/internal/repository/redis"
 	"userservice/internal/server"
+	"userservice/pkg/breached"
 	"userservice/pkg/db"
 	"userservice/pkg/jwt"
+	"userservice/pkg/password"
+	"userservice/pkg/secretbox"
 
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -51,23 +64,161 @@ func main() {
 		}
 	}()
 
+	// Подключение к Redis (список отозванных токенов, счетчики неудачных входов).
+	// Без Redis работаем с хранилищами в памяти процесса.
+	var revocationStore domain.TokenRevocationStore
+	var attemptStore domain.LoginAttemptStore
+	redisClient, err := db.ConnectRedis(db.RedisConfig(cfg.Redis))
+	if err != nil {
+		log.Printf("Redis unavailable, using in-memory token revocation and login lockout: %v", err)
+		revocationStore = memory.NewMemoryRevocationStore()
+		attemptStore = memory.NewMemoryLoginAttemptStore()
+	} else {
+		defer redisClient.Close()
+		revocationStore = redisstore.NewRedisRevocationStore(redisClient)
+		attemptStore = redisstore.NewRedisLoginAttemptStore(redisClient)
+	}
+
 	// Инициализация репозиториев
 	postgresRepo := postgres.NewPostgresUserRepository(postgresDB)
 	mongoRepo := mongodb.NewMongoUserRepository(mongoClient, cfg.Mongo.Database)
+	tokenRepo := postgres.NewPostgresTokenRepository(postgresDB)
+	resetRepo := postgres.NewPostgresPasswordResetRepository(postgresDB)
+	otpRepo := postgres.NewPostgresPhoneOTPRepository(postgresDB)
+	mfaRepo := postgres.NewPostgresMFARepository(postgresDB)
+	webauthnRepo := postgres.NewPostgresWebAuthnRepository(postgresDB)
+	passwordHistoryRepo := postgres.NewPostgresPasswordHistoryRepository(postgresDB)
+	orgRepo := postgres.NewPostgresOrganizationRepository(postgresDB)
+	apiKeyRepo := postgres.NewPostgresAPIKeyRepository(postgresDB)
+	appealRepo := postgres.NewPostgresBanAppealRepository(postgresDB)
+	reportRepo := postgres.NewPostgresReportRepository(postgresDB)
+	denyListRepo := postgres.NewPostgresDenyListRepository(postgresDB)
+	serviceAccountRepo := memory.NewMemoryServiceAccountRepository(serviceAccounts(cfg.ServiceAccounts.Clients))
+
+	// Ключ шифрования TOTP секретов
+	secrets, err := secretbox.New(cfg.MFA.EncryptionKey)
+	if err != nil {
+		log.Fatalf("Failed to init MFA encryption: %v", err)
+	}
+
+	// Хеширование паролей
+	passwordHasher, err := password.NewHasher(cfg.Password.Algorithm, cfg.Password.BcryptCost, password.Argon2idParams(cfg.Password.Argon2id))
+	if err != nil {
+		log.Fatalf("Failed to init password hasher: %v", err)
+	}
+
+	// Политика паролей
+	policyCfg := cfg.PasswordPolicy
+	words := policyCfg.Dictionary
+	if policyCfg.DictionaryFile != "" {
+		fileWords, err := loadWordList(policyCfg.DictionaryFile)
+		if err != nil {
+			log.Fatalf("Failed to load password dictionary: %v", err)
+		}
+		words = append(words, fileWords...)
+	}
+	passwordPolicy := &domain.PasswordPolicy{
+		MinLength:      policyCfg.MinLength,
+		MaxLength:      policyCfg.MaxLength,
+		RequireUpper:   policyCfg.RequireUpper,
+		RequireLower:   policyCfg.RequireLower,
+		RequireDigit:   policyCfg.RequireDigit,
+		RequireSymbol:  policyCfg.RequireSymbol,
+		MinCharClasses: policyCfg.MinCharClasses,
+		Dictionary:     domain.NewPasswordPolicyDictionary(words),
+		MinWordLength:  policyCfg.MinWordLength,
+	}
+
+	// База утекших паролей (необязательная)
+	var breachedChecker domain.BreachedPasswordChecker
+	if policyCfg.BreachedPath != "" {
+		checker, err := breached.Open(policyCfg.BreachedPath, policyCfg.BreachedMinCount)
+		if err != nil {
+			log.Fatalf("Failed to load breached passwords: %v", err)
+		}
+		breachedChecker = checker
+	}
+
+	// Домены одноразовой почты
+	disposable := cfg.DenyLists.DisposableDomains
+	if cfg.DenyLists.DisposableDomainsFile != "" {
+		fileDomains, err := loadWordList(cfg.DenyLists.DisposableDomainsFile)
+		if err != nil {
+			log.Fatalf("Failed to load disposable email domains: %v", err)
+		}
+		disposable = append(disposable, fileDomains...)
+	}
+	disposableDomains := domain.NewEmailDomainSet(disposable)
+
+	// Отправка писем
+	var mailer domain.Mailer
+	switch cfg.Mail.Driver {
+	case "file":
+		mailer, err = notification.NewFileMailer(cfg.Mail.From, cfg.Mail.OutboxDir)
+		if err != nil {
+			log.Fatalf("Failed to init mailer: %v", err)
+		}
+	default:
+		mailer = notification.NewLogMailer(cfg.Mail.From)
+	}
+
+	// Отправка SMS (пока только заглушка)
+	smsSender := notification.NewLogSMSSender(cfg.SMS.From)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	// Инициализация JWT менеджера
-	jwtManager := jwt.NewJWTManager(cfg.JWT.Secret, cfg.JWT.Expiry)
+	var jwtManager *jwt.JWTManager
+	if cfg.JWT.Algorithm == "HS256" {
+		jwtManager = jwt.NewJWTManager(cfg.JWT.Secret, cfg.JWT.Issuer, cfg.JWT.Audience, cfg.JWT.Expiry, cfg.JWT.RefreshExpiry)
+	} else {
+		keyManager, err := jwt.NewKeyManager(cfg.JWT.KeysDir, cfg.JWT.Algorithm, cfg.JWT.PublishDelay)
+		if err != nil {
+			log.Fatalf("Failed to load signing keys: %v", err)
+		}
+		// Ключ удаляется не раньше, чем истекут все подписанные им токены,
+		// включая одноцелевые (ссылка подтверждения email живет дольше access токена)
+		keyManager.StartRotation(ctx, cfg.JWT.RotationInterval, cfg.SigningKeyRetention())
+		jwtManager = jwt.NewKeyedJWTManager(keyManager, cfg.JWT.Issuer, cfg.JWT.Audience, cfg.JWT.Expiry, cfg.JWT.RefreshExpiry)
+	}
+
+	// WebAuthn (passkeys). Вход по ключу заменяет и пароль, и TOTP,
+	// поэтому проверка пользователя (PIN, биометрия) обязательна
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          cfg.WebAuthn.RPID,
+		RPDisplayName: cfg.WebAuthn.RPDisplayName,
+		RPOrigins:     cfg.WebAuthn.RPOrigins,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			UserVerification: protocol.VerificationRequired,
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to init WebAuthn: %v", err)
+	}
 
 	// Инициализация сервиса
-	userService := server.NewUserService(postgresRepo, mongoRepo, jwtManager, cfg)
+	userService := server.NewUserService(postgresRepo, mongoRepo, tokenRepo, revocationStore, attemptStore, resetRepo, otpRepo, mfaRepo, mailer, smsSender, secrets, passwordHasher, passwordHistoryRepo, passwordPolicy, breachedChecker, jwtManager, webauthnRepo, webAuthn, orgRepo, apiKeyRepo, serviceAccountRepo, appealRepo, reportRepo, denyListRepo, disposableDomains, cfg)
+
+	// Снятие истекших временных банов
+	userService.StartBanExpiry(ctx, cfg.Bans.ExpiryCheckInterval, cfg.Bans.ExpiryBatchSize)
 
 	// Создание gRPC обработчика
-	userHandler := grpch.NewUserHandler(userService)
+	trustedProxies, err := grpch.ParseTrustedProxies(cfg.GRPC.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to parse trusted proxies: %v", err)
+	}
+	userHandler := grpch.NewUserHandler(userService, trustedProxies)
+
+	// Проверка access токена или API ключа для всех методов, кроме публичных
+	authInterceptor := grpch.NewAuthInterceptor(jwtManager, revocationStore, userService, cfg.ServiceAccounts.Audience, grpch.PublicMethods)
 
 	// Создание gRPC сервера
 	grpcServer := grpc.NewServer(
 		grpc.MaxRecvMsgSize(1024*1024*10), // 10MB
 		grpc.MaxSendMsgSize(1024*1024*10), // 10MB
+		grpc.ChainUnaryInterceptor(authInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(authInterceptor.Stream()),
 	)
 
 	// Регистрация сервисов
@@ -91,6 +242,20 @@ func main() {
 
 	log.Printf("Starting gRPC server on port %d", cfg.GRPC.Port)
 
+	// HTTP сервер (JWKS)
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.HTTP.Port),
+		Handler:           httph.NewRouter(jwtManager),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		log.Printf("Starting HTTP server on port %d", cfg.HTTP.Port)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to serve HTTP: %v", err)
+		}
+	}()
+
 	// Graceful shutdown
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
@@ -109,9 +274,63 @@ func main() {
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 
 	// Graceful stop с таймаутом
-	_, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer shutdownCancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shutdown HTTP server: %v", err)
+	}
 
 	grpcServer.GracefulStop()
 	log.Println("gRPC server stopped gracefully")
 }
+
+// loadWordList читает слова из файла, по одному в строке
+func loadWordList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var words []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if word := strings.TrimSpace(sc.Text()); word != "" && !strings.HasPrefix(word, "#") {
+			words = append(words, word)
+		}
+	}
+	return words, sc.Err()
+}
+
+// serviceAccounts собирает сервисные аккаунты из конфигурации.
+// Неизвестное или недоступное сервисам право и некорректный хеш секрета
+// у включенного аккаунта - ошибка конфигурации, сервис не запускается.
+func serviceAccounts(clients []config.ServiceAccountConfig) []*domain.ServiceAccount {
+	accounts := make([]*domain.ServiceAccount, 0, len(clients))
+	for _, c := range clients {
+		if !c.Disabled && !domain.IsValidSecretHash(c.SecretHash) {
+			log.Fatalf("Invalid secret_hash for service account %s: expected SHA-256 in hex", c.ClientID)
+		}
+
+		account := &domain.ServiceAccount{
+			ClientID:   c.ClientID,
+			Name:       c.Name,
+			SecretHash: strings.ToLower(c.SecretHash),
+			Audiences:  c.Audiences,
+			Disabled:   c.Disabled,
+		}
+		for _, p := range c.Permissions {
+			perm := domain.Permission(p)
+			if !perm.IsValid() {
+				log.Fatalf("Unknown permission %q for service account %s", p, c.ClientID)
+			}
+			if !perm.AllowedForService() {
+				log.Fatalf("Permission %q requires a role and cannot be granted to service account %s", p, c.ClientID)
+			}
+			account.Permissions = append(account.Permissions, perm)
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts
+}
@@ -313,25 +313,462 @@ func (SubscriptionLevel) EnumDescriptor() ([]byte, []int) {
 	return file_v1_user_proto_rawDescGZIP(), []int{3}
 }
 
+// Роль внутри организации
+type OrganizationRole int32
+
+const (
+	OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED OrganizationRole = 0
+	OrganizationRole_ORGANIZATION_ROLE_OWNER       OrganizationRole = 1
+	OrganizationRole_ORGANIZATION_ROLE_ADMIN       OrganizationRole = 2 // Управляет участниками и приглашениями
+	OrganizationRole_ORGANIZATION_ROLE_MEMBER      OrganizationRole = 3
+)
+
+// Enum value maps for OrganizationRole.
+var (
+	OrganizationRole_name = map[int32]string{
+		0: "ORGANIZATION_ROLE_UNSPECIFIED",
+		1: "ORGANIZATION_ROLE_OWNER",
+		2: "ORGANIZATION_ROLE_ADMIN",
+		3: "ORGANIZATION_ROLE_MEMBER",
+	}
+	OrganizationRole_value = map[string]int32{
+		"ORGANIZATION_ROLE_UNSPECIFIED": 0,
+		"ORGANIZATION_ROLE_OWNER":       1,
+		"ORGANIZATION_ROLE_ADMIN":       2,
+		"ORGANIZATION_ROLE_MEMBER":      3,
+	}
+)
+
+func (x OrganizationRole) Enum() *OrganizationRole {
+	p := new(OrganizationRole)
+	*p = x
+	return p
+}
+
+func (x OrganizationRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrganizationRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_user_proto_enumTypes[4].Descriptor()
+}
+
+func (OrganizationRole) Type() protoreflect.EnumType {
+	return &file_v1_user_proto_enumTypes[4]
+}
+
+func (x OrganizationRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrganizationRole.Descriptor instead.
+func (OrganizationRole) EnumDescriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{4}
+}
+
+// Статусы приглашения в организацию
+type InvitationStatus int32
+
+const (
+	InvitationStatus_INVITATION_STATUS_UNSPECIFIED InvitationStatus = 0
+	InvitationStatus_INVITATION_STATUS_PENDING     InvitationStatus = 1
+	InvitationStatus_INVITATION_STATUS_ACCEPTED    InvitationStatus = 2
+	InvitationStatus_INVITATION_STATUS_DECLINED    InvitationStatus = 3
+)
+
+// Enum value maps for InvitationStatus.
+var (
+	InvitationStatus_name = map[int32]string{
+		0: "INVITATION_STATUS_UNSPECIFIED",
+		1: "INVITATION_STATUS_PENDING",
+		2: "INVITATION_STATUS_ACCEPTED",
+		3: "INVITATION_STATUS_DECLINED",
+	}
+	InvitationStatus_value = map[string]int32{
+		"INVITATION_STATUS_UNSPECIFIED": 0,
+		"INVITATION_STATUS_PENDING":     1,
+		"INVITATION_STATUS_ACCEPTED":    2,
+		"INVITATION_STATUS_DECLINED":    3,
+	}
+)
+
+func (x InvitationStatus) Enum() *InvitationStatus {
+	p := new(InvitationStatus)
+	*p = x
+	return p
+}
+
+func (x InvitationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InvitationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_user_proto_enumTypes[5].Descriptor()
+}
+
+func (InvitationStatus) Type() protoreflect.EnumType {
+	return &file_v1_user_proto_enumTypes[5]
+}
+
+func (x InvitationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InvitationStatus.Descriptor instead.
+func (InvitationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{5}
+}
+
+// Категории банов, каждой соответствует свой статус пользователя
+type BanCategory int32
+
+const (
+	BanCategory_BAN_CATEGORY_UNSPECIFIED BanCategory = 0
+	BanCategory_BAN_CATEGORY_ADMIN       BanCategory = 1 // USER_STATUS_BANNED_BY_ADMIN
+	BanCategory_BAN_CATEGORY_SYSTEM      BanCategory = 2 // USER_STATUS_BANNED_BY_SYSTEM, только автоматические баны
+	BanCategory_BAN_CATEGORY_SPAM        BanCategory = 3 // USER_STATUS_BANNED_FOR_SPAM
+	BanCategory_BAN_CATEGORY_ABUSE       BanCategory = 4 // USER_STATUS_BANNED_FOR_ABUSE
+	BanCategory_BAN_CATEGORY_FRAUD       BanCategory = 5 // USER_STATUS_BANNED_FOR_FRAUD
+)
+
+// Enum value maps for BanCategory.
+var (
+	BanCategory_name = map[int32]string{
+		0: "BAN_CATEGORY_UNSPECIFIED",
+		1: "BAN_CATEGORY_ADMIN",
+		2: "BAN_CATEGORY_SYSTEM",
+		3: "BAN_CATEGORY_SPAM",
+		4: "BAN_CATEGORY_ABUSE",
+		5: "BAN_CATEGORY_FRAUD",
+	}
+	BanCategory_value = map[string]int32{
+		"BAN_CATEGORY_UNSPECIFIED": 0,
+		"BAN_CATEGORY_ADMIN":       1,
+		"BAN_CATEGORY_SYSTEM":      2,
+		"BAN_CATEGORY_SPAM":        3,
+		"BAN_CATEGORY_ABUSE":       4,
+		"BAN_CATEGORY_FRAUD":       5,
+	}
+)
+
+func (x BanCategory) Enum() *BanCategory {
+	p := new(BanCategory)
+	*p = x
+	return p
+}
+
+func (x BanCategory) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BanCategory) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_user_proto_enumTypes[6].Descriptor()
+}
+
+func (BanCategory) Type() protoreflect.EnumType {
+	return &file_v1_user_proto_enumTypes[6]
+}
+
+func (x BanCategory) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BanCategory.Descriptor instead.
+func (BanCategory) EnumDescriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{6}
+}
+
+// Статусы апелляции на бан
+type AppealStatus int32
+
+const (
+	AppealStatus_APPEAL_STATUS_UNSPECIFIED AppealStatus = 0
+	AppealStatus_APPEAL_STATUS_PENDING     AppealStatus = 1
+	AppealStatus_APPEAL_STATUS_IN_REVIEW   AppealStatus = 2 // Назначена модератору
+	AppealStatus_APPEAL_STATUS_APPROVED    AppealStatus = 3 // Бан снят
+	AppealStatus_APPEAL_STATUS_REJECTED    AppealStatus = 4
+)
+
+// Enum value maps for AppealStatus.
+var (
+	AppealStatus_name = map[int32]string{
+		0: "APPEAL_STATUS_UNSPECIFIED",
+		1: "APPEAL_STATUS_PENDING",
+		2: "APPEAL_STATUS_IN_REVIEW",
+		3: "APPEAL_STATUS_APPROVED",
+		4: "APPEAL_STATUS_REJECTED",
+	}
+	AppealStatus_value = map[string]int32{
+		"APPEAL_STATUS_UNSPECIFIED": 0,
+		"APPEAL_STATUS_PENDING":     1,
+		"APPEAL_STATUS_IN_REVIEW":   2,
+		"APPEAL_STATUS_APPROVED":    3,
+		"APPEAL_STATUS_REJECTED":    4,
+	}
+)
+
+func (x AppealStatus) Enum() *AppealStatus {
+	p := new(AppealStatus)
+	*p = x
+	return p
+}
+
+func (x AppealStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AppealStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_user_proto_enumTypes[7].Descriptor()
+}
+
+func (AppealStatus) Type() protoreflect.EnumType {
+	return &file_v1_user_proto_enumTypes[7]
+}
+
+func (x AppealStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AppealStatus.Descriptor instead.
+func (AppealStatus) EnumDescriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{7}
+}
+
+// Категории жалоб
+type ReportCategory int32
+
+const (
+	ReportCategory_REPORT_CATEGORY_UNSPECIFIED ReportCategory = 0
+	ReportCategory_REPORT_CATEGORY_SPAM        ReportCategory = 1
+	ReportCategory_REPORT_CATEGORY_ABUSE       ReportCategory = 2
+	ReportCategory_REPORT_CATEGORY_FRAUD       ReportCategory = 3
+	ReportCategory_REPORT_CATEGORY_OTHER       ReportCategory = 4
+)
+
+// Enum value maps for ReportCategory.
+var (
+	ReportCategory_name = map[int32]string{
+		0: "REPORT_CATEGORY_UNSPECIFIED",
+		1: "REPORT_CATEGORY_SPAM",
+		2: "REPORT_CATEGORY_ABUSE",
+		3: "REPORT_CATEGORY_FRAUD",
+		4: "REPORT_CATEGORY_OTHER",
+	}
+	ReportCategory_value = map[string]int32{
+		"REPORT_CATEGORY_UNSPECIFIED": 0,
+		"REPORT_CATEGORY_SPAM":        1,
+		"REPORT_CATEGORY_ABUSE":       2,
+		"REPORT_CATEGORY_FRAUD":       3,
+		"REPORT_CATEGORY_OTHER":       4,
+	}
+)
+
+func (x ReportCategory) Enum() *ReportCategory {
+	p := new(ReportCategory)
+	*p = x
+	return p
+}
+
+func (x ReportCategory) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportCategory) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_user_proto_enumTypes[8].Descriptor()
+}
+
+func (ReportCategory) Type() protoreflect.EnumType {
+	return &file_v1_user_proto_enumTypes[8]
+}
+
+func (x ReportCategory) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportCategory.Descriptor instead.
+func (ReportCategory) EnumDescriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{8}
+}
+
+// Статусы жалобы в очереди модерации
+type ReportStatus int32
+
+const (
+	ReportStatus_REPORT_STATUS_UNSPECIFIED ReportStatus = 0
+	ReportStatus_REPORT_STATUS_OPEN        ReportStatus = 1
+	ReportStatus_REPORT_STATUS_CLAIMED     ReportStatus = 2 // Взята модератором
+	ReportStatus_REPORT_STATUS_RESOLVED    ReportStatus = 3
+)
+
+// Enum value maps for ReportStatus.
+var (
+	ReportStatus_name = map[int32]string{
+		0: "REPORT_STATUS_UNSPECIFIED",
+		1: "REPORT_STATUS_OPEN",
+		2: "REPORT_STATUS_CLAIMED",
+		3: "REPORT_STATUS_RESOLVED",
+	}
+	ReportStatus_value = map[string]int32{
+		"REPORT_STATUS_UNSPECIFIED": 0,
+		"REPORT_STATUS_OPEN":        1,
+		"REPORT_STATUS_CLAIMED":     2,
+		"REPORT_STATUS_RESOLVED":    3,
+	}
+)
+
+func (x ReportStatus) Enum() *ReportStatus {
+	p := new(ReportStatus)
+	*p = x
+	return p
+}
+
+func (x ReportStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_user_proto_enumTypes[9].Descriptor()
+}
+
+func (ReportStatus) Type() protoreflect.EnumType {
+	return &file_v1_user_proto_enumTypes[9]
+}
+
+func (x ReportStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportStatus.Descriptor instead.
+func (ReportStatus) EnumDescriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{9}
+}
+
+// Решения по жалобе
+type ReportAction int32
+
+const (
+	ReportAction_REPORT_ACTION_UNSPECIFIED ReportAction = 0
+	ReportAction_REPORT_ACTION_DISMISS     ReportAction = 1
+	ReportAction_REPORT_ACTION_WARN        ReportAction = 2 // Страйк с эскалацией
+	ReportAction_REPORT_ACTION_BAN         ReportAction = 3 // Бан с категорией жалобы
+)
+
+// Enum value maps for ReportAction.
+var (
+	ReportAction_name = map[int32]string{
+		0: "REPORT_ACTION_UNSPECIFIED",
+		1: "REPORT_ACTION_DISMISS",
+		2: "REPORT_ACTION_WARN",
+		3: "REPORT_ACTION_BAN",
+	}
+	ReportAction_value = map[string]int32{
+		"REPORT_ACTION_UNSPECIFIED": 0,
+		"REPORT_ACTION_DISMISS":     1,
+		"REPORT_ACTION_WARN":        2,
+		"REPORT_ACTION_BAN":         3,
+	}
+)
+
+func (x ReportAction) Enum() *ReportAction {
+	p := new(ReportAction)
+	*p = x
+	return p
+}
+
+func (x ReportAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_user_proto_enumTypes[10].Descriptor()
+}
+
+func (ReportAction) Type() protoreflect.EnumType {
+	return &file_v1_user_proto_enumTypes[10]
+}
+
+func (x ReportAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportAction.Descriptor instead.
+func (ReportAction) EnumDescriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{10}
+}
+
+// Типы записей списка запрета
+type DenyListKind int32
+
+const (
+	DenyListKind_DENY_LIST_KIND_UNSPECIFIED  DenyListKind = 0
+	DenyListKind_DENY_LIST_KIND_IP           DenyListKind = 1
+	DenyListKind_DENY_LIST_KIND_EMAIL_DOMAIN DenyListKind = 2
+	DenyListKind_DENY_LIST_KIND_DEVICE       DenyListKind = 3
+)
+
+// Enum value maps for DenyListKind.
+var (
+	DenyListKind_name = map[int32]string{
+		0: "DENY_LIST_KIND_UNSPECIFIED",
+		1: "DENY_LIST_KIND_IP",
+		2: "DENY_LIST_KIND_EMAIL_DOMAIN",
+		3: "DENY_LIST_KIND_DEVICE",
+	}
+	DenyListKind_value = map[string]int32{
+		"DENY_LIST_KIND_UNSPECIFIED":  0,
+		"DENY_LIST_KIND_IP":           1,
+		"DENY_LIST_KIND_EMAIL_DOMAIN": 2,
+		"DENY_LIST_KIND_DEVICE":       3,
+	}
+)
+
+func (x DenyListKind) Enum() *DenyListKind {
+	p := new(DenyListKind)
+	*p = x
+	return p
+}
+
+func (x DenyListKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DenyListKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_user_proto_enumTypes[11].Descriptor()
+}
+
+func (DenyListKind) Type() protoreflect.EnumType {
+	return &file_v1_user_proto_enumTypes[11]
+}
+
+func (x DenyListKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DenyListKind.Descriptor instead.
+func (DenyListKind) EnumDescriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{11}
+}
+
 // ===== Сообщения пользователя =====
 type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ServiceEmail  string                 `protobuf:"bytes,2,opt,name=service_email,json=serviceEmail,proto3" json:"service_email,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"` // Уже хешированный пароль
-	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
-	Phone         string                 `protobuf:"bytes,6,opt,name=phone,proto3" json:"phone,omitempty"`
-	Status        UserStatus             `protobuf:"varint,7,opt,name=status,proto3,enum=users.UserStatus" json:"status,omitempty"`
-	Role          UserRole               `protobuf:"varint,8,opt,name=role,proto3,enum=users.UserRole" json:"role,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	LastLoginAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`
-	BanInfo       *BanInfo               `protobuf:"bytes,12,opt,name=ban_info,json=banInfo,proto3" json:"ban_info,omitempty"` // Информация о бане
-	Subscription  *SubscriptionInfo      `protobuf:"bytes,13,opt,name=subscription,proto3" json:"subscription,omitempty"`      // Информация о подписке
-	Metadata      map[string]string      `protobuf:"bytes,14,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceEmail    string                 `protobuf:"bytes,2,opt,name=service_email,json=serviceEmail,proto3" json:"service_email,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Password        string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"` // Уже хешированный пароль
+	Email           string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Phone           string                 `protobuf:"bytes,6,opt,name=phone,proto3" json:"phone,omitempty"`
+	Status          UserStatus             `protobuf:"varint,7,opt,name=status,proto3,enum=users.UserStatus" json:"status,omitempty"`
+	Role            UserRole               `protobuf:"varint,8,opt,name=role,proto3,enum=users.UserRole" json:"role,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	LastLoginAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`
+	BanInfo         *BanInfo               `protobuf:"bytes,12,opt,name=ban_info,json=banInfo,proto3" json:"ban_info,omitempty"` // Информация о бане
+	Subscription    *SubscriptionInfo      `protobuf:"bytes,13,opt,name=subscription,proto3" json:"subscription,omitempty"`      // Информация о подписке
+	Metadata        map[string]string      `protobuf:"bytes,14,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	PhoneVerifiedAt *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=phone_verified_at,json=phoneVerifiedAt,proto3" json:"phone_verified_at,omitempty"` // null, если телефон не подтвержден
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -462,6 +899,13 @@ func (x *User) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *User) GetPhoneVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PhoneVerifiedAt
+	}
+	return nil
+}
+
 // Информация о бане пользователя
 type BanInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -470,6 +914,8 @@ type BanInfo struct {
 	BannedUntil   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=banned_until,json=bannedUntil,proto3" json:"banned_until,omitempty"` // Для временного бана
 	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	BannedBy      string                 `protobuf:"bytes,5,opt,name=banned_by,json=bannedBy,proto3" json:"banned_by,omitempty"` // ID администратора, который забанил
+	Category      BanCategory            `protobuf:"varint,6,opt,name=category,proto3,enum=users.BanCategory" json:"category,omitempty"`
+	ReportId      string                 `protobuf:"bytes,7,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"` // Жалоба, по которой выдан бан
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -539,6 +985,20 @@ func (x *BanInfo) GetBannedBy() string {
 	return ""
 }
 
+func (x *BanInfo) GetCategory() BanCategory {
+	if x != nil {
+		return x.Category
+	}
+	return BanCategory_BAN_CATEGORY_UNSPECIFIED
+}
+
+func (x *BanInfo) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
 // Информация о подписке пользователя
 type SubscriptionInfo struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -1040,6 +1500,7 @@ type ListUsersRequest struct {
 	SubscriptionStatus    *SubscriptionStatus    `protobuf:"varint,7,opt,name=subscription_status,json=subscriptionStatus,proto3,enum=users.SubscriptionStatus,oneof" json:"subscription_status,omitempty"` // Фильтр по статусу подписки
 	SubscriptionLevel     *SubscriptionLevel     `protobuf:"varint,8,opt,name=subscription_level,json=subscriptionLevel,proto3,enum=users.SubscriptionLevel,oneof" json:"subscription_level,omitempty"`     // Фильтр по уровню подписки
 	HasActiveSubscription *bool                  `protobuf:"varint,9,opt,name=has_active_subscription,json=hasActiveSubscription,proto3,oneof" json:"has_active_subscription,omitempty"`                    // Фильтр по активным подпискам
+	BanCategory           *BanCategory           `protobuf:"varint,10,opt,name=ban_category,json=banCategory,proto3,enum=users.BanCategory,oneof" json:"ban_category,omitempty"`                            // Фильтр по категории действующего бана
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -1137,6 +1598,13 @@ func (x *ListUsersRequest) GetHasActiveSubscription() bool {
 	return false
 }
 
+func (x *ListUsersRequest) GetBanCategory() BanCategory {
+	if x != nil && x.BanCategory != nil {
+		return *x.BanCategory
+	}
+	return BanCategory_BAN_CATEGORY_UNSPECIFIED
+}
+
 // ===== Аутентификация =====
 type AuthenticateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1191,12 +1659,16 @@ func (x *AuthenticateRequest) GetPassword() string {
 }
 
 type AuthenticateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Token            string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	User             *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	RefreshToken     string                 `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	RefreshExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=refresh_expires_at,json=refreshExpiresAt,proto3" json:"refresh_expires_at,omitempty"`
+	MfaRequired      bool                   `protobuf:"varint,6,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"` // Токены не выданы, нужен VerifyMFA
+	MfaToken         string                 `protobuf:"bytes,7,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`           // Одноразовый токен для VerifyMFA
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *AuthenticateResponse) Reset() {
@@ -1250,20 +1722,220 @@ func (x *AuthenticateResponse) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type ValidateTokenRequest struct {
+func (x *AuthenticateResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *AuthenticateResponse) GetRefreshExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshExpiresAt
+	}
+	return nil
+}
+
+func (x *AuthenticateResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *AuthenticateResponse) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+type VerifyMFARequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	MfaToken      string                 `protobuf:"bytes,1,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // TOTP код или код восстановления
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateTokenRequest) Reset() {
-	*x = ValidateTokenRequest{}
+func (x *VerifyMFARequest) Reset() {
+	*x = VerifyMFARequest{}
 	mi := &file_v1_user_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
+func (x *VerifyMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFARequest) ProtoMessage() {}
+
+func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFARequest.ProtoReflect.Descriptor instead.
+func (*VerifyMFARequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VerifyMFARequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *VerifyMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_v1_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RefreshTokenResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Token            string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	RefreshToken     string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	RefreshExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=refresh_expires_at,json=refreshExpiresAt,proto3" json:"refresh_expires_at,omitempty"`
+	User             *User                  `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	mi := &file_v1_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RefreshTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *RefreshTokenResponse) GetRefreshExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshExpiresAt
+	}
+	return nil
+}
+
+func (x *RefreshTokenResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ValidateTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokenRequest) Reset() {
+	*x = ValidateTokenRequest{}
+	mi := &file_v1_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
 func (x *ValidateTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
@@ -1271,7 +1943,7 @@ func (x *ValidateTokenRequest) String() string {
 func (*ValidateTokenRequest) ProtoMessage() {}
 
 func (x *ValidateTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_user_proto_msgTypes[11]
+	mi := &file_v1_user_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1284,7 +1956,7 @@ func (x *ValidateTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateTokenRequest.ProtoReflect.Descriptor instead.
 func (*ValidateTokenRequest) Descriptor() ([]byte, []int) {
-	return file_v1_user_proto_rawDescGZIP(), []int{11}
+	return file_v1_user_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ValidateTokenRequest) GetToken() string {
@@ -1304,7 +1976,7 @@ type ValidateTokenResponse struct {
 
 func (x *ValidateTokenResponse) Reset() {
 	*x = ValidateTokenResponse{}
-	mi := &file_v1_user_proto_msgTypes[12]
+	mi := &file_v1_user_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1316,7 +1988,7 @@ func (x *ValidateTokenResponse) String() string {
 func (*ValidateTokenResponse) ProtoMessage() {}
 
 func (x *ValidateTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_user_proto_msgTypes[12]
+	mi := &file_v1_user_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1329,7 +2001,7 @@ func (x *ValidateTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateTokenResponse.ProtoReflect.Descriptor instead.
 func (*ValidateTokenResponse) Descriptor() ([]byte, []int) {
-	return file_v1_user_proto_rawDescGZIP(), []int{12}
+	return file_v1_user_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ValidateTokenResponse) GetValid() bool {
@@ -1346,32 +2018,4818 @@ func (x *ValidateTokenResponse) GetUser() *User {
 	return nil
 }
 
-// ===== Бан-система =====
-type BanUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	BannedUntil   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=banned_until,json=bannedUntil,proto3,oneof" json:"banned_until,omitempty"` // Для временного бана
-	BannedBy      string                 `protobuf:"bytes,4,opt,name=banned_by,json=bannedBy,proto3" json:"banned_by,omitempty"`                // ID администратора
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                         // Access токен текущей сессии
+	RefreshToken  *string                `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3,oneof" json:"refresh_token,omitempty"` // Refresh токен сессии, если есть
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_v1_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LogoutRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
+	if x != nil && x.RefreshToken != nil {
+		return *x.RefreshToken
+	}
+	return ""
+}
+
+type RevokeAllSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllSessionsRequest) Reset() {
+	*x = RevokeAllSessionsRequest{}
+	mi := &file_v1_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeAllSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RevokeAllSessionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// ===== Подтверждение email =====
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Токен из письма
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_v1_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *VerifyEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ResendVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationRequest) Reset() {
+	*x = ResendVerificationRequest{}
+	mi := &file_v1_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationRequest) ProtoMessage() {}
+
+func (x *ResendVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendVerificationRequest.ProtoReflect.Descriptor instead.
+func (*ResendVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ResendVerificationRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// ===== Подтверждение телефона =====
+type SendPhoneOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendPhoneOTPRequest) Reset() {
+	*x = SendPhoneOTPRequest{}
+	mi := &file_v1_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendPhoneOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendPhoneOTPRequest) ProtoMessage() {}
+
+func (x *SendPhoneOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendPhoneOTPRequest.ProtoReflect.Descriptor instead.
+func (*SendPhoneOTPRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SendPhoneOTPRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type SendPhoneOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Когда истекает отправленный код
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendPhoneOTPResponse) Reset() {
+	*x = SendPhoneOTPResponse{}
+	mi := &file_v1_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendPhoneOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendPhoneOTPResponse) ProtoMessage() {}
+
+func (x *SendPhoneOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendPhoneOTPResponse.ProtoReflect.Descriptor instead.
+func (*SendPhoneOTPResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SendPhoneOTPResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type VerifyPhoneOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPhoneOTPRequest) Reset() {
+	*x = VerifyPhoneOTPRequest{}
+	mi := &file_v1_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPhoneOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPhoneOTPRequest) ProtoMessage() {}
+
+func (x *VerifyPhoneOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPhoneOTPRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPhoneOTPRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VerifyPhoneOTPRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *VerifyPhoneOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// ===== Двухфакторная аутентификация =====
+type EnrollTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
+	mi := &file_v1_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPRequest) ProtoMessage() {}
+
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EnrollTOTPRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type EnrollTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`                           // base32, для ручного ввода
+	OtpauthUri    string                 `protobuf:"bytes,2,opt,name=otpauth_uri,json=otpauthUri,proto3" json:"otpauth_uri,omitempty"` // Для QR кода
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPResponse) Reset() {
+	*x = EnrollTOTPResponse{}
+	mi := &file_v1_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPResponse) ProtoMessage() {}
+
+func (x *EnrollTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EnrollTOTPResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTOTPResponse) GetOtpauthUri() string {
+	if x != nil {
+		return x.OtpauthUri
+	}
+	return ""
+}
+
+type ConfirmTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // Первый код из приложения
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
+	mi := &file_v1_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPRequest) ProtoMessage() {}
+
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ConfirmTOTPRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ConfirmTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"` // Показываются один раз
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPResponse) Reset() {
+	*x = ConfirmTOTPResponse{}
+	mi := &file_v1_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPResponse) ProtoMessage() {}
+
+func (x *ConfirmTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ConfirmTOTPResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type DisableTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // TOTP код или код восстановления
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
+	mi := &file_v1_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPRequest) ProtoMessage() {}
+
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DisableTOTPRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DisableTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// ===== WebAuthn =====
+// Ключ доступа пользователя (passkey или аппаратный ключ)
+type WebAuthnCredential struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Transports     []string               `protobuf:"bytes,3,rep,name=transports,proto3" json:"transports,omitempty"`                                // usb, nfc, ble, internal, hybrid
+	BackupEligible bool                   `protobuf:"varint,4,opt,name=backup_eligible,json=backupEligible,proto3" json:"backup_eligible,omitempty"` // Синхронизируемый passkey
+	SignCount      uint32                 `protobuf:"varint,5,opt,name=sign_count,json=signCount,proto3" json:"sign_count,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebAuthnCredential) Reset() {
+	*x = WebAuthnCredential{}
+	mi := &file_v1_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebAuthnCredential) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebAuthnCredential) ProtoMessage() {}
+
+func (x *WebAuthnCredential) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebAuthnCredential.ProtoReflect.Descriptor instead.
+func (*WebAuthnCredential) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *WebAuthnCredential) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebAuthnCredential) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WebAuthnCredential) GetTransports() []string {
+	if x != nil {
+		return x.Transports
+	}
+	return nil
+}
+
+func (x *WebAuthnCredential) GetBackupEligible() bool {
+	if x != nil {
+		return x.BackupEligible
+	}
+	return false
+}
+
+func (x *WebAuthnCredential) GetSignCount() uint32 {
+	if x != nil {
+		return x.SignCount
+	}
+	return 0
+}
+
+func (x *WebAuthnCredential) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebAuthnCredential) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+type BeginWebAuthnRegistrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginWebAuthnRegistrationRequest) Reset() {
+	*x = BeginWebAuthnRegistrationRequest{}
+	mi := &file_v1_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginWebAuthnRegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginWebAuthnRegistrationRequest) ProtoMessage() {}
+
+func (x *BeginWebAuthnRegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginWebAuthnRegistrationRequest.ProtoReflect.Descriptor instead.
+func (*BeginWebAuthnRegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BeginWebAuthnRegistrationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type BeginWebAuthnLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"` // Пусто для входа по passkey без ввода email
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginWebAuthnLoginRequest) Reset() {
+	*x = BeginWebAuthnLoginRequest{}
+	mi := &file_v1_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginWebAuthnLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginWebAuthnLoginRequest) ProtoMessage() {}
+
+func (x *BeginWebAuthnLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginWebAuthnLoginRequest.ProtoReflect.Descriptor instead.
+func (*BeginWebAuthnLoginRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *BeginWebAuthnLoginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type BeginWebAuthnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	OptionsJson   string                 `protobuf:"bytes,2,opt,name=options_json,json=optionsJson,proto3" json:"options_json,omitempty"` // JSON для navigator.credentials.create() / get()
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginWebAuthnResponse) Reset() {
+	*x = BeginWebAuthnResponse{}
+	mi := &file_v1_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginWebAuthnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginWebAuthnResponse) ProtoMessage() {}
+
+func (x *BeginWebAuthnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginWebAuthnResponse.ProtoReflect.Descriptor instead.
+func (*BeginWebAuthnResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BeginWebAuthnResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *BeginWebAuthnResponse) GetOptionsJson() string {
+	if x != nil {
+		return x.OptionsJson
+	}
+	return ""
+}
+
+type FinishWebAuthnRegistrationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId      string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	CredentialJson string                 `protobuf:"bytes,3,opt,name=credential_json,json=credentialJson,proto3" json:"credential_json,omitempty"` // JSON ответа navigator.credentials.create()
+	Name           string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`                                           // Название ключа, необязательно
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FinishWebAuthnRegistrationRequest) Reset() {
+	*x = FinishWebAuthnRegistrationRequest{}
+	mi := &file_v1_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishWebAuthnRegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishWebAuthnRegistrationRequest) ProtoMessage() {}
+
+func (x *FinishWebAuthnRegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishWebAuthnRegistrationRequest.ProtoReflect.Descriptor instead.
+func (*FinishWebAuthnRegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *FinishWebAuthnRegistrationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnRegistrationRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnRegistrationRequest) GetCredentialJson() string {
+	if x != nil {
+		return x.CredentialJson
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnRegistrationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type FinishWebAuthnLoginRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SessionId      string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	CredentialJson string                 `protobuf:"bytes,2,opt,name=credential_json,json=credentialJson,proto3" json:"credential_json,omitempty"` // JSON ответа navigator.credentials.get()
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FinishWebAuthnLoginRequest) Reset() {
+	*x = FinishWebAuthnLoginRequest{}
+	mi := &file_v1_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishWebAuthnLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishWebAuthnLoginRequest) ProtoMessage() {}
+
+func (x *FinishWebAuthnLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishWebAuthnLoginRequest.ProtoReflect.Descriptor instead.
+func (*FinishWebAuthnLoginRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *FinishWebAuthnLoginRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnLoginRequest) GetCredentialJson() string {
+	if x != nil {
+		return x.CredentialJson
+	}
+	return ""
+}
+
+type ListWebAuthnCredentialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebAuthnCredentialsRequest) Reset() {
+	*x = ListWebAuthnCredentialsRequest{}
+	mi := &file_v1_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebAuthnCredentialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebAuthnCredentialsRequest) ProtoMessage() {}
+
+func (x *ListWebAuthnCredentialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebAuthnCredentialsRequest.ProtoReflect.Descriptor instead.
+func (*ListWebAuthnCredentialsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListWebAuthnCredentialsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListWebAuthnCredentialsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Credentials   []*WebAuthnCredential  `protobuf:"bytes,1,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebAuthnCredentialsResponse) Reset() {
+	*x = ListWebAuthnCredentialsResponse{}
+	mi := &file_v1_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebAuthnCredentialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebAuthnCredentialsResponse) ProtoMessage() {}
+
+func (x *ListWebAuthnCredentialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebAuthnCredentialsResponse.ProtoReflect.Descriptor instead.
+func (*ListWebAuthnCredentialsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListWebAuthnCredentialsResponse) GetCredentials() []*WebAuthnCredential {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+type RenameWebAuthnCredentialRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CredentialId  string                 `protobuf:"bytes,2,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameWebAuthnCredentialRequest) Reset() {
+	*x = RenameWebAuthnCredentialRequest{}
+	mi := &file_v1_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameWebAuthnCredentialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameWebAuthnCredentialRequest) ProtoMessage() {}
+
+func (x *RenameWebAuthnCredentialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameWebAuthnCredentialRequest.ProtoReflect.Descriptor instead.
+func (*RenameWebAuthnCredentialRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RenameWebAuthnCredentialRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RenameWebAuthnCredentialRequest) GetCredentialId() string {
+	if x != nil {
+		return x.CredentialId
+	}
+	return ""
+}
+
+func (x *RenameWebAuthnCredentialRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteWebAuthnCredentialRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CredentialId  string                 `protobuf:"bytes,2,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebAuthnCredentialRequest) Reset() {
+	*x = DeleteWebAuthnCredentialRequest{}
+	mi := &file_v1_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebAuthnCredentialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebAuthnCredentialRequest) ProtoMessage() {}
+
+func (x *DeleteWebAuthnCredentialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebAuthnCredentialRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebAuthnCredentialRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeleteWebAuthnCredentialRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteWebAuthnCredentialRequest) GetCredentialId() string {
+	if x != nil {
+		return x.CredentialId
+	}
+	return ""
+}
+
+// ===== API ключи =====
+type APIKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Prefix        string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"` // Открытая часть ключа, например usk_abcd1234
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"` // Пусто - все права владельца
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_v1_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *APIKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`                        // Права из списка прав роли, например users.read; без self.write ключ не меняет аккаунт владельца
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Пусто - бессрочный ключ
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_v1_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateAPIKeyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        *APIKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"` // Полный ключ, показывается только один раз
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyResponse) Reset() {
+	*x = CreateAPIKeyResponse{}
+	mi := &file_v1_user_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyResponse) ProtoMessage() {}
+
+func (x *CreateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreateAPIKeyResponse) GetApiKey() *APIKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type ListAPIKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_v1_user_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListAPIKeysRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*APIKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_v1_user_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListAPIKeysResponse) GetApiKeys() []*APIKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+type RevokeAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ApiKeyId      string                 `protobuf:"bytes,2,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_v1_user_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RevokeAPIKeyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RevokeAPIKeyRequest) GetApiKeyId() string {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return ""
+}
+
+// ===== Имперсонация =====
+type ImpersonateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Обязательно, например номер обращения в поддержку
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateUserRequest) Reset() {
+	*x = ImpersonateUserRequest{}
+	mi := &file_v1_user_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateUserRequest) ProtoMessage() {}
+
+func (x *ImpersonateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateUserRequest.ProtoReflect.Descriptor instead.
+func (*ImpersonateUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ImpersonateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ImpersonateUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ImpersonateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Access токен пользователя, refresh не выдается
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateUserResponse) Reset() {
+	*x = ImpersonateUserResponse{}
+	mi := &file_v1_user_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateUserResponse) ProtoMessage() {}
+
+func (x *ImpersonateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateUserResponse.ProtoReflect.Descriptor instead.
+func (*ImpersonateUserResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ImpersonateUserResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ImpersonateUserResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ImpersonateUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type StopImpersonationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopImpersonationRequest) Reset() {
+	*x = StopImpersonationRequest{}
+	mi := &file_v1_user_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopImpersonationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopImpersonationRequest) ProtoMessage() {}
+
+func (x *StopImpersonationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopImpersonationRequest.ProtoReflect.Descriptor instead.
+func (*StopImpersonationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{46}
+}
+
+// ===== Сервисные аккаунты =====
+type IssueServiceTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret  string                 `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	Audience      string                 `protobuf:"bytes,3,opt,name=audience,proto3" json:"audience,omitempty"` // Сервис, к которому будет обращаться клиент
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueServiceTokenRequest) Reset() {
+	*x = IssueServiceTokenRequest{}
+	mi := &file_v1_user_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueServiceTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueServiceTokenRequest) ProtoMessage() {}
+
+func (x *IssueServiceTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueServiceTokenRequest.ProtoReflect.Descriptor instead.
+func (*IssueServiceTokenRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *IssueServiceTokenRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *IssueServiceTokenRequest) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *IssueServiceTokenRequest) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+type IssueServiceTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Audience      string                 `protobuf:"bytes,3,opt,name=audience,proto3" json:"audience,omitempty"`
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"` // Права сервисного аккаунта
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueServiceTokenResponse) Reset() {
+	*x = IssueServiceTokenResponse{}
+	mi := &file_v1_user_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueServiceTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueServiceTokenResponse) ProtoMessage() {}
+
+func (x *IssueServiceTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueServiceTokenResponse.ProtoReflect.Descriptor instead.
+func (*IssueServiceTokenResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *IssueServiceTokenResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *IssueServiceTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *IssueServiceTokenResponse) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *IssueServiceTokenResponse) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+// ===== Сброс пароля =====
+type RequestPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_v1_user_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RequestPasswordResetRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ConfirmPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Токен из письма
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPasswordResetRequest) Reset() {
+	*x = ConfirmPasswordResetRequest{}
+	mi := &file_v1_user_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPasswordResetRequest) ProtoMessage() {}
+
+func (x *ConfirmPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ConfirmPasswordResetRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ConfirmPasswordResetRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ClearLockoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`                          // Снять блокировку аккаунта
+	IpAddress     string                 `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"` // Снять блокировку IP адреса
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearLockoutRequest) Reset() {
+	*x = ClearLockoutRequest{}
+	mi := &file_v1_user_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearLockoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearLockoutRequest) ProtoMessage() {}
+
+func (x *ClearLockoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearLockoutRequest.ProtoReflect.Descriptor instead.
+func (*ClearLockoutRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ClearLockoutRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ClearLockoutRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+// ===== Бан-система =====
+type BanUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	BannedUntil   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=banned_until,json=bannedUntil,proto3,oneof" json:"banned_until,omitempty"` // Для временного бана
+	BannedBy      string                 `protobuf:"bytes,4,opt,name=banned_by,json=bannedBy,proto3" json:"banned_by,omitempty"`                // Игнорируется: администратор определяется по токену
+	Category      BanCategory            `protobuf:"varint,5,opt,name=category,proto3,enum=users.BanCategory" json:"category,omitempty"`        // UNSPECIFIED - бан без категории, SYSTEM недоступна
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanUserRequest) Reset() {
+	*x = BanUserRequest{}
+	mi := &file_v1_user_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanUserRequest) ProtoMessage() {}
+
+func (x *BanUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanUserRequest.ProtoReflect.Descriptor instead.
+func (*BanUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *BanUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BanUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BanUserRequest) GetBannedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BannedUntil
+	}
+	return nil
+}
+
+func (x *BanUserRequest) GetBannedBy() string {
+	if x != nil {
+		return x.BannedBy
+	}
+	return ""
+}
+
+func (x *BanUserRequest) GetCategory() BanCategory {
+	if x != nil {
+		return x.Category
+	}
+	return BanCategory_BAN_CATEGORY_UNSPECIFIED
+}
+
+type UnbanUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UnbannedBy    string                 `protobuf:"bytes,2,opt,name=unbanned_by,json=unbannedBy,proto3" json:"unbanned_by,omitempty"` // Игнорируется: администратор определяется по токену
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbanUserRequest) Reset() {
+	*x = UnbanUserRequest{}
+	mi := &file_v1_user_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbanUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbanUserRequest) ProtoMessage() {}
+
+func (x *UnbanUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbanUserRequest.ProtoReflect.Descriptor instead.
+func (*UnbanUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *UnbanUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnbanUserRequest) GetUnbannedBy() string {
+	if x != nil {
+		return x.UnbannedBy
+	}
+	return ""
+}
+
+type GetBanStatisticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBanStatisticsRequest) Reset() {
+	*x = GetBanStatisticsRequest{}
+	mi := &file_v1_user_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBanStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBanStatisticsRequest) ProtoMessage() {}
+
+func (x *GetBanStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBanStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetBanStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{54}
+}
+
+// Действующие баны одной категории
+type BanCategoryStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      BanCategory            `protobuf:"varint,1,opt,name=category,proto3,enum=users.BanCategory" json:"category,omitempty"` // UNSPECIFIED - баны без категории
+	Active        int64                  `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	Temporary     int64                  `protobuf:"varint,3,opt,name=temporary,proto3" json:"temporary,omitempty"`
+	Permanent     int64                  `protobuf:"varint,4,opt,name=permanent,proto3" json:"permanent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanCategoryStats) Reset() {
+	*x = BanCategoryStats{}
+	mi := &file_v1_user_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanCategoryStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanCategoryStats) ProtoMessage() {}
+
+func (x *BanCategoryStats) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanCategoryStats.ProtoReflect.Descriptor instead.
+func (*BanCategoryStats) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *BanCategoryStats) GetCategory() BanCategory {
+	if x != nil {
+		return x.Category
+	}
+	return BanCategory_BAN_CATEGORY_UNSPECIFIED
+}
+
+func (x *BanCategoryStats) GetActive() int64 {
+	if x != nil {
+		return x.Active
+	}
+	return 0
+}
+
+func (x *BanCategoryStats) GetTemporary() int64 {
+	if x != nil {
+		return x.Temporary
+	}
+	return 0
+}
+
+func (x *BanCategoryStats) GetPermanent() int64 {
+	if x != nil {
+		return x.Permanent
+	}
+	return 0
+}
+
+type BanStatisticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*BanCategoryStats    `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	TotalActive   int64                  `protobuf:"varint,2,opt,name=total_active,json=totalActive,proto3" json:"total_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanStatisticsResponse) Reset() {
+	*x = BanStatisticsResponse{}
+	mi := &file_v1_user_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanStatisticsResponse) ProtoMessage() {}
+
+func (x *BanStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*BanStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *BanStatisticsResponse) GetCategories() []*BanCategoryStats {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *BanStatisticsResponse) GetTotalActive() int64 {
+	if x != nil {
+		return x.TotalActive
+	}
+	return 0
+}
+
+// Предупреждение модератора, учитывается в эскалации до expires_at
+type Strike struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	IssuedBy      string                 `protobuf:"bytes,4,opt,name=issued_by,json=issuedBy,proto3" json:"issued_by,omitempty"`
+	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Strike) Reset() {
+	*x = Strike{}
+	mi := &file_v1_user_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Strike) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Strike) ProtoMessage() {}
+
+func (x *Strike) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Strike.ProtoReflect.Descriptor instead.
+func (*Strike) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Strike) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Strike) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Strike) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Strike) GetIssuedBy() string {
+	if x != nil {
+		return x.IssuedBy
+	}
+	return ""
+}
+
+func (x *Strike) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *Strike) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type IssueWarningRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueWarningRequest) Reset() {
+	*x = IssueWarningRequest{}
+	mi := &file_v1_user_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueWarningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueWarningRequest) ProtoMessage() {}
+
+func (x *IssueWarningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueWarningRequest.ProtoReflect.Descriptor instead.
+func (*IssueWarningRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *IssueWarningRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *IssueWarningRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type IssueWarningResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Strike        *Strike                `protobuf:"bytes,1,opt,name=strike,proto3" json:"strike,omitempty"`
+	ActiveStrikes int32                  `protobuf:"varint,2,opt,name=active_strikes,json=activeStrikes,proto3" json:"active_strikes,omitempty"`
+	Banned        bool                   `protobuf:"varint,3,opt,name=banned,proto3" json:"banned,omitempty"` // Сработала эскалация, бан в user.ban_info
+	User          *User                  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueWarningResponse) Reset() {
+	*x = IssueWarningResponse{}
+	mi := &file_v1_user_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueWarningResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueWarningResponse) ProtoMessage() {}
+
+func (x *IssueWarningResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueWarningResponse.ProtoReflect.Descriptor instead.
+func (*IssueWarningResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *IssueWarningResponse) GetStrike() *Strike {
+	if x != nil {
+		return x.Strike
+	}
+	return nil
+}
+
+func (x *IssueWarningResponse) GetActiveStrikes() int32 {
+	if x != nil {
+		return x.ActiveStrikes
+	}
+	return 0
+}
+
+func (x *IssueWarningResponse) GetBanned() bool {
+	if x != nil {
+		return x.Banned
+	}
+	return false
+}
+
+func (x *IssueWarningResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ListStrikesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStrikesRequest) Reset() {
+	*x = ListStrikesRequest{}
+	mi := &file_v1_user_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStrikesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStrikesRequest) ProtoMessage() {}
+
+func (x *ListStrikesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStrikesRequest.ProtoReflect.Descriptor instead.
+func (*ListStrikesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListStrikesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListStrikesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Strikes       []*Strike              `protobuf:"bytes,1,rep,name=strikes,proto3" json:"strikes,omitempty"` // Только действующие
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStrikesResponse) Reset() {
+	*x = ListStrikesResponse{}
+	mi := &file_v1_user_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStrikesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStrikesResponse) ProtoMessage() {}
+
+func (x *ListStrikesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStrikesResponse.ProtoReflect.Descriptor instead.
+func (*ListStrikesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListStrikesResponse) GetStrikes() []*Strike {
+	if x != nil {
+		return x.Strikes
+	}
+	return nil
+}
+
+// Апелляция на бан, не больше одной на каждый бан
+type BanAppeal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BannedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=banned_at,json=bannedAt,proto3" json:"banned_at,omitempty"` // Начало обжалуемого бана
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Status        AppealStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=users.AppealStatus" json:"status,omitempty"`
+	AssignedTo    string                 `protobuf:"bytes,6,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	ResolvedBy    string                 `protobuf:"bytes,7,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	Resolution    string                 `protobuf:"bytes,8,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ResolvedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=resolved_at,json=resolvedAt,proto3,oneof" json:"resolved_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanAppeal) Reset() {
+	*x = BanAppeal{}
+	mi := &file_v1_user_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanAppeal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanAppeal) ProtoMessage() {}
+
+func (x *BanAppeal) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanAppeal.ProtoReflect.Descriptor instead.
+func (*BanAppeal) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *BanAppeal) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BanAppeal) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BanAppeal) GetBannedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BannedAt
+	}
+	return nil
+}
+
+func (x *BanAppeal) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BanAppeal) GetStatus() AppealStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AppealStatus_APPEAL_STATUS_UNSPECIFIED
+}
+
+func (x *BanAppeal) GetAssignedTo() string {
+	if x != nil {
+		return x.AssignedTo
+	}
+	return ""
+}
+
+func (x *BanAppeal) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *BanAppeal) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *BanAppeal) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *BanAppeal) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *BanAppeal) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+type SubmitBanAppealRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppealToken   string                 `protobuf:"bytes,1,opt,name=appeal_token,json=appealToken,proto3" json:"appeal_token,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitBanAppealRequest) Reset() {
+	*x = SubmitBanAppealRequest{}
+	mi := &file_v1_user_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitBanAppealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitBanAppealRequest) ProtoMessage() {}
+
+func (x *SubmitBanAppealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitBanAppealRequest.ProtoReflect.Descriptor instead.
+func (*SubmitBanAppealRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SubmitBanAppealRequest) GetAppealToken() string {
+	if x != nil {
+		return x.AppealToken
+	}
+	return ""
+}
+
+func (x *SubmitBanAppealRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListBanAppealsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Status        *AppealStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=users.AppealStatus,oneof" json:"status,omitempty"` // По умолчанию - все открытые
+	AssignedTo    *string                `protobuf:"bytes,4,opt,name=assigned_to,json=assignedTo,proto3,oneof" json:"assigned_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBanAppealsRequest) Reset() {
+	*x = ListBanAppealsRequest{}
+	mi := &file_v1_user_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBanAppealsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBanAppealsRequest) ProtoMessage() {}
+
+func (x *ListBanAppealsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBanAppealsRequest.ProtoReflect.Descriptor instead.
+func (*ListBanAppealsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListBanAppealsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBanAppealsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBanAppealsRequest) GetStatus() AppealStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return AppealStatus_APPEAL_STATUS_UNSPECIFIED
+}
+
+func (x *ListBanAppealsRequest) GetAssignedTo() string {
+	if x != nil && x.AssignedTo != nil {
+		return *x.AssignedTo
+	}
+	return ""
+}
+
+type ListBanAppealsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Appeals       []*BanAppeal           `protobuf:"bytes,1,rep,name=appeals,proto3" json:"appeals,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBanAppealsResponse) Reset() {
+	*x = ListBanAppealsResponse{}
+	mi := &file_v1_user_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBanAppealsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBanAppealsResponse) ProtoMessage() {}
+
+func (x *ListBanAppealsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBanAppealsResponse.ProtoReflect.Descriptor instead.
+func (*ListBanAppealsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListBanAppealsResponse) GetAppeals() []*BanAppeal {
+	if x != nil {
+		return x.Appeals
+	}
+	return nil
+}
+
+func (x *ListBanAppealsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListBanAppealsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBanAppealsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type AssignBanAppealRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppealId      string                 `protobuf:"bytes,1,opt,name=appeal_id,json=appealId,proto3" json:"appeal_id,omitempty"`
+	ModeratorId   string                 `protobuf:"bytes,2,opt,name=moderator_id,json=moderatorId,proto3" json:"moderator_id,omitempty"` // Пусто - назначить себе
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignBanAppealRequest) Reset() {
+	*x = AssignBanAppealRequest{}
+	mi := &file_v1_user_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignBanAppealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignBanAppealRequest) ProtoMessage() {}
+
+func (x *AssignBanAppealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignBanAppealRequest.ProtoReflect.Descriptor instead.
+func (*AssignBanAppealRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *AssignBanAppealRequest) GetAppealId() string {
+	if x != nil {
+		return x.AppealId
+	}
+	return ""
+}
+
+func (x *AssignBanAppealRequest) GetModeratorId() string {
+	if x != nil {
+		return x.ModeratorId
+	}
+	return ""
+}
+
+type ResolveBanAppealRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppealId      string                 `protobuf:"bytes,1,opt,name=appeal_id,json=appealId,proto3" json:"appeal_id,omitempty"`
+	Resolution    string                 `protobuf:"bytes,2,opt,name=resolution,proto3" json:"resolution,omitempty"` // Комментарий к решению
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveBanAppealRequest) Reset() {
+	*x = ResolveBanAppealRequest{}
+	mi := &file_v1_user_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveBanAppealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveBanAppealRequest) ProtoMessage() {}
+
+func (x *ResolveBanAppealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveBanAppealRequest.ProtoReflect.Descriptor instead.
+func (*ResolveBanAppealRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ResolveBanAppealRequest) GetAppealId() string {
+	if x != nil {
+		return x.AppealId
+	}
+	return ""
+}
+
+func (x *ResolveBanAppealRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+// Жалоба на пользователя. Жалобы разных заявителей одной категории
+// объединяются, пока жалоба открыта.
+type UserReport struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReportedUserId string                 `protobuf:"bytes,2,opt,name=reported_user_id,json=reportedUserId,proto3" json:"reported_user_id,omitempty"`
+	Category       ReportCategory         `protobuf:"varint,3,opt,name=category,proto3,enum=users.ReportCategory" json:"category,omitempty"`
+	Status         ReportStatus           `protobuf:"varint,4,opt,name=status,proto3,enum=users.ReportStatus" json:"status,omitempty"`
+	ReportCount    int32                  `protobuf:"varint,5,opt,name=report_count,json=reportCount,proto3" json:"report_count,omitempty"` // Число заявителей
+	Priority       int32                  `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	ClaimedBy      string                 `protobuf:"bytes,7,opt,name=claimed_by,json=claimedBy,proto3" json:"claimed_by,omitempty"`
+	ClaimedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=claimed_at,json=claimedAt,proto3,oneof" json:"claimed_at,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Action         ReportAction           `protobuf:"varint,11,opt,name=action,proto3,enum=users.ReportAction" json:"action,omitempty"`
+	Resolution     string                 `protobuf:"bytes,12,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	ResolvedBy     string                 `protobuf:"bytes,13,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	ResolvedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=resolved_at,json=resolvedAt,proto3,oneof" json:"resolved_at,omitempty"`
+	BannedAt       *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=banned_at,json=bannedAt,proto3,oneof" json:"banned_at,omitempty"` // Начало выданного по жалобе бана
+	StrikeId       string                 `protobuf:"bytes,16,opt,name=strike_id,json=strikeId,proto3" json:"strike_id,omitempty"`
+	Entries        []*ReportEntry         `protobuf:"bytes,17,rep,name=entries,proto3" json:"entries,omitempty"` // Только в ответе ClaimReport
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UserReport) Reset() {
+	*x = UserReport{}
+	mi := &file_v1_user_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserReport) ProtoMessage() {}
+
+func (x *UserReport) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserReport.ProtoReflect.Descriptor instead.
+func (*UserReport) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *UserReport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserReport) GetReportedUserId() string {
+	if x != nil {
+		return x.ReportedUserId
+	}
+	return ""
+}
+
+func (x *UserReport) GetCategory() ReportCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ReportCategory_REPORT_CATEGORY_UNSPECIFIED
+}
+
+func (x *UserReport) GetStatus() ReportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *UserReport) GetReportCount() int32 {
+	if x != nil {
+		return x.ReportCount
+	}
+	return 0
+}
+
+func (x *UserReport) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *UserReport) GetClaimedBy() string {
+	if x != nil {
+		return x.ClaimedBy
+	}
+	return ""
+}
+
+func (x *UserReport) GetClaimedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClaimedAt
+	}
+	return nil
+}
+
+func (x *UserReport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserReport) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *UserReport) GetAction() ReportAction {
+	if x != nil {
+		return x.Action
+	}
+	return ReportAction_REPORT_ACTION_UNSPECIFIED
+}
+
+func (x *UserReport) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *UserReport) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *UserReport) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *UserReport) GetBannedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BannedAt
+	}
+	return nil
+}
+
+func (x *UserReport) GetStrikeId() string {
+	if x != nil {
+		return x.StrikeId
+	}
+	return ""
+}
+
+func (x *UserReport) GetEntries() []*ReportEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// Жалоба одного заявителя
+type ReportEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReporterId    string                 `protobuf:"bytes,1,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Evidence      map[string]string      `protobuf:"bytes,3,rep,name=evidence,proto3" json:"evidence,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Ссылки, ID сообщений и т.п.
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportEntry) Reset() {
+	*x = ReportEntry{}
+	mi := &file_v1_user_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportEntry) ProtoMessage() {}
+
+func (x *ReportEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportEntry.ProtoReflect.Descriptor instead.
+func (*ReportEntry) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ReportEntry) GetReporterId() string {
+	if x != nil {
+		return x.ReporterId
+	}
+	return ""
+}
+
+func (x *ReportEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ReportEntry) GetEvidence() map[string]string {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *ReportEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ReportUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Category      ReportCategory         `protobuf:"varint,2,opt,name=category,proto3,enum=users.ReportCategory" json:"category,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Evidence      map[string]string      `protobuf:"bytes,4,rep,name=evidence,proto3" json:"evidence,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportUserRequest) Reset() {
+	*x = ReportUserRequest{}
+	mi := &file_v1_user_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportUserRequest) ProtoMessage() {}
+
+func (x *ReportUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportUserRequest.ProtoReflect.Descriptor instead.
+func (*ReportUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ReportUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReportUserRequest) GetCategory() ReportCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ReportCategory_REPORT_CATEGORY_UNSPECIFIED
+}
+
+func (x *ReportUserRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ReportUserRequest) GetEvidence() map[string]string {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+type ReportUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Status        ReportStatus           `protobuf:"varint,2,opt,name=status,proto3,enum=users.ReportStatus" json:"status,omitempty"`
+	Duplicate     bool                   `protobuf:"varint,3,opt,name=duplicate,proto3" json:"duplicate,omitempty"` // Заявитель уже жаловался, жалоба не учтена повторно
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportUserResponse) Reset() {
+	*x = ReportUserResponse{}
+	mi := &file_v1_user_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportUserResponse) ProtoMessage() {}
+
+func (x *ReportUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportUserResponse.ProtoReflect.Descriptor instead.
+func (*ReportUserResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ReportUserResponse) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *ReportUserResponse) GetStatus() ReportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *ReportUserResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+type ListModerationQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Status        *ReportStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=users.ReportStatus,oneof" json:"status,omitempty"` // По умолчанию - все открытые
+	Category      *ReportCategory        `protobuf:"varint,4,opt,name=category,proto3,enum=users.ReportCategory,oneof" json:"category,omitempty"`
+	ClaimedBy     *string                `protobuf:"bytes,5,opt,name=claimed_by,json=claimedBy,proto3,oneof" json:"claimed_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModerationQueueRequest) Reset() {
+	*x = ListModerationQueueRequest{}
+	mi := &file_v1_user_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModerationQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModerationQueueRequest) ProtoMessage() {}
+
+func (x *ListModerationQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModerationQueueRequest.ProtoReflect.Descriptor instead.
+func (*ListModerationQueueRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListModerationQueueRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListModerationQueueRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListModerationQueueRequest) GetStatus() ReportStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *ListModerationQueueRequest) GetCategory() ReportCategory {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ReportCategory_REPORT_CATEGORY_UNSPECIFIED
+}
+
+func (x *ListModerationQueueRequest) GetClaimedBy() string {
+	if x != nil && x.ClaimedBy != nil {
+		return *x.ClaimedBy
+	}
+	return ""
+}
+
+type ListModerationQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reports       []*UserReport          `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"` // По убыванию приоритета
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModerationQueueResponse) Reset() {
+	*x = ListModerationQueueResponse{}
+	mi := &file_v1_user_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModerationQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModerationQueueResponse) ProtoMessage() {}
+
+func (x *ListModerationQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModerationQueueResponse.ProtoReflect.Descriptor instead.
+func (*ListModerationQueueResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListModerationQueueResponse) GetReports() []*UserReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+func (x *ListModerationQueueResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListModerationQueueResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListModerationQueueResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ClaimReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimReportRequest) Reset() {
+	*x = ClaimReportRequest{}
+	mi := &file_v1_user_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimReportRequest) ProtoMessage() {}
+
+func (x *ClaimReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimReportRequest.ProtoReflect.Descriptor instead.
+func (*ClaimReportRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ClaimReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+type ResolveReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Action        ReportAction           `protobuf:"varint,2,opt,name=action,proto3,enum=users.ReportAction" json:"action,omitempty"`
+	Resolution    string                 `protobuf:"bytes,3,opt,name=resolution,proto3" json:"resolution,omitempty"`                            // Комментарий, он же причина страйка или бана
+	BannedUntil   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=banned_until,json=bannedUntil,proto3,oneof" json:"banned_until,omitempty"` // Для BAN, пусто - перманентный бан
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveReportRequest) Reset() {
+	*x = ResolveReportRequest{}
+	mi := &file_v1_user_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveReportRequest) ProtoMessage() {}
+
+func (x *ResolveReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveReportRequest.ProtoReflect.Descriptor instead.
+func (*ResolveReportRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ResolveReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *ResolveReportRequest) GetAction() ReportAction {
+	if x != nil {
+		return x.Action
+	}
+	return ReportAction_REPORT_ACTION_UNSPECIFIED
+}
+
+func (x *ResolveReportRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *ResolveReportRequest) GetBannedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BannedUntil
+	}
+	return nil
+}
+
+// Запрет регистрации и входа. Проверяются IP клиента, домен email
+// (вместе с поддоменами) и ID устройства из метаданных x-device-id.
+type DenyListEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          DenyListKind           `protobuf:"varint,2,opt,name=kind,proto3,enum=users.DenyListKind" json:"kind,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"` // CIDR, домен или ID устройства
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"` // Пусто - бессрочно
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyListEntry) Reset() {
+	*x = DenyListEntry{}
+	mi := &file_v1_user_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyListEntry) ProtoMessage() {}
+
+func (x *DenyListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyListEntry.ProtoReflect.Descriptor instead.
+func (*DenyListEntry) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *DenyListEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DenyListEntry) GetKind() DenyListKind {
+	if x != nil {
+		return x.Kind
+	}
+	return DenyListKind_DENY_LIST_KIND_UNSPECIFIED
+}
+
+func (x *DenyListEntry) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *DenyListEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DenyListEntry) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *DenyListEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DenyListEntry) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *DenyListEntry) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateDenyListEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          DenyListKind           `protobuf:"varint,1,opt,name=kind,proto3,enum=users.DenyListKind" json:"kind,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // Для IP - адрес или CIDR диапазон
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDenyListEntryRequest) Reset() {
+	*x = CreateDenyListEntryRequest{}
+	mi := &file_v1_user_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDenyListEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDenyListEntryRequest) ProtoMessage() {}
+
+func (x *CreateDenyListEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDenyListEntryRequest.ProtoReflect.Descriptor instead.
+func (*CreateDenyListEntryRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *CreateDenyListEntryRequest) GetKind() DenyListKind {
+	if x != nil {
+		return x.Kind
+	}
+	return DenyListKind_DENY_LIST_KIND_UNSPECIFIED
+}
+
+func (x *CreateDenyListEntryRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *CreateDenyListEntryRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreateDenyListEntryRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type GetDenyListEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDenyListEntryRequest) Reset() {
+	*x = GetDenyListEntryRequest{}
+	mi := &file_v1_user_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDenyListEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDenyListEntryRequest) ProtoMessage() {}
+
+func (x *GetDenyListEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDenyListEntryRequest.ProtoReflect.Descriptor instead.
+func (*GetDenyListEntryRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *GetDenyListEntryRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+type ListDenyListEntriesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Page           int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize       int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Kind           *DenyListKind          `protobuf:"varint,3,opt,name=kind,proto3,enum=users.DenyListKind,oneof" json:"kind,omitempty"`
+	IncludeExpired bool                   `protobuf:"varint,4,opt,name=include_expired,json=includeExpired,proto3" json:"include_expired,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListDenyListEntriesRequest) Reset() {
+	*x = ListDenyListEntriesRequest{}
+	mi := &file_v1_user_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDenyListEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDenyListEntriesRequest) ProtoMessage() {}
+
+func (x *ListDenyListEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDenyListEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListDenyListEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListDenyListEntriesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDenyListEntriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDenyListEntriesRequest) GetKind() DenyListKind {
+	if x != nil && x.Kind != nil {
+		return *x.Kind
+	}
+	return DenyListKind_DENY_LIST_KIND_UNSPECIFIED
+}
+
+func (x *ListDenyListEntriesRequest) GetIncludeExpired() bool {
+	if x != nil {
+		return x.IncludeExpired
+	}
+	return false
+}
+
+type ListDenyListEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*DenyListEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDenyListEntriesResponse) Reset() {
+	*x = ListDenyListEntriesResponse{}
+	mi := &file_v1_user_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDenyListEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDenyListEntriesResponse) ProtoMessage() {}
+
+func (x *ListDenyListEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDenyListEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListDenyListEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ListDenyListEntriesResponse) GetEntries() []*DenyListEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListDenyListEntriesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListDenyListEntriesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDenyListEntriesResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type UpdateDenyListEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"` // Пусто - бессрочно, в прошлом - снять запрет
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDenyListEntryRequest) Reset() {
+	*x = UpdateDenyListEntryRequest{}
+	mi := &file_v1_user_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDenyListEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDenyListEntryRequest) ProtoMessage() {}
+
+func (x *UpdateDenyListEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDenyListEntryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDenyListEntryRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *UpdateDenyListEntryRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *UpdateDenyListEntryRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateDenyListEntryRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type DeleteDenyListEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDenyListEntryRequest) Reset() {
+	*x = DeleteDenyListEntryRequest{}
+	mi := &file_v1_user_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDenyListEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDenyListEntryRequest) ProtoMessage() {}
+
+func (x *DeleteDenyListEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDenyListEntryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDenyListEntryRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *DeleteDenyListEntryRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+// ===== Подписки =====
+type UpdateSubscriptionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Level           *SubscriptionLevel     `protobuf:"varint,2,opt,name=level,proto3,enum=users.SubscriptionLevel,oneof" json:"level,omitempty"`    // Уровень ACTIVE подписки меняется только через UPGRADING или DOWNGRADING
+	Status          *SubscriptionStatus    `protobuf:"varint,3,opt,name=status,proto3,enum=users.SubscriptionStatus,oneof" json:"status,omitempty"` // Пусто - без смены статуса, иначе только допустимый переход
+	SubscriptionEnd *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=subscription_end,json=subscriptionEnd,proto3,oneof" json:"subscription_end,omitempty"`
+	TrialEnd        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=trial_end,json=trialEnd,proto3,oneof" json:"trial_end,omitempty"`
+	SubscriptionId  *string                `protobuf:"bytes,6,opt,name=subscription_id,json=subscriptionId,proto3,oneof" json:"subscription_id,omitempty"`
+	PaymentMethod   *string                `protobuf:"bytes,7,opt,name=payment_method,json=paymentMethod,proto3,oneof" json:"payment_method,omitempty"`
+	AutoRenew       *bool                  `protobuf:"varint,8,opt,name=auto_renew,json=autoRenew,proto3,oneof" json:"auto_renew,omitempty"`
+	Amount          *float64               `protobuf:"fixed64,9,opt,name=amount,proto3,oneof" json:"amount,omitempty"`
+	Currency        *string                `protobuf:"bytes,10,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateSubscriptionRequest) Reset() {
+	*x = UpdateSubscriptionRequest{}
+	mi := &file_v1_user_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSubscriptionRequest) ProtoMessage() {}
+
+func (x *UpdateSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *UpdateSubscriptionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionRequest) GetLevel() SubscriptionLevel {
+	if x != nil && x.Level != nil {
+		return *x.Level
+	}
+	return SubscriptionLevel_SUBSCRIPTION_LEVEL_UNSPECIFIED
+}
+
+func (x *UpdateSubscriptionRequest) GetStatus() SubscriptionStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return SubscriptionStatus_SUBSCRIPTION_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateSubscriptionRequest) GetSubscriptionEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubscriptionEnd
+	}
+	return nil
+}
+
+func (x *UpdateSubscriptionRequest) GetTrialEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TrialEnd
+	}
+	return nil
+}
+
+func (x *UpdateSubscriptionRequest) GetSubscriptionId() string {
+	if x != nil && x.SubscriptionId != nil {
+		return *x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionRequest) GetPaymentMethod() string {
+	if x != nil && x.PaymentMethod != nil {
+		return *x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionRequest) GetAutoRenew() bool {
+	if x != nil && x.AutoRenew != nil {
+		return *x.AutoRenew
+	}
+	return false
+}
+
+func (x *UpdateSubscriptionRequest) GetAmount() float64 {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return 0
+}
+
+func (x *UpdateSubscriptionRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+type CancelSubscriptionRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	UserId                string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason                *string                `protobuf:"bytes,2,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
+	ImmediateCancellation bool                   `protobuf:"varint,3,opt,name=immediate_cancellation,json=immediateCancellation,proto3" json:"immediate_cancellation,omitempty"` // Немедленная отмена или в конце периода
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CancelSubscriptionRequest) Reset() {
+	*x = CancelSubscriptionRequest{}
+	mi := &file_v1_user_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSubscriptionRequest) ProtoMessage() {}
+
+func (x *CancelSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CancelSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *CancelSubscriptionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelSubscriptionRequest) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ""
+}
+
+func (x *CancelSubscriptionRequest) GetImmediateCancellation() bool {
+	if x != nil {
+		return x.ImmediateCancellation
+	}
+	return false
+}
+
+// ===== Организации =====
+type Organization struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`                      // Уникальное короткое имя
+	OwnerId       string                 `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // Создатель организации
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_v1_user_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *Organization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Organization) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Organization) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Organization) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type OrganizationMember struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role           OrganizationRole       `protobuf:"varint,3,opt,name=role,proto3,enum=users.OrganizationRole" json:"role,omitempty"`
+	JoinedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OrganizationMember) Reset() {
+	*x = OrganizationMember{}
+	mi := &file_v1_user_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationMember) ProtoMessage() {}
+
+func (x *OrganizationMember) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationMember.ProtoReflect.Descriptor instead.
+func (*OrganizationMember) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *OrganizationMember) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetRole() OrganizationRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED
+}
+
+func (x *OrganizationMember) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+type OrganizationInvitation struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Email          string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role           OrganizationRole       `protobuf:"varint,4,opt,name=role,proto3,enum=users.OrganizationRole" json:"role,omitempty"` // Роль после принятия
+	InvitedBy      string                 `protobuf:"bytes,5,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	Status         InvitationStatus       `protobuf:"varint,6,opt,name=status,proto3,enum=users.InvitationStatus" json:"status,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OrganizationInvitation) Reset() {
+	*x = OrganizationInvitation{}
+	mi := &file_v1_user_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationInvitation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationInvitation) ProtoMessage() {}
+
+func (x *OrganizationInvitation) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationInvitation.ProtoReflect.Descriptor instead.
+func (*OrganizationInvitation) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *OrganizationInvitation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrganizationInvitation) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *OrganizationInvitation) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *OrganizationInvitation) GetRole() OrganizationRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED
+}
+
+func (x *OrganizationInvitation) GetInvitedBy() string {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return ""
+}
+
+func (x *OrganizationInvitation) GetStatus() InvitationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return InvitationStatus_INVITATION_STATUS_UNSPECIFIED
+}
+
+func (x *OrganizationInvitation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *OrganizationInvitation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_v1_user_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *CreateOrganizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOrganizationRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type GetOrganizationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetOrganizationRequest) Reset() {
+	*x = GetOrganizationRequest{}
+	mi := &file_v1_user_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationRequest) ProtoMessage() {}
+
+func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *GetOrganizationRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+type ListOrganizationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsRequest) Reset() {
+	*x = ListOrganizationsRequest{}
+	mi := &file_v1_user_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsRequest) ProtoMessage() {}
+
+func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{90}
+}
+
+type ListOrganizationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsResponse) Reset() {
+	*x = ListOrganizationsResponse{}
+	mi := &file_v1_user_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsResponse) ProtoMessage() {}
+
+func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *ListOrganizationsResponse) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+type ListOrganizationMembersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListOrganizationMembersRequest) Reset() {
+	*x = ListOrganizationMembersRequest{}
+	mi := &file_v1_user_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationMembersRequest) ProtoMessage() {}
+
+func (x *ListOrganizationMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationMembersRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *ListOrganizationMembersRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+type ListOrganizationMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*OrganizationMember  `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationMembersResponse) Reset() {
+	*x = ListOrganizationMembersResponse{}
+	mi := &file_v1_user_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationMembersResponse) ProtoMessage() {}
+
+func (x *ListOrganizationMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationMembersResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ListOrganizationMembersResponse) GetMembers() []*OrganizationMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type UpdateOrganizationMemberRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role           OrganizationRole       `protobuf:"varint,3,opt,name=role,proto3,enum=users.OrganizationRole" json:"role,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateOrganizationMemberRequest) Reset() {
+	*x = UpdateOrganizationMemberRequest{}
+	mi := &file_v1_user_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrganizationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrganizationMemberRequest) ProtoMessage() {}
+
+func (x *UpdateOrganizationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrganizationMemberRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrganizationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *UpdateOrganizationMemberRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *UpdateOrganizationMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateOrganizationMemberRequest) GetRole() OrganizationRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED
+}
+
+type RemoveOrganizationMemberRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Свой ID - выйти из организации
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RemoveOrganizationMemberRequest) Reset() {
+	*x = RemoveOrganizationMemberRequest{}
+	mi := &file_v1_user_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveOrganizationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveOrganizationMemberRequest) ProtoMessage() {}
+
+func (x *RemoveOrganizationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveOrganizationMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveOrganizationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *RemoveOrganizationMemberRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *RemoveOrganizationMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type InviteToOrganizationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Email          string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role           OrganizationRole       `protobuf:"varint,3,opt,name=role,proto3,enum=users.OrganizationRole" json:"role,omitempty"` // По умолчанию MEMBER
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *BanUserRequest) Reset() {
-	*x = BanUserRequest{}
-	mi := &file_v1_user_proto_msgTypes[13]
+func (x *InviteToOrganizationRequest) Reset() {
+	*x = InviteToOrganizationRequest{}
+	mi := &file_v1_user_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BanUserRequest) String() string {
+func (x *InviteToOrganizationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BanUserRequest) ProtoMessage() {}
+func (*InviteToOrganizationRequest) ProtoMessage() {}
 
-func (x *BanUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_user_proto_msgTypes[13]
+func (x *InviteToOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1382,62 +6840,53 @@ func (x *BanUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BanUserRequest.ProtoReflect.Descriptor instead.
-func (*BanUserRequest) Descriptor() ([]byte, []int) {
-	return file_v1_user_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use InviteToOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*InviteToOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *BanUserRequest) GetUserId() string {
+func (x *InviteToOrganizationRequest) GetOrganizationId() string {
 	if x != nil {
-		return x.UserId
+		return x.OrganizationId
 	}
 	return ""
 }
 
-func (x *BanUserRequest) GetReason() string {
+func (x *InviteToOrganizationRequest) GetEmail() string {
 	if x != nil {
-		return x.Reason
+		return x.Email
 	}
 	return ""
 }
 
-func (x *BanUserRequest) GetBannedUntil() *timestamppb.Timestamp {
-	if x != nil {
-		return x.BannedUntil
-	}
-	return nil
-}
-
-func (x *BanUserRequest) GetBannedBy() string {
+func (x *InviteToOrganizationRequest) GetRole() OrganizationRole {
 	if x != nil {
-		return x.BannedBy
+		return x.Role
 	}
-	return ""
+	return OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED
 }
 
-type UnbanUserRequest struct {
+type ListMyInvitationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	UnbannedBy    string                 `protobuf:"bytes,2,opt,name=unbanned_by,json=unbannedBy,proto3" json:"unbanned_by,omitempty"` // ID администратора
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UnbanUserRequest) Reset() {
-	*x = UnbanUserRequest{}
-	mi := &file_v1_user_proto_msgTypes[14]
+func (x *ListMyInvitationsRequest) Reset() {
+	*x = ListMyInvitationsRequest{}
+	mi := &file_v1_user_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UnbanUserRequest) String() string {
+func (x *ListMyInvitationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnbanUserRequest) ProtoMessage() {}
+func (*ListMyInvitationsRequest) ProtoMessage() {}
 
-func (x *UnbanUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_user_proto_msgTypes[14]
+func (x *ListMyInvitationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1448,57 +6897,33 @@ func (x *UnbanUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnbanUserRequest.ProtoReflect.Descriptor instead.
-func (*UnbanUserRequest) Descriptor() ([]byte, []int) {
-	return file_v1_user_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *UnbanUserRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *UnbanUserRequest) GetUnbannedBy() string {
-	if x != nil {
-		return x.UnbannedBy
-	}
-	return ""
+// Deprecated: Use ListMyInvitationsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyInvitationsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{97}
 }
 
-// ===== Подписки =====
-type UpdateSubscriptionRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Level           SubscriptionLevel      `protobuf:"varint,2,opt,name=level,proto3,enum=users.SubscriptionLevel" json:"level,omitempty"`
-	Status          *SubscriptionStatus    `protobuf:"varint,3,opt,name=status,proto3,enum=users.SubscriptionStatus,oneof" json:"status,omitempty"`
-	SubscriptionEnd *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=subscription_end,json=subscriptionEnd,proto3,oneof" json:"subscription_end,omitempty"`
-	TrialEnd        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=trial_end,json=trialEnd,proto3,oneof" json:"trial_end,omitempty"`
-	SubscriptionId  *string                `protobuf:"bytes,6,opt,name=subscription_id,json=subscriptionId,proto3,oneof" json:"subscription_id,omitempty"`
-	PaymentMethod   *string                `protobuf:"bytes,7,opt,name=payment_method,json=paymentMethod,proto3,oneof" json:"payment_method,omitempty"`
-	AutoRenew       *bool                  `protobuf:"varint,8,opt,name=auto_renew,json=autoRenew,proto3,oneof" json:"auto_renew,omitempty"`
-	Amount          *float64               `protobuf:"fixed64,9,opt,name=amount,proto3,oneof" json:"amount,omitempty"`
-	Currency        *string                `protobuf:"bytes,10,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type ListMyInvitationsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Invitations   []*OrganizationInvitation `protobuf:"bytes,1,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateSubscriptionRequest) Reset() {
-	*x = UpdateSubscriptionRequest{}
-	mi := &file_v1_user_proto_msgTypes[15]
+func (x *ListMyInvitationsResponse) Reset() {
+	*x = ListMyInvitationsResponse{}
+	mi := &file_v1_user_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateSubscriptionRequest) String() string {
+func (x *ListMyInvitationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateSubscriptionRequest) ProtoMessage() {}
+func (*ListMyInvitationsResponse) ProtoMessage() {}
 
-func (x *UpdateSubscriptionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_user_proto_msgTypes[15]
+func (x *ListMyInvitationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1509,105 +6934,92 @@ func (x *UpdateSubscriptionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateSubscriptionRequest.ProtoReflect.Descriptor instead.
-func (*UpdateSubscriptionRequest) Descriptor() ([]byte, []int) {
-	return file_v1_user_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListMyInvitationsResponse.ProtoReflect.Descriptor instead.
+func (*ListMyInvitationsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *UpdateSubscriptionRequest) GetUserId() string {
+func (x *ListMyInvitationsResponse) GetInvitations() []*OrganizationInvitation {
 	if x != nil {
-		return x.UserId
+		return x.Invitations
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateSubscriptionRequest) GetLevel() SubscriptionLevel {
-	if x != nil {
-		return x.Level
-	}
-	return SubscriptionLevel_SUBSCRIPTION_LEVEL_UNSPECIFIED
+type RespondInvitationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	InvitationId   string                 `protobuf:"bytes,2,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *UpdateSubscriptionRequest) GetStatus() SubscriptionStatus {
-	if x != nil && x.Status != nil {
-		return *x.Status
-	}
-	return SubscriptionStatus_SUBSCRIPTION_STATUS_UNSPECIFIED
+func (x *RespondInvitationRequest) Reset() {
+	*x = RespondInvitationRequest{}
+	mi := &file_v1_user_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateSubscriptionRequest) GetSubscriptionEnd() *timestamppb.Timestamp {
-	if x != nil {
-		return x.SubscriptionEnd
-	}
-	return nil
+func (x *RespondInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *UpdateSubscriptionRequest) GetTrialEnd() *timestamppb.Timestamp {
+func (*RespondInvitationRequest) ProtoMessage() {}
+
+func (x *RespondInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[99]
 	if x != nil {
-		return x.TrialEnd
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateSubscriptionRequest) GetSubscriptionId() string {
-	if x != nil && x.SubscriptionId != nil {
-		return *x.SubscriptionId
-	}
-	return ""
+// Deprecated: Use RespondInvitationRequest.ProtoReflect.Descriptor instead.
+func (*RespondInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *UpdateSubscriptionRequest) GetPaymentMethod() string {
-	if x != nil && x.PaymentMethod != nil {
-		return *x.PaymentMethod
+func (x *RespondInvitationRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
 	}
 	return ""
 }
 
-func (x *UpdateSubscriptionRequest) GetAutoRenew() bool {
-	if x != nil && x.AutoRenew != nil {
-		return *x.AutoRenew
-	}
-	return false
-}
-
-func (x *UpdateSubscriptionRequest) GetAmount() float64 {
-	if x != nil && x.Amount != nil {
-		return *x.Amount
-	}
-	return 0
-}
-
-func (x *UpdateSubscriptionRequest) GetCurrency() string {
-	if x != nil && x.Currency != nil {
-		return *x.Currency
+func (x *RespondInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
 	}
 	return ""
 }
 
-type CancelSubscriptionRequest struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	UserId                string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Reason                *string                `protobuf:"bytes,2,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
-	ImmediateCancellation bool                   `protobuf:"varint,3,opt,name=immediate_cancellation,json=immediateCancellation,proto3" json:"immediate_cancellation,omitempty"` // Немедленная отмена или в конце периода
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+type SwitchOrganizationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *CancelSubscriptionRequest) Reset() {
-	*x = CancelSubscriptionRequest{}
-	mi := &file_v1_user_proto_msgTypes[16]
+func (x *SwitchOrganizationRequest) Reset() {
+	*x = SwitchOrganizationRequest{}
+	mi := &file_v1_user_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelSubscriptionRequest) String() string {
+func (x *SwitchOrganizationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelSubscriptionRequest) ProtoMessage() {}
+func (*SwitchOrganizationRequest) ProtoMessage() {}
 
-func (x *CancelSubscriptionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_user_proto_msgTypes[16]
+func (x *SwitchOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1618,32 +7030,18 @@ func (x *CancelSubscriptionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelSubscriptionRequest.ProtoReflect.Descriptor instead.
-func (*CancelSubscriptionRequest) Descriptor() ([]byte, []int) {
-	return file_v1_user_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use SwitchOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*SwitchOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_proto_rawDescGZIP(), []int{100}
 }
 
-func (x *CancelSubscriptionRequest) GetUserId() string {
+func (x *SwitchOrganizationRequest) GetOrganizationId() string {
 	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *CancelSubscriptionRequest) GetReason() string {
-	if x != nil && x.Reason != nil {
-		return *x.Reason
+		return x.OrganizationId
 	}
 	return ""
 }
 
-func (x *CancelSubscriptionRequest) GetImmediateCancellation() bool {
-	if x != nil {
-		return x.ImmediateCancellation
-	}
-	return false
-}
-
 // ===== Ответы =====
 type ListUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1658,7 +7056,7 @@ type ListUsersResponse struct {
 
 func (x *ListUsersResponse) Reset() {
 	*x = ListUsersResponse{}
-	mi := &file_v1_user_proto_msgTypes[17]
+	mi := &file_v1_user_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1670,7 +7068,7 @@ func (x *ListUsersResponse) String() string {
 func (*ListUsersResponse) ProtoMessage() {}
 
 func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_user_proto_msgTypes[17]
+	mi := &file_v1_user_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1683,7 +7081,7 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
 func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_v1_user_proto_rawDescGZIP(), []int{17}
+	return file_v1_user_proto_rawDescGZIP(), []int{101}
 }
 
 func (x *ListUsersResponse) GetUsers() []*User {
@@ -1730,7 +7128,7 @@ type HealthCheckRequest struct {
 
 func (x *HealthCheckRequest) Reset() {
 	*x = HealthCheckRequest{}
-	mi := &file_v1_user_proto_msgTypes[18]
+	mi := &file_v1_user_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1742,7 +7140,7 @@ func (x *HealthCheckRequest) String() string {
 func (*HealthCheckRequest) ProtoMessage() {}
 
 func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_user_proto_msgTypes[18]
+	mi := &file_v1_user_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1755,7 +7153,7 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
 func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return file_v1_user_proto_rawDescGZIP(), []int{18}
+	return file_v1_user_proto_rawDescGZIP(), []int{102}
 }
 
 type HealthCheckResponse struct {
@@ -1770,7 +7168,7 @@ type HealthCheckResponse struct {
 
 func (x *HealthCheckResponse) Reset() {
 	*x = HealthCheckResponse{}
-	mi := &file_v1_user_proto_msgTypes[19]
+	mi := &file_v1_user_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1782,7 +7180,7 @@ func (x *HealthCheckResponse) String() string {
 func (*HealthCheckResponse) ProtoMessage() {}
 
 func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_user_proto_msgTypes[19]
+	mi := &file_v1_user_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1795,7 +7193,7 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_v1_user_proto_rawDescGZIP(), []int{19}
+	return file_v1_user_proto_rawDescGZIP(), []int{103}
 }
 
 func (x *HealthCheckResponse) GetStatus() string {
@@ -1845,7 +7243,7 @@ type SubscriptionAnalytics struct {
 
 func (x *SubscriptionAnalytics) Reset() {
 	*x = SubscriptionAnalytics{}
-	mi := &file_v1_user_proto_msgTypes[20]
+	mi := &file_v1_user_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1857,7 +7255,7 @@ func (x *SubscriptionAnalytics) String() string {
 func (*SubscriptionAnalytics) ProtoMessage() {}
 
 func (x *SubscriptionAnalytics) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_user_proto_msgTypes[20]
+	mi := &file_v1_user_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1870,7 +7268,7 @@ func (x *SubscriptionAnalytics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscriptionAnalytics.ProtoReflect.Descriptor instead.
 func (*SubscriptionAnalytics) Descriptor() ([]byte, []int) {
-	return file_v1_user_proto_rawDescGZIP(), []int{20}
+	return file_v1_user_proto_rawDescGZIP(), []int{104}
 }
 
 func (x *SubscriptionAnalytics) GetTotalSubscribers() int32 {
@@ -1961,7 +7359,7 @@ type SubscriptionHistoryEntry struct {
 
 func (x *SubscriptionHistoryEntry) Reset() {
 	*x = SubscriptionHistoryEntry{}
-	mi := &file_v1_user_proto_msgTypes[21]
+	mi := &file_v1_user_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1973,7 +7371,7 @@ func (x *SubscriptionHistoryEntry) String() string {
 func (*SubscriptionHistoryEntry) ProtoMessage() {}
 
 func (x *SubscriptionHistoryEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_user_proto_msgTypes[21]
+	mi := &file_v1_user_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1986,7 +7384,7 @@ func (x *SubscriptionHistoryEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscriptionHistoryEntry.ProtoReflect.Descriptor instead.
 func (*SubscriptionHistoryEntry) Descriptor() ([]byte, []int) {
-	return file_v1_user_proto_rawDescGZIP(), []int{21}
+	return file_v1_user_proto_rawDescGZIP(), []int{105}
 }
 
 func (x *SubscriptionHistoryEntry) GetId() string {
@@ -2063,7 +7461,7 @@ var File_v1_user_proto protoreflect.FileDescriptor
 
 const file_v1_user_proto_rawDesc = "" +
 	"\n" +
-	"\rv1/user.proto\x12\x05users\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\"\xf9\x04\n" +
+	"\rv1/user.proto\x12\x05users\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\"\xc1\x05\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
 	"\rservice_email\x18\x02 \x01(\tR\fserviceEmail\x12\x12\n" +
@@ -2081,16 +7479,19 @@ const file_v1_user_proto_rawDesc = "" +
 	"\rlast_login_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vlastLoginAt\x12)\n" +
 	"\bban_info\x18\f \x01(\v2\x0e.users.BanInfoR\abanInfo\x12;\n" +
 	"\fsubscription\x18\r \x01(\v2\x17.users.SubscriptionInfoR\fsubscription\x125\n" +
-	"\bmetadata\x18\x0e \x03(\v2\x19.users.User.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x0e \x03(\v2\x19.users.User.MetadataEntryR\bmetadata\x12F\n" +
+	"\x11phone_verified_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\x0fphoneVerifiedAt\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd3\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa0\x02\n" +
 	"\aBanInfo\x12\x1b\n" +
 	"\tis_banned\x18\x01 \x01(\bR\bisBanned\x127\n" +
 	"\tbanned_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bbannedAt\x12=\n" +
 	"\fbanned_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vbannedUntil\x12\x16\n" +
 	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1b\n" +
-	"\tbanned_by\x18\x05 \x01(\tR\bbannedBy\"\xef\x05\n" +
+	"\tbanned_by\x18\x05 \x01(\tR\bbannedBy\x12.\n" +
+	"\bcategory\x18\x06 \x01(\x0e2\x12.users.BanCategoryR\bcategory\x12\x1b\n" +
+	"\treport_id\x18\a \x01(\tR\breportId\"\xef\x05\n" +
 	"\x10SubscriptionInfo\x121\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x19.users.SubscriptionStatusR\x06status\x12.\n" +
 	"\x05level\x18\x02 \x01(\x0e2\x18.users.SubscriptionLevelR\x05level\x12I\n" +
@@ -2147,7 +7548,7 @@ const file_v1_user_proto_rawDesc = "" +
 	"\a_statusB\a\n" +
 	"\x05_role\"#\n" +
 	"\x11DeleteUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xb0\x04\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xfd\x04\n" +
 	"\x10ListUsersRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1b\n" +
@@ -2157,7 +7558,9 @@ const file_v1_user_proto_rawDesc = "" +
 	"\tis_banned\x18\x06 \x01(\bH\x03R\bisBanned\x88\x01\x01\x12O\n" +
 	"\x13subscription_status\x18\a \x01(\x0e2\x19.users.SubscriptionStatusH\x04R\x12subscriptionStatus\x88\x01\x01\x12L\n" +
 	"\x12subscription_level\x18\b \x01(\x0e2\x18.users.SubscriptionLevelH\x05R\x11subscriptionLevel\x88\x01\x01\x12;\n" +
-	"\x17has_active_subscription\x18\t \x01(\bH\x06R\x15hasActiveSubscription\x88\x01\x01B\t\n" +
+	"\x17has_active_subscription\x18\t \x01(\bH\x06R\x15hasActiveSubscription\x88\x01\x01\x12:\n" +
+	"\fban_category\x18\n" +
+	" \x01(\x0e2\x12.users.BanCategoryH\aR\vbanCategory\x88\x01\x01B\t\n" +
 	"\a_searchB\t\n" +
 	"\a_statusB\a\n" +
 	"\x05_roleB\f\n" +
@@ -2165,43 +7568,389 @@ const file_v1_user_proto_rawDesc = "" +
 	"_is_bannedB\x16\n" +
 	"\x14_subscription_statusB\x15\n" +
 	"\x13_subscription_levelB\x1a\n" +
-	"\x18_has_active_subscription\"G\n" +
+	"\x18_has_active_subscriptionB\x0f\n" +
+	"\r_ban_category\"G\n" +
 	"\x13AuthenticateRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x88\x01\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xb7\x02\n" +
 	"\x14AuthenticateResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1f\n" +
 	"\x04user\x18\x02 \x01(\v2\v.users.UserR\x04user\x129\n" +
 	"\n" +
-	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\",\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12#\n" +
+	"\rrefresh_token\x18\x04 \x01(\tR\frefreshToken\x12H\n" +
+	"\x12refresh_expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x10refreshExpiresAt\x12!\n" +
+	"\fmfa_required\x18\x06 \x01(\bR\vmfaRequired\x12\x1b\n" +
+	"\tmfa_token\x18\a \x01(\tR\bmfaToken\"C\n" +
+	"\x10VerifyMFARequest\x12\x1b\n" +
+	"\tmfa_token\x18\x01 \x01(\tR\bmfaToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\":\n" +
+	"\x13RefreshTokenRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\xf7\x01\n" +
+	"\x14RefreshTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12H\n" +
+	"\x12refresh_expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x10refreshExpiresAt\x12\x1f\n" +
+	"\x04user\x18\x05 \x01(\v2\v.users.UserR\x04user\",\n" +
 	"\x14ValidateTokenRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\"N\n" +
 	"\x15ValidateTokenResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x1f\n" +
-	"\x04user\x18\x02 \x01(\v2\v.users.UserR\x04user\"\xb3\x01\n" +
+	"\x04user\x18\x02 \x01(\v2\v.users.UserR\x04user\"a\n" +
+	"\rLogoutRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\rrefresh_token\x18\x02 \x01(\tH\x00R\frefreshToken\x88\x01\x01B\x10\n" +
+	"\x0e_refresh_token\"3\n" +
+	"\x18RevokeAllSessionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"*\n" +
+	"\x12VerifyEmailRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"1\n" +
+	"\x19ResendVerificationRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\".\n" +
+	"\x13SendPhoneOTPRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"Q\n" +
+	"\x14SendPhoneOTPResponse\x129\n" +
+	"\n" +
+	"expires_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"D\n" +
+	"\x15VerifyPhoneOTPRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\",\n" +
+	"\x11EnrollTOTPRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"M\n" +
+	"\x12EnrollTOTPResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1f\n" +
+	"\votpauth_uri\x18\x02 \x01(\tR\n" +
+	"otpauthUri\"A\n" +
+	"\x12ConfirmTOTPRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"<\n" +
+	"\x13ConfirmTOTPResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"A\n" +
+	"\x12DisableTOTPRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\x99\x02\n" +
+	"\x12WebAuthnCredential\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"transports\x18\x03 \x03(\tR\n" +
+	"transports\x12'\n" +
+	"\x0fbackup_eligible\x18\x04 \x01(\bR\x0ebackupEligible\x12\x1d\n" +
+	"\n" +
+	"sign_count\x18\x05 \x01(\rR\tsignCount\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\";\n" +
+	" BeginWebAuthnRegistrationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"1\n" +
+	"\x19BeginWebAuthnLoginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"Y\n" +
+	"\x15BeginWebAuthnResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12!\n" +
+	"\foptions_json\x18\x02 \x01(\tR\voptionsJson\"\x98\x01\n" +
+	"!FinishWebAuthnRegistrationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12'\n" +
+	"\x0fcredential_json\x18\x03 \x01(\tR\x0ecredentialJson\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\"d\n" +
+	"\x1aFinishWebAuthnLoginRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12'\n" +
+	"\x0fcredential_json\x18\x02 \x01(\tR\x0ecredentialJson\"9\n" +
+	"\x1eListWebAuthnCredentialsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"^\n" +
+	"\x1fListWebAuthnCredentialsResponse\x12;\n" +
+	"\vcredentials\x18\x01 \x03(\v2\x19.users.WebAuthnCredentialR\vcredentials\"s\n" +
+	"\x1fRenameWebAuthnCredentialRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rcredential_id\x18\x02 \x01(\tR\fcredentialId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"_\n" +
+	"\x1fDeleteWebAuthnCredentialRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rcredential_id\x18\x02 \x01(\tR\fcredentialId\"\x90\x02\n" +
+	"\x06APIKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12<\n" +
+	"\flast_used_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x95\x01\n" +
+	"\x13CreateAPIKeyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"P\n" +
+	"\x14CreateAPIKeyResponse\x12&\n" +
+	"\aapi_key\x18\x01 \x01(\v2\r.users.APIKeyR\x06apiKey\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"-\n" +
+	"\x12ListAPIKeysRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"?\n" +
+	"\x13ListAPIKeysResponse\x12(\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\r.users.APIKeyR\aapiKeys\"L\n" +
+	"\x13RevokeAPIKeyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\n" +
+	"api_key_id\x18\x02 \x01(\tR\bapiKeyId\"I\n" +
+	"\x16ImpersonateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x8b\x01\n" +
+	"\x17ImpersonateUserResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1f\n" +
+	"\x04user\x18\x03 \x01(\v2\v.users.UserR\x04user\"\x1a\n" +
+	"\x18StopImpersonationRequest\"x\n" +
+	"\x18IssueServiceTokenRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x02 \x01(\tR\fclientSecret\x12\x1a\n" +
+	"\baudience\x18\x03 \x01(\tR\baudience\"\xad\x01\n" +
+	"\x19IssueServiceTokenResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1a\n" +
+	"\baudience\x18\x03 \x01(\tR\baudience\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\"3\n" +
+	"\x1bRequestPasswordResetRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"V\n" +
+	"\x1bConfirmPasswordResetRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"J\n" +
+	"\x13ClearLockoutRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x02 \x01(\tR\tipAddress\"\xe3\x01\n" +
 	"\x0eBanUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\x12B\n" +
 	"\fbanned_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\vbannedUntil\x88\x01\x01\x12\x1b\n" +
-	"\tbanned_by\x18\x04 \x01(\tR\bbannedByB\x0f\n" +
+	"\tbanned_by\x18\x04 \x01(\tR\bbannedBy\x12.\n" +
+	"\bcategory\x18\x05 \x01(\x0e2\x12.users.BanCategoryR\bcategoryB\x0f\n" +
 	"\r_banned_until\"L\n" +
 	"\x10UnbanUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
 	"\vunbanned_by\x18\x02 \x01(\tR\n" +
-	"unbannedBy\"\xde\x04\n" +
+	"unbannedBy\"\x19\n" +
+	"\x17GetBanStatisticsRequest\"\x96\x01\n" +
+	"\x10BanCategoryStats\x12.\n" +
+	"\bcategory\x18\x01 \x01(\x0e2\x12.users.BanCategoryR\bcategory\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\x03R\x06active\x12\x1c\n" +
+	"\ttemporary\x18\x03 \x01(\x03R\ttemporary\x12\x1c\n" +
+	"\tpermanent\x18\x04 \x01(\x03R\tpermanent\"s\n" +
+	"\x15BanStatisticsResponse\x127\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x17.users.BanCategoryStatsR\n" +
+	"categories\x12!\n" +
+	"\ftotal_active\x18\x02 \x01(\x03R\vtotalActive\"\xda\x01\n" +
+	"\x06Strike\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1b\n" +
+	"\tissued_by\x18\x04 \x01(\tR\bissuedBy\x127\n" +
+	"\tissued_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"F\n" +
+	"\x13IssueWarningRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x9d\x01\n" +
+	"\x14IssueWarningResponse\x12%\n" +
+	"\x06strike\x18\x01 \x01(\v2\r.users.StrikeR\x06strike\x12%\n" +
+	"\x0eactive_strikes\x18\x02 \x01(\x05R\ractiveStrikes\x12\x16\n" +
+	"\x06banned\x18\x03 \x01(\bR\x06banned\x12\x1f\n" +
+	"\x04user\x18\x04 \x01(\v2\v.users.UserR\x04user\"-\n" +
+	"\x12ListStrikesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\">\n" +
+	"\x13ListStrikesResponse\x12'\n" +
+	"\astrikes\x18\x01 \x03(\v2\r.users.StrikeR\astrikes\"\xde\x03\n" +
+	"\tBanAppeal\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x127\n" +
+	"\tbanned_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bbannedAt\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12+\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x13.users.AppealStatusR\x06status\x12\x1f\n" +
+	"\vassigned_to\x18\x06 \x01(\tR\n" +
+	"assignedTo\x12\x1f\n" +
+	"\vresolved_by\x18\a \x01(\tR\n" +
+	"resolvedBy\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\b \x01(\tR\n" +
+	"resolution\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12@\n" +
+	"\vresolved_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampH\x00R\n" +
+	"resolvedAt\x88\x01\x01B\x0e\n" +
+	"\f_resolved_at\"U\n" +
+	"\x16SubmitBanAppealRequest\x12!\n" +
+	"\fappeal_token\x18\x01 \x01(\tR\vappealToken\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xbb\x01\n" +
+	"\x15ListBanAppealsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x120\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x13.users.AppealStatusH\x00R\x06status\x88\x01\x01\x12$\n" +
+	"\vassigned_to\x18\x04 \x01(\tH\x01R\n" +
+	"assignedTo\x88\x01\x01B\t\n" +
+	"\a_statusB\x0e\n" +
+	"\f_assigned_to\"\x8b\x01\n" +
+	"\x16ListBanAppealsResponse\x12*\n" +
+	"\aappeals\x18\x01 \x03(\v2\x10.users.BanAppealR\aappeals\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"X\n" +
+	"\x16AssignBanAppealRequest\x12\x1b\n" +
+	"\tappeal_id\x18\x01 \x01(\tR\bappealId\x12!\n" +
+	"\fmoderator_id\x18\x02 \x01(\tR\vmoderatorId\"V\n" +
+	"\x17ResolveBanAppealRequest\x12\x1b\n" +
+	"\tappeal_id\x18\x01 \x01(\tR\bappealId\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x02 \x01(\tR\n" +
+	"resolution\"\xa0\x06\n" +
+	"\n" +
+	"UserReport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x10reported_user_id\x18\x02 \x01(\tR\x0ereportedUserId\x121\n" +
+	"\bcategory\x18\x03 \x01(\x0e2\x15.users.ReportCategoryR\bcategory\x12+\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x13.users.ReportStatusR\x06status\x12!\n" +
+	"\freport_count\x18\x05 \x01(\x05R\vreportCount\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\x05R\bpriority\x12\x1d\n" +
+	"\n" +
+	"claimed_by\x18\a \x01(\tR\tclaimedBy\x12>\n" +
+	"\n" +
+	"claimed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tclaimedAt\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12+\n" +
+	"\x06action\x18\v \x01(\x0e2\x13.users.ReportActionR\x06action\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\f \x01(\tR\n" +
+	"resolution\x12\x1f\n" +
+	"\vresolved_by\x18\r \x01(\tR\n" +
+	"resolvedBy\x12@\n" +
+	"\vresolved_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampH\x01R\n" +
+	"resolvedAt\x88\x01\x01\x12<\n" +
+	"\tbanned_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampH\x02R\bbannedAt\x88\x01\x01\x12\x1b\n" +
+	"\tstrike_id\x18\x10 \x01(\tR\bstrikeId\x12,\n" +
+	"\aentries\x18\x11 \x03(\v2\x12.users.ReportEntryR\aentriesB\r\n" +
+	"\v_claimed_atB\x0e\n" +
+	"\f_resolved_atB\f\n" +
+	"\n" +
+	"_banned_at\"\x86\x02\n" +
+	"\vReportEntry\x12\x1f\n" +
+	"\vreporter_id\x18\x01 \x01(\tR\n" +
+	"reporterId\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12<\n" +
+	"\bevidence\x18\x03 \x03(\v2 .users.ReportEntry.EvidenceEntryR\bevidence\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1a;\n" +
+	"\rEvidenceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x82\x02\n" +
+	"\x11ReportUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
+	"\bcategory\x18\x02 \x01(\x0e2\x15.users.ReportCategoryR\bcategory\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12B\n" +
+	"\bevidence\x18\x04 \x03(\v2&.users.ReportUserRequest.EvidenceEntryR\bevidence\x1a;\n" +
+	"\rEvidenceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"|\n" +
+	"\x12ReportUserResponse\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12+\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x13.users.ReportStatusR\x06status\x12\x1c\n" +
+	"\tduplicate\x18\x03 \x01(\bR\tduplicate\"\x82\x02\n" +
+	"\x1aListModerationQueueRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x120\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x13.users.ReportStatusH\x00R\x06status\x88\x01\x01\x126\n" +
+	"\bcategory\x18\x04 \x01(\x0e2\x15.users.ReportCategoryH\x01R\bcategory\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"claimed_by\x18\x05 \x01(\tH\x02R\tclaimedBy\x88\x01\x01B\t\n" +
+	"\a_statusB\v\n" +
+	"\t_categoryB\r\n" +
+	"\v_claimed_by\"\x91\x01\n" +
+	"\x1bListModerationQueueResponse\x12+\n" +
+	"\areports\x18\x01 \x03(\v2\x11.users.UserReportR\areports\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"1\n" +
+	"\x12ClaimReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\"\xd5\x01\n" +
+	"\x14ResolveReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12+\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x13.users.ReportActionR\x06action\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x03 \x01(\tR\n" +
+	"resolution\x12B\n" +
+	"\fbanned_until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\vbannedUntil\x88\x01\x01B\x0f\n" +
+	"\r_banned_until\"\xda\x02\n" +
+	"\rDenyListEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x13.users.DenyListKindR\x04kind\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01B\r\n" +
+	"\v_expires_at\"\xc2\x01\n" +
+	"\x1aCreateDenyListEntryRequest\x12'\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x13.users.DenyListKindR\x04kind\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12>\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01B\r\n" +
+	"\v_expires_at\"4\n" +
+	"\x17GetDenyListEntryRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\"\xad\x01\n" +
+	"\x1aListDenyListEntriesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12,\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\x13.users.DenyListKindH\x00R\x04kind\x88\x01\x01\x12'\n" +
+	"\x0finclude_expired\x18\x04 \x01(\bR\x0eincludeExpiredB\a\n" +
+	"\x05_kind\"\x94\x01\n" +
+	"\x1bListDenyListEntriesResponse\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.users.DenyListEntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x9e\x01\n" +
+	"\x1aUpdateDenyListEntryRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12>\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01B\r\n" +
+	"\v_expires_at\"7\n" +
+	"\x1aDeleteDenyListEntryRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\"\xed\x04\n" +
 	"\x19UpdateSubscriptionRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
-	"\x05level\x18\x02 \x01(\x0e2\x18.users.SubscriptionLevelR\x05level\x126\n" +
-	"\x06status\x18\x03 \x01(\x0e2\x19.users.SubscriptionStatusH\x00R\x06status\x88\x01\x01\x12J\n" +
-	"\x10subscription_end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x0fsubscriptionEnd\x88\x01\x01\x12<\n" +
-	"\ttrial_end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\btrialEnd\x88\x01\x01\x12,\n" +
-	"\x0fsubscription_id\x18\x06 \x01(\tH\x03R\x0esubscriptionId\x88\x01\x01\x12*\n" +
-	"\x0epayment_method\x18\a \x01(\tH\x04R\rpaymentMethod\x88\x01\x01\x12\"\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x123\n" +
+	"\x05level\x18\x02 \x01(\x0e2\x18.users.SubscriptionLevelH\x00R\x05level\x88\x01\x01\x126\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x19.users.SubscriptionStatusH\x01R\x06status\x88\x01\x01\x12J\n" +
+	"\x10subscription_end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\x0fsubscriptionEnd\x88\x01\x01\x12<\n" +
+	"\ttrial_end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\btrialEnd\x88\x01\x01\x12,\n" +
+	"\x0fsubscription_id\x18\x06 \x01(\tH\x04R\x0esubscriptionId\x88\x01\x01\x12*\n" +
+	"\x0epayment_method\x18\a \x01(\tH\x05R\rpaymentMethod\x88\x01\x01\x12\"\n" +
 	"\n" +
-	"auto_renew\x18\b \x01(\bH\x05R\tautoRenew\x88\x01\x01\x12\x1b\n" +
-	"\x06amount\x18\t \x01(\x01H\x06R\x06amount\x88\x01\x01\x12\x1f\n" +
+	"auto_renew\x18\b \x01(\bH\x06R\tautoRenew\x88\x01\x01\x12\x1b\n" +
+	"\x06amount\x18\t \x01(\x01H\aR\x06amount\x88\x01\x01\x12\x1f\n" +
 	"\bcurrency\x18\n" +
-	" \x01(\tH\aR\bcurrency\x88\x01\x01B\t\n" +
+	" \x01(\tH\bR\bcurrency\x88\x01\x01B\b\n" +
+	"\x06_levelB\t\n" +
 	"\a_statusB\x13\n" +
 	"\x11_subscription_endB\f\n" +
 	"\n" +
@@ -2215,7 +7964,64 @@ const file_v1_user_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
 	"\x06reason\x18\x02 \x01(\tH\x00R\x06reason\x88\x01\x01\x125\n" +
 	"\x16immediate_cancellation\x18\x03 \x01(\bR\x15immediateCancellationB\t\n" +
-	"\a_reason\"\x9e\x01\n" +
+	"\a_reason\"\xd7\x01\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12\x19\n" +
+	"\bowner_id\x18\x04 \x01(\tR\aownerId\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xbc\x01\n" +
+	"\x12OrganizationMember\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12+\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x17.users.OrganizationRoleR\x04role\x127\n" +
+	"\tjoined_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\"\xda\x02\n" +
+	"\x16OrganizationInvitation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12+\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x17.users.OrganizationRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"invited_by\x18\x05 \x01(\tR\tinvitedBy\x12/\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x17.users.InvitationStatusR\x06status\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"C\n" +
+	"\x19CreateOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\"A\n" +
+	"\x16GetOrganizationRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\"\x1a\n" +
+	"\x18ListOrganizationsRequest\"V\n" +
+	"\x19ListOrganizationsResponse\x129\n" +
+	"\rorganizations\x18\x01 \x03(\v2\x13.users.OrganizationR\rorganizations\"I\n" +
+	"\x1eListOrganizationMembersRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\"V\n" +
+	"\x1fListOrganizationMembersResponse\x123\n" +
+	"\amembers\x18\x01 \x03(\v2\x19.users.OrganizationMemberR\amembers\"\x90\x01\n" +
+	"\x1fUpdateOrganizationMemberRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12+\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x17.users.OrganizationRoleR\x04role\"c\n" +
+	"\x1fRemoveOrganizationMemberRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x89\x01\n" +
+	"\x1bInviteToOrganizationRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12+\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x17.users.OrganizationRoleR\x04role\"\x1a\n" +
+	"\x18ListMyInvitationsRequest\"\\\n" +
+	"\x19ListMyInvitationsResponse\x12?\n" +
+	"\vinvitations\x18\x01 \x03(\v2\x1d.users.OrganizationInvitationR\vinvitations\"h\n" +
+	"\x18RespondInvitationRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12#\n" +
+	"\rinvitation_id\x18\x02 \x01(\tR\finvitationId\"D\n" +
+	"\x19SwitchOrganizationRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\"\x9e\x01\n" +
 	"\x11ListUsersResponse\x12!\n" +
 	"\x05users\x18\x01 \x03(\v2\v.users.UserR\x05users\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
@@ -2313,7 +8119,51 @@ const file_v1_user_proto_rawDesc = "" +
 	"\x1aSUBSCRIPTION_LEVEL_STARTER\x10\b\x12\x1f\n" +
 	"\x1bSUBSCRIPTION_LEVEL_BUSINESS\x10\t\x12\x1f\n" +
 	"\x1bSUBSCRIPTION_LEVEL_ULTIMATE\x10\n" +
-	"2\xed\t\n" +
+	"*\x8d\x01\n" +
+	"\x10OrganizationRole\x12!\n" +
+	"\x1dORGANIZATION_ROLE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17ORGANIZATION_ROLE_OWNER\x10\x01\x12\x1b\n" +
+	"\x17ORGANIZATION_ROLE_ADMIN\x10\x02\x12\x1c\n" +
+	"\x18ORGANIZATION_ROLE_MEMBER\x10\x03*\x94\x01\n" +
+	"\x10InvitationStatus\x12!\n" +
+	"\x1dINVITATION_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19INVITATION_STATUS_PENDING\x10\x01\x12\x1e\n" +
+	"\x1aINVITATION_STATUS_ACCEPTED\x10\x02\x12\x1e\n" +
+	"\x1aINVITATION_STATUS_DECLINED\x10\x03*\xa3\x01\n" +
+	"\vBanCategory\x12\x1c\n" +
+	"\x18BAN_CATEGORY_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12BAN_CATEGORY_ADMIN\x10\x01\x12\x17\n" +
+	"\x13BAN_CATEGORY_SYSTEM\x10\x02\x12\x15\n" +
+	"\x11BAN_CATEGORY_SPAM\x10\x03\x12\x16\n" +
+	"\x12BAN_CATEGORY_ABUSE\x10\x04\x12\x16\n" +
+	"\x12BAN_CATEGORY_FRAUD\x10\x05*\x9d\x01\n" +
+	"\fAppealStatus\x12\x1d\n" +
+	"\x19APPEAL_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15APPEAL_STATUS_PENDING\x10\x01\x12\x1b\n" +
+	"\x17APPEAL_STATUS_IN_REVIEW\x10\x02\x12\x1a\n" +
+	"\x16APPEAL_STATUS_APPROVED\x10\x03\x12\x1a\n" +
+	"\x16APPEAL_STATUS_REJECTED\x10\x04*\x9c\x01\n" +
+	"\x0eReportCategory\x12\x1f\n" +
+	"\x1bREPORT_CATEGORY_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14REPORT_CATEGORY_SPAM\x10\x01\x12\x19\n" +
+	"\x15REPORT_CATEGORY_ABUSE\x10\x02\x12\x19\n" +
+	"\x15REPORT_CATEGORY_FRAUD\x10\x03\x12\x19\n" +
+	"\x15REPORT_CATEGORY_OTHER\x10\x04*|\n" +
+	"\fReportStatus\x12\x1d\n" +
+	"\x19REPORT_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12REPORT_STATUS_OPEN\x10\x01\x12\x19\n" +
+	"\x15REPORT_STATUS_CLAIMED\x10\x02\x12\x1a\n" +
+	"\x16REPORT_STATUS_RESOLVED\x10\x03*w\n" +
+	"\fReportAction\x12\x1d\n" +
+	"\x19REPORT_ACTION_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15REPORT_ACTION_DISMISS\x10\x01\x12\x16\n" +
+	"\x12REPORT_ACTION_WARN\x10\x02\x12\x15\n" +
+	"\x11REPORT_ACTION_BAN\x10\x03*\x81\x01\n" +
+	"\fDenyListKind\x12\x1e\n" +
+	"\x1aDENY_LIST_KIND_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11DENY_LIST_KIND_IP\x10\x01\x12\x1f\n" +
+	"\x1bDENY_LIST_KIND_EMAIL_DOMAIN\x10\x02\x12\x19\n" +
+	"\x15DENY_LIST_KIND_DEVICE\x10\x032\x98@\n" +
 	"\vUserService\x12M\n" +
 	"\n" +
 	"CreateUser\x12\x18.users.CreateUserRequest\x1a\v.users.User\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/users\x12Q\n" +
@@ -2324,12 +8174,69 @@ const file_v1_user_proto_rawDesc = "" +
 	"\n" +
 	"DeleteUser\x12\x18.users.DeleteUserRequest\x1a\x16.google.protobuf.Empty\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/users/{id}\x12U\n" +
 	"\tListUsers\x12\x17.users.ListUsersRequest\x1a\x18.users.ListUsersResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/users\x12f\n" +
-	"\fAuthenticate\x12\x1a.users.AuthenticateRequest\x1a\x1b.users.AuthenticateResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/api/v1/auth/login\x12l\n" +
-	"\rValidateToken\x12\x1b.users.ValidateTokenRequest\x1a\x1c.users.ValidateTokenResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/auth/validate\x12U\n" +
+	"\fAuthenticate\x12\x1a.users.AuthenticateRequest\x1a\x1b.users.AuthenticateResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/api/v1/auth/login\x12e\n" +
+	"\tVerifyMFA\x12\x17.users.VerifyMFARequest\x1a\x1b.users.AuthenticateResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/auth/mfa/verify\x12h\n" +
+	"\fRefreshToken\x12\x1a.users.RefreshTokenRequest\x1a\x1b.users.RefreshTokenResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/auth/refresh\x12l\n" +
+	"\rValidateToken\x12\x1b.users.ValidateTokenRequest\x1a\x1c.users.ValidateTokenResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/auth/validate\x12V\n" +
+	"\x06Logout\x12\x14.users.LogoutRequest\x1a\x16.google.protobuf.Empty\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/v1/auth/logout\x12}\n" +
+	"\x11RevokeAllSessions\x12\x1f.users.RevokeAllSessionsRequest\x1a\x16.google.protobuf.Empty\"/\x82\xd3\xe4\x93\x02)\"'/api/v1/users/{user_id}/sessions/revoke\x12[\n" +
+	"\vVerifyEmail\x12\x19.users.VerifyEmailRequest\x1a\v.users.User\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/auth/email/verify\x12t\n" +
+	"\x12ResendVerification\x12 .users.ResendVerificationRequest\x1a\x16.google.protobuf.Empty\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/auth/email/resend\x12u\n" +
+	"\fSendPhoneOTP\x12\x1a.users.SendPhoneOTPRequest\x1a\x1b.users.SendPhoneOTPResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/users/{user_id}/phone/otp\x12l\n" +
+	"\x0eVerifyPhoneOTP\x12\x1c.users.VerifyPhoneOTPRequest\x1a\v.users.User\"/\x82\xd3\xe4\x93\x02):\x01*\"$/api/v1/users/{user_id}/phone/verify\x12n\n" +
+	"\n" +
+	"EnrollTOTP\x12\x18.users.EnrollTOTPRequest\x1a\x19.users.EnrollTOTPResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/users/{user_id}/mfa/totp\x12y\n" +
+	"\vConfirmTOTP\x12\x19.users.ConfirmTOTPRequest\x1a\x1a.users.ConfirmTOTPResponse\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/api/v1/users/{user_id}/mfa/totp/confirm\x12u\n" +
+	"\vDisableTOTP\x12\x19.users.DisableTOTPRequest\x1a\x16.google.protobuf.Empty\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/api/v1/users/{user_id}/mfa/totp/disable\x12\x9e\x01\n" +
+	"\x19BeginWebAuthnRegistration\x12'.users.BeginWebAuthnRegistrationRequest\x1a\x1c.users.BeginWebAuthnResponse\":\x82\xd3\xe4\x93\x024:\x01*\"//api/v1/users/{user_id}/webauthn/register/begin\x12\x9e\x01\n" +
+	"\x1aFinishWebAuthnRegistration\x12(.users.FinishWebAuthnRegistrationRequest\x1a\x19.users.WebAuthnCredential\";\x82\xd3\xe4\x93\x025:\x01*\"0/api/v1/users/{user_id}/webauthn/register/finish\x12\x82\x01\n" +
+	"\x12BeginWebAuthnLogin\x12 .users.BeginWebAuthnLoginRequest\x1a\x1c.users.BeginWebAuthnResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/auth/webauthn/login/begin\x12\x84\x01\n" +
+	"\x13FinishWebAuthnLogin\x12!.users.FinishWebAuthnLoginRequest\x1a\x1b.users.AuthenticateResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/auth/webauthn/login/finish\x12\x9e\x01\n" +
+	"\x17ListWebAuthnCredentials\x12%.users.ListWebAuthnCredentialsRequest\x1a&.users.ListWebAuthnCredentialsResponse\"4\x82\xd3\xe4\x93\x02.\x12,/api/v1/users/{user_id}/webauthn/credentials\x12\xa6\x01\n" +
+	"\x18RenameWebAuthnCredential\x12&.users.RenameWebAuthnCredentialRequest\x1a\x19.users.WebAuthnCredential\"G\x82\xd3\xe4\x93\x02A:\x01*2</api/v1/users/{user_id}/webauthn/credentials/{credential_id}\x12\xa0\x01\n" +
+	"\x18DeleteWebAuthnCredential\x12&.users.DeleteWebAuthnCredentialRequest\x1a\x16.google.protobuf.Empty\"D\x82\xd3\xe4\x93\x02>*</api/v1/users/{user_id}/webauthn/credentials/{credential_id}\x12t\n" +
+	"\fCreateAPIKey\x12\x1a.users.CreateAPIKeyRequest\x1a\x1b.users.CreateAPIKeyResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/users/{user_id}/api-keys\x12n\n" +
+	"\vListAPIKeys\x12\x19.users.ListAPIKeysRequest\x1a\x1a.users.ListAPIKeysResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/users/{user_id}/api-keys\x12y\n" +
+	"\fRevokeAPIKey\x12\x1a.users.RevokeAPIKeyRequest\x1a\x16.google.protobuf.Empty\"5\x82\xd3\xe4\x93\x02/*-/api/v1/users/{user_id}/api-keys/{api_key_id}\x12}\n" +
+	"\x11IssueServiceToken\x12\x1f.users.IssueServiceTokenRequest\x1a .users.IssueServiceTokenResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/auth/service-token\x12z\n" +
+	"\x14RequestPasswordReset\x12\".users.RequestPasswordResetRequest\x1a\x16.google.protobuf.Empty\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/auth/password/reset\x12\x82\x01\n" +
+	"\x14ConfirmPasswordReset\x12\".users.ConfirmPasswordResetRequest\x1a\x16.google.protobuf.Empty\".\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/auth/password/reset/confirm\x12j\n" +
+	"\fClearLockout\x12\x1a.users.ClearLockoutRequest\x1a\x16.google.protobuf.Empty\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/admin/lockout/clear\x12\x80\x01\n" +
+	"\x0fImpersonateUser\x12\x1d.users.ImpersonateUserRequest\x1a\x1e.users.ImpersonateUserResponse\".\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/users/{user_id}/impersonate\x12u\n" +
+	"\x11StopImpersonation\x12\x1f.users.StopImpersonationRequest\x1a\x16.google.protobuf.Empty\"'\x82\xd3\xe4\x93\x02!\"\x1f/api/v1/auth/impersonation/stop\x12U\n" +
 	"\aBanUser\x12\x15.users.BanUserRequest\x1a\v.users.User\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/users/{user_id}/ban\x12X\n" +
-	"\tUnbanUser\x12\x17.users.UnbanUserRequest\x1a\v.users.User\"%\x82\xd3\xe4\x93\x02\x1f\"\x1d/api/v1/users/{user_id}/unban\x12t\n" +
+	"\tUnbanUser\x12\x17.users.UnbanUserRequest\x1a\v.users.User\"%\x82\xd3\xe4\x93\x02\x1f\"\x1d/api/v1/users/{user_id}/unban\x12q\n" +
+	"\x10GetBanStatistics\x12\x1e.users.GetBanStatisticsRequest\x1a\x1c.users.BanStatisticsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/bans/statistics\x12t\n" +
+	"\fIssueWarning\x12\x1a.users.IssueWarningRequest\x1a\x1b.users.IssueWarningResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/users/{user_id}/warnings\x12m\n" +
+	"\vListStrikes\x12\x19.users.ListStrikesRequest\x1a\x1a.users.ListStrikesResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/users/{user_id}/strikes\x12c\n" +
+	"\x0fSubmitBanAppeal\x12\x1d.users.SubmitBanAppealRequest\x1a\x10.users.BanAppeal\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/bans/appeals\x12k\n" +
+	"\x0eListBanAppeals\x12\x1c.users.ListBanAppealsRequest\x1a\x1d.users.ListBanAppealsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/bans/appeals\x12v\n" +
+	"\x0fAssignBanAppeal\x12\x1d.users.AssignBanAppealRequest\x1a\x10.users.BanAppeal\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/bans/appeals/{appeal_id}/assign\x12y\n" +
+	"\x10ApproveBanAppeal\x12\x1e.users.ResolveBanAppealRequest\x1a\x10.users.BanAppeal\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/api/v1/bans/appeals/{appeal_id}/approve\x12w\n" +
+	"\x0fRejectBanAppeal\x12\x1e.users.ResolveBanAppealRequest\x1a\x10.users.BanAppeal\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/bans/appeals/{appeal_id}/reject\x12m\n" +
+	"\n" +
+	"ReportUser\x12\x18.users.ReportUserRequest\x1a\x19.users.ReportUserResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/users/{user_id}/reports\x12\x80\x01\n" +
+	"\x13ListModerationQueue\x12!.users.ListModerationQueueRequest\x1a\".users.ListModerationQueueResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/moderation/reports\x12q\n" +
+	"\vClaimReport\x12\x19.users.ClaimReportRequest\x1a\x11.users.UserReport\"4\x82\xd3\xe4\x93\x02.\",/api/v1/moderation/reports/{report_id}/claim\x12z\n" +
+	"\rResolveReport\x12\x1b.users.ResolveReportRequest\x1a\x11.users.UserReport\"9\x82\xd3\xe4\x93\x023:\x01*\"./api/v1/moderation/reports/{report_id}/resolve\x12l\n" +
+	"\x13CreateDenyListEntry\x12!.users.CreateDenyListEntryRequest\x1a\x14.users.DenyListEntry\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/deny-list\x12n\n" +
+	"\x10GetDenyListEntry\x12\x1e.users.GetDenyListEntryRequest\x1a\x14.users.DenyListEntry\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/deny-list/{entry_id}\x12w\n" +
+	"\x13ListDenyListEntries\x12!.users.ListDenyListEntriesRequest\x1a\".users.ListDenyListEntriesResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/deny-list\x12w\n" +
+	"\x13UpdateDenyListEntry\x12!.users.UpdateDenyListEntryRequest\x1a\x14.users.DenyListEntry\"'\x82\xd3\xe4\x93\x02!:\x01*\x1a\x1c/api/v1/deny-list/{entry_id}\x12v\n" +
+	"\x13DeleteDenyListEntry\x12!.users.DeleteDenyListEntryRequest\x1a\x16.google.protobuf.Empty\"$\x82\xd3\xe4\x93\x02\x1e*\x1c/api/v1/deny-list/{entry_id}\x12t\n" +
 	"\x12UpdateSubscription\x12 .users.UpdateSubscriptionRequest\x1a\v.users.User\"/\x82\xd3\xe4\x93\x02):\x01*\x1a$/api/v1/users/{user_id}/subscription\x12x\n" +
-	"\x12CancelSubscription\x12 .users.CancelSubscriptionRequest\x1a\v.users.User\"3\x82\xd3\xe4\x93\x02-\"+/api/v1/users/{user_id}/subscription/cancel\x12\\\n" +
+	"\x12CancelSubscription\x12 .users.CancelSubscriptionRequest\x1a\v.users.User\"3\x82\xd3\xe4\x93\x02-\"+/api/v1/users/{user_id}/subscription/cancel\x12m\n" +
+	"\x12CreateOrganization\x12 .users.CreateOrganizationRequest\x1a\x13.users.Organization\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/organizations\x12v\n" +
+	"\x0fGetOrganization\x12\x1d.users.GetOrganizationRequest\x1a\x13.users.Organization\"/\x82\xd3\xe4\x93\x02)\x12'/api/v1/organizations/{organization_id}\x12u\n" +
+	"\x11ListOrganizations\x12\x1f.users.ListOrganizationsRequest\x1a .users.ListOrganizationsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/organizations\x12\xa1\x01\n" +
+	"\x17ListOrganizationMembers\x12%.users.ListOrganizationMembersRequest\x1a&.users.ListOrganizationMembersResponse\"7\x82\xd3\xe4\x93\x021\x12//api/v1/organizations/{organization_id}/members\x12\xa3\x01\n" +
+	"\x18UpdateOrganizationMember\x12&.users.UpdateOrganizationMemberRequest\x1a\x19.users.OrganizationMember\"D\x82\xd3\xe4\x93\x02>:\x01*29/api/v1/organizations/{organization_id}/members/{user_id}\x12\x9d\x01\n" +
+	"\x18RemoveOrganizationMember\x12&.users.RemoveOrganizationMemberRequest\x1a\x16.google.protobuf.Empty\"A\x82\xd3\xe4\x93\x02;*9/api/v1/organizations/{organization_id}/members/{user_id}\x12\x99\x01\n" +
+	"\x14InviteToOrganization\x12\".users.InviteToOrganizationRequest\x1a\x1d.users.OrganizationInvitation\">\x82\xd3\xe4\x93\x028:\x01*\"3/api/v1/organizations/{organization_id}/invitations\x12s\n" +
+	"\x11ListMyInvitations\x12\x1f.users.ListMyInvitationsRequest\x1a .users.ListMyInvitationsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/api/v1/invitations\x12\xa6\x01\n" +
+	"\x10AcceptInvitation\x12\x1f.users.RespondInvitationRequest\x1a\x1d.users.OrganizationInvitation\"R\x82\xd3\xe4\x93\x02L\"J/api/v1/organizations/{organization_id}/invitations/{invitation_id}/accept\x12\xa8\x01\n" +
+	"\x11DeclineInvitation\x12\x1f.users.RespondInvitationRequest\x1a\x1d.users.OrganizationInvitation\"S\x82\xd3\xe4\x93\x02M\"K/api/v1/organizations/{organization_id}/invitations/{invitation_id}/decline\x12y\n" +
+	"\x12SwitchOrganization\x12 .users.SwitchOrganizationRequest\x1a\x1b.users.AuthenticateResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/auth/organization\x12\\\n" +
 	"\vHealthCheck\x12\x19.users.HealthCheckRequest\x1a\x1a.users.HealthCheckResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/api/v1/healthB\rZ\v./gen;usersb\x06proto3"
 
 var (
@@ -2344,117 +8251,397 @@ func file_v1_user_proto_rawDescGZIP() []byte {
 	return file_v1_user_proto_rawDescData
 }
 
-var file_v1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_v1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 12)
+var file_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 112)
 var file_v1_user_proto_goTypes = []any{
-	(UserStatus)(0),                   // 0: users.UserStatus
-	(UserRole)(0),                     // 1: users.UserRole
-	(SubscriptionStatus)(0),           // 2: users.SubscriptionStatus
-	(SubscriptionLevel)(0),            // 3: users.SubscriptionLevel
-	(*User)(nil),                      // 4: users.User
-	(*BanInfo)(nil),                   // 5: users.BanInfo
-	(*SubscriptionInfo)(nil),          // 6: users.SubscriptionInfo
-	(*CreateUserRequest)(nil),         // 7: users.CreateUserRequest
-	(*GetUserByIdRequest)(nil),        // 8: users.GetUserByIdRequest
-	(*GetUserByEmailRequest)(nil),     // 9: users.GetUserByEmailRequest
-	(*UpdateUserRequest)(nil),         // 10: users.UpdateUserRequest
-	(*DeleteUserRequest)(nil),         // 11: users.DeleteUserRequest
-	(*ListUsersRequest)(nil),          // 12: users.ListUsersRequest
-	(*AuthenticateRequest)(nil),       // 13: users.AuthenticateRequest
-	(*AuthenticateResponse)(nil),      // 14: users.AuthenticateResponse
-	(*ValidateTokenRequest)(nil),      // 15: users.ValidateTokenRequest
-	(*ValidateTokenResponse)(nil),     // 16: users.ValidateTokenResponse
-	(*BanUserRequest)(nil),            // 17: users.BanUserRequest
-	(*UnbanUserRequest)(nil),          // 18: users.UnbanUserRequest
-	(*UpdateSubscriptionRequest)(nil), // 19: users.UpdateSubscriptionRequest
-	(*CancelSubscriptionRequest)(nil), // 20: users.CancelSubscriptionRequest
-	(*ListUsersResponse)(nil),         // 21: users.ListUsersResponse
-	(*HealthCheckRequest)(nil),        // 22: users.HealthCheckRequest
-	(*HealthCheckResponse)(nil),       // 23: users.HealthCheckResponse
-	(*SubscriptionAnalytics)(nil),     // 24: users.SubscriptionAnalytics
-	(*SubscriptionHistoryEntry)(nil),  // 25: users.SubscriptionHistoryEntry
-	nil,                               // 26: users.User.MetadataEntry
-	nil,                               // 27: users.UpdateUserRequest.MetadataEntry
-	nil,                               // 28: users.SubscriptionAnalytics.SubscriptionsByLevelEntry
-	nil,                               // 29: users.SubscriptionHistoryEntry.MetadataEntry
-	(*timestamppb.Timestamp)(nil),     // 30: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),             // 31: google.protobuf.Empty
+	(UserStatus)(0),                           // 0: users.UserStatus
+	(UserRole)(0),                             // 1: users.UserRole
+	(SubscriptionStatus)(0),                   // 2: users.SubscriptionStatus
+	(SubscriptionLevel)(0),                    // 3: users.SubscriptionLevel
+	(OrganizationRole)(0),                     // 4: users.OrganizationRole
+	(InvitationStatus)(0),                     // 5: users.InvitationStatus
+	(BanCategory)(0),                          // 6: users.BanCategory
+	(AppealStatus)(0),                         // 7: users.AppealStatus
+	(ReportCategory)(0),                       // 8: users.ReportCategory
+	(ReportStatus)(0),                         // 9: users.ReportStatus
+	(ReportAction)(0),                         // 10: users.ReportAction
+	(DenyListKind)(0),                         // 11: users.DenyListKind
+	(*User)(nil),                              // 12: users.User
+	(*BanInfo)(nil),                           // 13: users.BanInfo
+	(*SubscriptionInfo)(nil),                  // 14: users.SubscriptionInfo
+	(*CreateUserRequest)(nil),                 // 15: users.CreateUserRequest
+	(*GetUserByIdRequest)(nil),                // 16: users.GetUserByIdRequest
+	(*GetUserByEmailRequest)(nil),             // 17: users.GetUserByEmailRequest
+	(*UpdateUserRequest)(nil),                 // 18: users.UpdateUserRequest
+	(*DeleteUserRequest)(nil),                 // 19: users.DeleteUserRequest
+	(*ListUsersRequest)(nil),                  // 20: users.ListUsersRequest
+	(*AuthenticateRequest)(nil),               // 21: users.AuthenticateRequest
+	(*AuthenticateResponse)(nil),              // 22: users.AuthenticateResponse
+	(*VerifyMFARequest)(nil),                  // 23: users.VerifyMFARequest
+	(*RefreshTokenRequest)(nil),               // 24: users.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),              // 25: users.RefreshTokenResponse
+	(*ValidateTokenRequest)(nil),              // 26: users.ValidateTokenRequest
+	(*ValidateTokenResponse)(nil),             // 27: users.ValidateTokenResponse
+	(*LogoutRequest)(nil),                     // 28: users.LogoutRequest
+	(*RevokeAllSessionsRequest)(nil),          // 29: users.RevokeAllSessionsRequest
+	(*VerifyEmailRequest)(nil),                // 30: users.VerifyEmailRequest
+	(*ResendVerificationRequest)(nil),         // 31: users.ResendVerificationRequest
+	(*SendPhoneOTPRequest)(nil),               // 32: users.SendPhoneOTPRequest
+	(*SendPhoneOTPResponse)(nil),              // 33: users.SendPhoneOTPResponse
+	(*VerifyPhoneOTPRequest)(nil),             // 34: users.VerifyPhoneOTPRequest
+	(*EnrollTOTPRequest)(nil),                 // 35: users.EnrollTOTPRequest
+	(*EnrollTOTPResponse)(nil),                // 36: users.EnrollTOTPResponse
+	(*ConfirmTOTPRequest)(nil),                // 37: users.ConfirmTOTPRequest
+	(*ConfirmTOTPResponse)(nil),               // 38: users.ConfirmTOTPResponse
+	(*DisableTOTPRequest)(nil),                // 39: users.DisableTOTPRequest
+	(*WebAuthnCredential)(nil),                // 40: users.WebAuthnCredential
+	(*BeginWebAuthnRegistrationRequest)(nil),  // 41: users.BeginWebAuthnRegistrationRequest
+	(*BeginWebAuthnLoginRequest)(nil),         // 42: users.BeginWebAuthnLoginRequest
+	(*BeginWebAuthnResponse)(nil),             // 43: users.BeginWebAuthnResponse
+	(*FinishWebAuthnRegistrationRequest)(nil), // 44: users.FinishWebAuthnRegistrationRequest
+	(*FinishWebAuthnLoginRequest)(nil),        // 45: users.FinishWebAuthnLoginRequest
+	(*ListWebAuthnCredentialsRequest)(nil),    // 46: users.ListWebAuthnCredentialsRequest
+	(*ListWebAuthnCredentialsResponse)(nil),   // 47: users.ListWebAuthnCredentialsResponse
+	(*RenameWebAuthnCredentialRequest)(nil),   // 48: users.RenameWebAuthnCredentialRequest
+	(*DeleteWebAuthnCredentialRequest)(nil),   // 49: users.DeleteWebAuthnCredentialRequest
+	(*APIKey)(nil),                            // 50: users.APIKey
+	(*CreateAPIKeyRequest)(nil),               // 51: users.CreateAPIKeyRequest
+	(*CreateAPIKeyResponse)(nil),              // 52: users.CreateAPIKeyResponse
+	(*ListAPIKeysRequest)(nil),                // 53: users.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),               // 54: users.ListAPIKeysResponse
+	(*RevokeAPIKeyRequest)(nil),               // 55: users.RevokeAPIKeyRequest
+	(*ImpersonateUserRequest)(nil),            // 56: users.ImpersonateUserRequest
+	(*ImpersonateUserResponse)(nil),           // 57: users.ImpersonateUserResponse
+	(*StopImpersonationRequest)(nil),          // 58: users.StopImpersonationRequest
+	(*IssueServiceTokenRequest)(nil),          // 59: users.IssueServiceTokenRequest
+	(*IssueServiceTokenResponse)(nil),         // 60: users.IssueServiceTokenResponse
+	(*RequestPasswordResetRequest)(nil),       // 61: users.RequestPasswordResetRequest
+	(*ConfirmPasswordResetRequest)(nil),       // 62: users.ConfirmPasswordResetRequest
+	(*ClearLockoutRequest)(nil),               // 63: users.ClearLockoutRequest
+	(*BanUserRequest)(nil),                    // 64: users.BanUserRequest
+	(*UnbanUserRequest)(nil),                  // 65: users.UnbanUserRequest
+	(*GetBanStatisticsRequest)(nil),           // 66: users.GetBanStatisticsRequest
+	(*BanCategoryStats)(nil),                  // 67: users.BanCategoryStats
+	(*BanStatisticsResponse)(nil),             // 68: users.BanStatisticsResponse
+	(*Strike)(nil),                            // 69: users.Strike
+	(*IssueWarningRequest)(nil),               // 70: users.IssueWarningRequest
+	(*IssueWarningResponse)(nil),              // 71: users.IssueWarningResponse
+	(*ListStrikesRequest)(nil),                // 72: users.ListStrikesRequest
+	(*ListStrikesResponse)(nil),               // 73: users.ListStrikesResponse
+	(*BanAppeal)(nil),                         // 74: users.BanAppeal
+	(*SubmitBanAppealRequest)(nil),            // 75: users.SubmitBanAppealRequest
+	(*ListBanAppealsRequest)(nil),             // 76: users.ListBanAppealsRequest
+	(*ListBanAppealsResponse)(nil),            // 77: users.ListBanAppealsResponse
+	(*AssignBanAppealRequest)(nil),            // 78: users.AssignBanAppealRequest
+	(*ResolveBanAppealRequest)(nil),           // 79: users.ResolveBanAppealRequest
+	(*UserReport)(nil),                        // 80: users.UserReport
+	(*ReportEntry)(nil),                       // 81: users.ReportEntry
+	(*ReportUserRequest)(nil),                 // 82: users.ReportUserRequest
+	(*ReportUserResponse)(nil),                // 83: users.ReportUserResponse
+	(*ListModerationQueueRequest)(nil),        // 84: users.ListModerationQueueRequest
+	(*ListModerationQueueResponse)(nil),       // 85: users.ListModerationQueueResponse
+	(*ClaimReportRequest)(nil),                // 86: users.ClaimReportRequest
+	(*ResolveReportRequest)(nil),              // 87: users.ResolveReportRequest
+	(*DenyListEntry)(nil),                     // 88: users.DenyListEntry
+	(*CreateDenyListEntryRequest)(nil),        // 89: users.CreateDenyListEntryRequest
+	(*GetDenyListEntryRequest)(nil),           // 90: users.GetDenyListEntryRequest
+	(*ListDenyListEntriesRequest)(nil),        // 91: users.ListDenyListEntriesRequest
+	(*ListDenyListEntriesResponse)(nil),       // 92: users.ListDenyListEntriesResponse
+	(*UpdateDenyListEntryRequest)(nil),        // 93: users.UpdateDenyListEntryRequest
+	(*DeleteDenyListEntryRequest)(nil),        // 94: users.DeleteDenyListEntryRequest
+	(*UpdateSubscriptionRequest)(nil),         // 95: users.UpdateSubscriptionRequest
+	(*CancelSubscriptionRequest)(nil),         // 96: users.CancelSubscriptionRequest
+	(*Organization)(nil),                      // 97: users.Organization
+	(*OrganizationMember)(nil),                // 98: users.OrganizationMember
+	(*OrganizationInvitation)(nil),            // 99: users.OrganizationInvitation
+	(*CreateOrganizationRequest)(nil),         // 100: users.CreateOrganizationRequest
+	(*GetOrganizationRequest)(nil),            // 101: users.GetOrganizationRequest
+	(*ListOrganizationsRequest)(nil),          // 102: users.ListOrganizationsRequest
+	(*ListOrganizationsResponse)(nil),         // 103: users.ListOrganizationsResponse
+	(*ListOrganizationMembersRequest)(nil),    // 104: users.ListOrganizationMembersRequest
+	(*ListOrganizationMembersResponse)(nil),   // 105: users.ListOrganizationMembersResponse
+	(*UpdateOrganizationMemberRequest)(nil),   // 106: users.UpdateOrganizationMemberRequest
+	(*RemoveOrganizationMemberRequest)(nil),   // 107: users.RemoveOrganizationMemberRequest
+	(*InviteToOrganizationRequest)(nil),       // 108: users.InviteToOrganizationRequest
+	(*ListMyInvitationsRequest)(nil),          // 109: users.ListMyInvitationsRequest
+	(*ListMyInvitationsResponse)(nil),         // 110: users.ListMyInvitationsResponse
+	(*RespondInvitationRequest)(nil),          // 111: users.RespondInvitationRequest
+	(*SwitchOrganizationRequest)(nil),         // 112: users.SwitchOrganizationRequest
+	(*ListUsersResponse)(nil),                 // 113: users.ListUsersResponse
+	(*HealthCheckRequest)(nil),                // 114: users.HealthCheckRequest
+	(*HealthCheckResponse)(nil),               // 115: users.HealthCheckResponse
+	(*SubscriptionAnalytics)(nil),             // 116: users.SubscriptionAnalytics
+	(*SubscriptionHistoryEntry)(nil),          // 117: users.SubscriptionHistoryEntry
+	nil,                                       // 118: users.User.MetadataEntry
+	nil,                                       // 119: users.UpdateUserRequest.MetadataEntry
+	nil,                                       // 120: users.ReportEntry.EvidenceEntry
+	nil,                                       // 121: users.ReportUserRequest.EvidenceEntry
+	nil,                                       // 122: users.SubscriptionAnalytics.SubscriptionsByLevelEntry
+	nil,                                       // 123: users.SubscriptionHistoryEntry.MetadataEntry
+	(*timestamppb.Timestamp)(nil),             // 124: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                     // 125: google.protobuf.Empty
 }
 var file_v1_user_proto_depIdxs = []int32{
-	0,  // 0: users.User.status:type_name -> users.UserStatus
-	1,  // 1: users.User.role:type_name -> users.UserRole
-	30, // 2: users.User.created_at:type_name -> google.protobuf.Timestamp
-	30, // 3: users.User.updated_at:type_name -> google.protobuf.Timestamp
-	30, // 4: users.User.last_login_at:type_name -> google.protobuf.Timestamp
-	5,  // 5: users.User.ban_info:type_name -> users.BanInfo
-	6,  // 6: users.User.subscription:type_name -> users.SubscriptionInfo
-	26, // 7: users.User.metadata:type_name -> users.User.MetadataEntry
-	30, // 8: users.BanInfo.banned_at:type_name -> google.protobuf.Timestamp
-	30, // 9: users.BanInfo.banned_until:type_name -> google.protobuf.Timestamp
-	2,  // 10: users.SubscriptionInfo.status:type_name -> users.SubscriptionStatus
-	3,  // 11: users.SubscriptionInfo.level:type_name -> users.SubscriptionLevel
-	30, // 12: users.SubscriptionInfo.subscription_start:type_name -> google.protobuf.Timestamp
-	30, // 13: users.SubscriptionInfo.subscription_end:type_name -> google.protobuf.Timestamp
-	30, // 14: users.SubscriptionInfo.trial_end:type_name -> google.protobuf.Timestamp
-	30, // 15: users.SubscriptionInfo.next_billing_date:type_name -> google.protobuf.Timestamp
-	30, // 16: users.SubscriptionInfo.canceled_at:type_name -> google.protobuf.Timestamp
-	30, // 17: users.SubscriptionInfo.grace_period_end:type_name -> google.protobuf.Timestamp
-	1,  // 18: users.CreateUserRequest.role:type_name -> users.UserRole
-	3,  // 19: users.CreateUserRequest.initial_subscription_level:type_name -> users.SubscriptionLevel
-	0,  // 20: users.UpdateUserRequest.status:type_name -> users.UserStatus
-	1,  // 21: users.UpdateUserRequest.role:type_name -> users.UserRole
-	27, // 22: users.UpdateUserRequest.metadata:type_name -> users.UpdateUserRequest.MetadataEntry
-	0,  // 23: users.ListUsersRequest.status:type_name -> users.UserStatus
-	1,  // 24: users.ListUsersRequest.role:type_name -> users.UserRole
-	2,  // 25: users.ListUsersRequest.subscription_status:type_name -> users.SubscriptionStatus
-	3,  // 26: users.ListUsersRequest.subscription_level:type_name -> users.SubscriptionLevel
-	4,  // 27: users.AuthenticateResponse.user:type_name -> users.User
-	30, // 28: users.AuthenticateResponse.expires_at:type_name -> google.protobuf.Timestamp
-	4,  // 29: users.ValidateTokenResponse.user:type_name -> users.User
-	30, // 30: users.BanUserRequest.banned_until:type_name -> google.protobuf.Timestamp
-	3,  // 31: users.UpdateSubscriptionRequest.level:type_name -> users.SubscriptionLevel
-	2,  // 32: users.UpdateSubscriptionRequest.status:type_name -> users.SubscriptionStatus
-	30, // 33: users.UpdateSubscriptionRequest.subscription_end:type_name -> google.protobuf.Timestamp
-	30, // 34: users.UpdateSubscriptionRequest.trial_end:type_name -> google.protobuf.Timestamp
-	4,  // 35: users.ListUsersResponse.users:type_name -> users.User
-	28, // 36: users.SubscriptionAnalytics.subscriptions_by_level:type_name -> users.SubscriptionAnalytics.SubscriptionsByLevelEntry
-	3,  // 37: users.SubscriptionHistoryEntry.old_level:type_name -> users.SubscriptionLevel
-	3,  // 38: users.SubscriptionHistoryEntry.new_level:type_name -> users.SubscriptionLevel
-	2,  // 39: users.SubscriptionHistoryEntry.old_status:type_name -> users.SubscriptionStatus
-	2,  // 40: users.SubscriptionHistoryEntry.new_status:type_name -> users.SubscriptionStatus
-	30, // 41: users.SubscriptionHistoryEntry.changed_at:type_name -> google.protobuf.Timestamp
-	29, // 42: users.SubscriptionHistoryEntry.metadata:type_name -> users.SubscriptionHistoryEntry.MetadataEntry
-	7,  // 43: users.UserService.CreateUser:input_type -> users.CreateUserRequest
-	8,  // 44: users.UserService.GetUserById:input_type -> users.GetUserByIdRequest
-	9,  // 45: users.UserService.GetUserByEmail:input_type -> users.GetUserByEmailRequest
-	10, // 46: users.UserService.UpdateUser:input_type -> users.UpdateUserRequest
-	11, // 47: users.UserService.DeleteUser:input_type -> users.DeleteUserRequest
-	12, // 48: users.UserService.ListUsers:input_type -> users.ListUsersRequest
-	13, // 49: users.UserService.Authenticate:input_type -> users.AuthenticateRequest
-	15, // 50: users.UserService.ValidateToken:input_type -> users.ValidateTokenRequest
-	17, // 51: users.UserService.BanUser:input_type -> users.BanUserRequest
-	18, // 52: users.UserService.UnbanUser:input_type -> users.UnbanUserRequest
-	19, // 53: users.UserService.UpdateSubscription:input_type -> users.UpdateSubscriptionRequest
-	20, // 54: users.UserService.CancelSubscription:input_type -> users.CancelSubscriptionRequest
-	22, // 55: users.UserService.HealthCheck:input_type -> users.HealthCheckRequest
-	4,  // 56: users.UserService.CreateUser:output_type -> users.User
-	4,  // 57: users.UserService.GetUserById:output_type -> users.User
-	4,  // 58: users.UserService.GetUserByEmail:output_type -> users.User
-	4,  // 59: users.UserService.UpdateUser:output_type -> users.User
-	31, // 60: users.UserService.DeleteUser:output_type -> google.protobuf.Empty
-	21, // 61: users.UserService.ListUsers:output_type -> users.ListUsersResponse
-	14, // 62: users.UserService.Authenticate:output_type -> users.AuthenticateResponse
-	16, // 63: users.UserService.ValidateToken:output_type -> users.ValidateTokenResponse
-	4,  // 64: users.UserService.BanUser:output_type -> users.User
-	4,  // 65: users.UserService.UnbanUser:output_type -> users.User
-	4,  // 66: users.UserService.UpdateSubscription:output_type -> users.User
-	4,  // 67: users.UserService.CancelSubscription:output_type -> users.User
-	23, // 68: users.UserService.HealthCheck:output_type -> users.HealthCheckResponse
-	56, // [56:69] is the sub-list for method output_type
-	43, // [43:56] is the sub-list for method input_type
-	43, // [43:43] is the sub-list for extension type_name
-	43, // [43:43] is the sub-list for extension extendee
-	0,  // [0:43] is the sub-list for field type_name
+	0,   // 0: users.User.status:type_name -> users.UserStatus
+	1,   // 1: users.User.role:type_name -> users.UserRole
+	124, // 2: users.User.created_at:type_name -> google.protobuf.Timestamp
+	124, // 3: users.User.updated_at:type_name -> google.protobuf.Timestamp
+	124, // 4: users.User.last_login_at:type_name -> google.protobuf.Timestamp
+	13,  // 5: users.User.ban_info:type_name -> users.BanInfo
+	14,  // 6: users.User.subscription:type_name -> users.SubscriptionInfo
+	118, // 7: users.User.metadata:type_name -> users.User.MetadataEntry
+	124, // 8: users.User.phone_verified_at:type_name -> google.protobuf.Timestamp
+	124, // 9: users.BanInfo.banned_at:type_name -> google.protobuf.Timestamp
+	124, // 10: users.BanInfo.banned_until:type_name -> google.protobuf.Timestamp
+	6,   // 11: users.BanInfo.category:type_name -> users.BanCategory
+	2,   // 12: users.SubscriptionInfo.status:type_name -> users.SubscriptionStatus
+	3,   // 13: users.SubscriptionInfo.level:type_name -> users.SubscriptionLevel
+	124, // 14: users.SubscriptionInfo.subscription_start:type_name -> google.protobuf.Timestamp
+	124, // 15: users.SubscriptionInfo.subscription_end:type_name -> google.protobuf.Timestamp
+	124, // 16: users.SubscriptionInfo.trial_end:type_name -> google.protobuf.Timestamp
+	124, // 17: users.SubscriptionInfo.next_billing_date:type_name -> google.protobuf.Timestamp
+	124, // 18: users.SubscriptionInfo.canceled_at:type_name -> google.protobuf.Timestamp
+	124, // 19: users.SubscriptionInfo.grace_period_end:type_name -> google.protobuf.Timestamp
+	1,   // 20: users.CreateUserRequest.role:type_name -> users.UserRole
+	3,   // 21: users.CreateUserRequest.initial_subscription_level:type_name -> users.SubscriptionLevel
+	0,   // 22: users.UpdateUserRequest.status:type_name -> users.UserStatus
+	1,   // 23: users.UpdateUserRequest.role:type_name -> users.UserRole
+	119, // 24: users.UpdateUserRequest.metadata:type_name -> users.UpdateUserRequest.MetadataEntry
+	0,   // 25: users.ListUsersRequest.status:type_name -> users.UserStatus
+	1,   // 26: users.ListUsersRequest.role:type_name -> users.UserRole
+	2,   // 27: users.ListUsersRequest.subscription_status:type_name -> users.SubscriptionStatus
+	3,   // 28: users.ListUsersRequest.subscription_level:type_name -> users.SubscriptionLevel
+	6,   // 29: users.ListUsersRequest.ban_category:type_name -> users.BanCategory
+	12,  // 30: users.AuthenticateResponse.user:type_name -> users.User
+	124, // 31: users.AuthenticateResponse.expires_at:type_name -> google.protobuf.Timestamp
+	124, // 32: users.AuthenticateResponse.refresh_expires_at:type_name -> google.protobuf.Timestamp
+	124, // 33: users.RefreshTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	124, // 34: users.RefreshTokenResponse.refresh_expires_at:type_name -> google.protobuf.Timestamp
+	12,  // 35: users.RefreshTokenResponse.user:type_name -> users.User
+	12,  // 36: users.ValidateTokenResponse.user:type_name -> users.User
+	124, // 37: users.SendPhoneOTPResponse.expires_at:type_name -> google.protobuf.Timestamp
+	124, // 38: users.WebAuthnCredential.created_at:type_name -> google.protobuf.Timestamp
+	124, // 39: users.WebAuthnCredential.last_used_at:type_name -> google.protobuf.Timestamp
+	40,  // 40: users.ListWebAuthnCredentialsResponse.credentials:type_name -> users.WebAuthnCredential
+	124, // 41: users.APIKey.expires_at:type_name -> google.protobuf.Timestamp
+	124, // 42: users.APIKey.last_used_at:type_name -> google.protobuf.Timestamp
+	124, // 43: users.APIKey.created_at:type_name -> google.protobuf.Timestamp
+	124, // 44: users.CreateAPIKeyRequest.expires_at:type_name -> google.protobuf.Timestamp
+	50,  // 45: users.CreateAPIKeyResponse.api_key:type_name -> users.APIKey
+	50,  // 46: users.ListAPIKeysResponse.api_keys:type_name -> users.APIKey
+	124, // 47: users.ImpersonateUserResponse.expires_at:type_name -> google.protobuf.Timestamp
+	12,  // 48: users.ImpersonateUserResponse.user:type_name -> users.User
+	124, // 49: users.IssueServiceTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	124, // 50: users.BanUserRequest.banned_until:type_name -> google.protobuf.Timestamp
+	6,   // 51: users.BanUserRequest.category:type_name -> users.BanCategory
+	6,   // 52: users.BanCategoryStats.category:type_name -> users.BanCategory
+	67,  // 53: users.BanStatisticsResponse.categories:type_name -> users.BanCategoryStats
+	124, // 54: users.Strike.issued_at:type_name -> google.protobuf.Timestamp
+	124, // 55: users.Strike.expires_at:type_name -> google.protobuf.Timestamp
+	69,  // 56: users.IssueWarningResponse.strike:type_name -> users.Strike
+	12,  // 57: users.IssueWarningResponse.user:type_name -> users.User
+	69,  // 58: users.ListStrikesResponse.strikes:type_name -> users.Strike
+	124, // 59: users.BanAppeal.banned_at:type_name -> google.protobuf.Timestamp
+	7,   // 60: users.BanAppeal.status:type_name -> users.AppealStatus
+	124, // 61: users.BanAppeal.created_at:type_name -> google.protobuf.Timestamp
+	124, // 62: users.BanAppeal.updated_at:type_name -> google.protobuf.Timestamp
+	124, // 63: users.BanAppeal.resolved_at:type_name -> google.protobuf.Timestamp
+	7,   // 64: users.ListBanAppealsRequest.status:type_name -> users.AppealStatus
+	74,  // 65: users.ListBanAppealsResponse.appeals:type_name -> users.BanAppeal
+	8,   // 66: users.UserReport.category:type_name -> users.ReportCategory
+	9,   // 67: users.UserReport.status:type_name -> users.ReportStatus
+	124, // 68: users.UserReport.claimed_at:type_name -> google.protobuf.Timestamp
+	124, // 69: users.UserReport.created_at:type_name -> google.protobuf.Timestamp
+	124, // 70: users.UserReport.updated_at:type_name -> google.protobuf.Timestamp
+	10,  // 71: users.UserReport.action:type_name -> users.ReportAction
+	124, // 72: users.UserReport.resolved_at:type_name -> google.protobuf.Timestamp
+	124, // 73: users.UserReport.banned_at:type_name -> google.protobuf.Timestamp
+	81,  // 74: users.UserReport.entries:type_name -> users.ReportEntry
+	120, // 75: users.ReportEntry.evidence:type_name -> users.ReportEntry.EvidenceEntry
+	124, // 76: users.ReportEntry.created_at:type_name -> google.protobuf.Timestamp
+	8,   // 77: users.ReportUserRequest.category:type_name -> users.ReportCategory
+	121, // 78: users.ReportUserRequest.evidence:type_name -> users.ReportUserRequest.EvidenceEntry
+	9,   // 79: users.ReportUserResponse.status:type_name -> users.ReportStatus
+	9,   // 80: users.ListModerationQueueRequest.status:type_name -> users.ReportStatus
+	8,   // 81: users.ListModerationQueueRequest.category:type_name -> users.ReportCategory
+	80,  // 82: users.ListModerationQueueResponse.reports:type_name -> users.UserReport
+	10,  // 83: users.ResolveReportRequest.action:type_name -> users.ReportAction
+	124, // 84: users.ResolveReportRequest.banned_until:type_name -> google.protobuf.Timestamp
+	11,  // 85: users.DenyListEntry.kind:type_name -> users.DenyListKind
+	124, // 86: users.DenyListEntry.created_at:type_name -> google.protobuf.Timestamp
+	124, // 87: users.DenyListEntry.updated_at:type_name -> google.protobuf.Timestamp
+	124, // 88: users.DenyListEntry.expires_at:type_name -> google.protobuf.Timestamp
+	11,  // 89: users.CreateDenyListEntryRequest.kind:type_name -> users.DenyListKind
+	124, // 90: users.CreateDenyListEntryRequest.expires_at:type_name -> google.protobuf.Timestamp
+	11,  // 91: users.ListDenyListEntriesRequest.kind:type_name -> users.DenyListKind
+	88,  // 92: users.ListDenyListEntriesResponse.entries:type_name -> users.DenyListEntry
+	124, // 93: users.UpdateDenyListEntryRequest.expires_at:type_name -> google.protobuf.Timestamp
+	3,   // 94: users.UpdateSubscriptionRequest.level:type_name -> users.SubscriptionLevel
+	2,   // 95: users.UpdateSubscriptionRequest.status:type_name -> users.SubscriptionStatus
+	124, // 96: users.UpdateSubscriptionRequest.subscription_end:type_name -> google.protobuf.Timestamp
+	124, // 97: users.UpdateSubscriptionRequest.trial_end:type_name -> google.protobuf.Timestamp
+	124, // 98: users.Organization.created_at:type_name -> google.protobuf.Timestamp
+	124, // 99: users.Organization.updated_at:type_name -> google.protobuf.Timestamp
+	4,   // 100: users.OrganizationMember.role:type_name -> users.OrganizationRole
+	124, // 101: users.OrganizationMember.joined_at:type_name -> google.protobuf.Timestamp
+	4,   // 102: users.OrganizationInvitation.role:type_name -> users.OrganizationRole
+	5,   // 103: users.OrganizationInvitation.status:type_name -> users.InvitationStatus
+	124, // 104: users.OrganizationInvitation.expires_at:type_name -> google.protobuf.Timestamp
+	124, // 105: users.OrganizationInvitation.created_at:type_name -> google.protobuf.Timestamp
+	97,  // 106: users.ListOrganizationsResponse.organizations:type_name -> users.Organization
+	98,  // 107: users.ListOrganizationMembersResponse.members:type_name -> users.OrganizationMember
+	4,   // 108: users.UpdateOrganizationMemberRequest.role:type_name -> users.OrganizationRole
+	4,   // 109: users.InviteToOrganizationRequest.role:type_name -> users.OrganizationRole
+	99,  // 110: users.ListMyInvitationsResponse.invitations:type_name -> users.OrganizationInvitation
+	12,  // 111: users.ListUsersResponse.users:type_name -> users.User
+	122, // 112: users.SubscriptionAnalytics.subscriptions_by_level:type_name -> users.SubscriptionAnalytics.SubscriptionsByLevelEntry
+	3,   // 113: users.SubscriptionHistoryEntry.old_level:type_name -> users.SubscriptionLevel
+	3,   // 114: users.SubscriptionHistoryEntry.new_level:type_name -> users.SubscriptionLevel
+	2,   // 115: users.SubscriptionHistoryEntry.old_status:type_name -> users.SubscriptionStatus
+	2,   // 116: users.SubscriptionHistoryEntry.new_status:type_name -> users.SubscriptionStatus
+	124, // 117: users.SubscriptionHistoryEntry.changed_at:type_name -> google.protobuf.Timestamp
+	123, // 118: users.SubscriptionHistoryEntry.metadata:type_name -> users.SubscriptionHistoryEntry.MetadataEntry
+	15,  // 119: users.UserService.CreateUser:input_type -> users.CreateUserRequest
+	16,  // 120: users.UserService.GetUserById:input_type -> users.GetUserByIdRequest
+	17,  // 121: users.UserService.GetUserByEmail:input_type -> users.GetUserByEmailRequest
+	18,  // 122: users.UserService.UpdateUser:input_type -> users.UpdateUserRequest
+	19,  // 123: users.UserService.DeleteUser:input_type -> users.DeleteUserRequest
+	20,  // 124: users.UserService.ListUsers:input_type -> users.ListUsersRequest
+	21,  // 125: users.UserService.Authenticate:input_type -> users.AuthenticateRequest
+	23,  // 126: users.UserService.VerifyMFA:input_type -> users.VerifyMFARequest
+	24,  // 127: users.UserService.RefreshToken:input_type -> users.RefreshTokenRequest
+	26,  // 128: users.UserService.ValidateToken:input_type -> users.ValidateTokenRequest
+	28,  // 129: users.UserService.Logout:input_type -> users.LogoutRequest
+	29,  // 130: users.UserService.RevokeAllSessions:input_type -> users.RevokeAllSessionsRequest
+	30,  // 131: users.UserService.VerifyEmail:input_type -> users.VerifyEmailRequest
+	31,  // 132: users.UserService.ResendVerification:input_type -> users.ResendVerificationRequest
+	32,  // 133: users.UserService.SendPhoneOTP:input_type -> users.SendPhoneOTPRequest
+	34,  // 134: users.UserService.VerifyPhoneOTP:input_type -> users.VerifyPhoneOTPRequest
+	35,  // 135: users.UserService.EnrollTOTP:input_type -> users.EnrollTOTPRequest
+	37,  // 136: users.UserService.ConfirmTOTP:input_type -> users.ConfirmTOTPRequest
+	39,  // 137: users.UserService.DisableTOTP:input_type -> users.DisableTOTPRequest
+	41,  // 138: users.UserService.BeginWebAuthnRegistration:input_type -> users.BeginWebAuthnRegistrationRequest
+	44,  // 139: users.UserService.FinishWebAuthnRegistration:input_type -> users.FinishWebAuthnRegistrationRequest
+	42,  // 140: users.UserService.BeginWebAuthnLogin:input_type -> users.BeginWebAuthnLoginRequest
+	45,  // 141: users.UserService.FinishWebAuthnLogin:input_type -> users.FinishWebAuthnLoginRequest
+	46,  // 142: users.UserService.ListWebAuthnCredentials:input_type -> users.ListWebAuthnCredentialsRequest
+	48,  // 143: users.UserService.RenameWebAuthnCredential:input_type -> users.RenameWebAuthnCredentialRequest
+	49,  // 144: users.UserService.DeleteWebAuthnCredential:input_type -> users.DeleteWebAuthnCredentialRequest
+	51,  // 145: users.UserService.CreateAPIKey:input_type -> users.CreateAPIKeyRequest
+	53,  // 146: users.UserService.ListAPIKeys:input_type -> users.ListAPIKeysRequest
+	55,  // 147: users.UserService.RevokeAPIKey:input_type -> users.RevokeAPIKeyRequest
+	59,  // 148: users.UserService.IssueServiceToken:input_type -> users.IssueServiceTokenRequest
+	61,  // 149: users.UserService.RequestPasswordReset:input_type -> users.RequestPasswordResetRequest
+	62,  // 150: users.UserService.ConfirmPasswordReset:input_type -> users.ConfirmPasswordResetRequest
+	63,  // 151: users.UserService.ClearLockout:input_type -> users.ClearLockoutRequest
+	56,  // 152: users.UserService.ImpersonateUser:input_type -> users.ImpersonateUserRequest
+	58,  // 153: users.UserService.StopImpersonation:input_type -> users.StopImpersonationRequest
+	64,  // 154: users.UserService.BanUser:input_type -> users.BanUserRequest
+	65,  // 155: users.UserService.UnbanUser:input_type -> users.UnbanUserRequest
+	66,  // 156: users.UserService.GetBanStatistics:input_type -> users.GetBanStatisticsRequest
+	70,  // 157: users.UserService.IssueWarning:input_type -> users.IssueWarningRequest
+	72,  // 158: users.UserService.ListStrikes:input_type -> users.ListStrikesRequest
+	75,  // 159: users.UserService.SubmitBanAppeal:input_type -> users.SubmitBanAppealRequest
+	76,  // 160: users.UserService.ListBanAppeals:input_type -> users.ListBanAppealsRequest
+	78,  // 161: users.UserService.AssignBanAppeal:input_type -> users.AssignBanAppealRequest
+	79,  // 162: users.UserService.ApproveBanAppeal:input_type -> users.ResolveBanAppealRequest
+	79,  // 163: users.UserService.RejectBanAppeal:input_type -> users.ResolveBanAppealRequest
+	82,  // 164: users.UserService.ReportUser:input_type -> users.ReportUserRequest
+	84,  // 165: users.UserService.ListModerationQueue:input_type -> users.ListModerationQueueRequest
+	86,  // 166: users.UserService.ClaimReport:input_type -> users.ClaimReportRequest
+	87,  // 167: users.UserService.ResolveReport:input_type -> users.ResolveReportRequest
+	89,  // 168: users.UserService.CreateDenyListEntry:input_type -> users.CreateDenyListEntryRequest
+	90,  // 169: users.UserService.GetDenyListEntry:input_type -> users.GetDenyListEntryRequest
+	91,  // 170: users.UserService.ListDenyListEntries:input_type -> users.ListDenyListEntriesRequest
+	93,  // 171: users.UserService.UpdateDenyListEntry:input_type -> users.UpdateDenyListEntryRequest
+	94,  // 172: users.UserService.DeleteDenyListEntry:input_type -> users.DeleteDenyListEntryRequest
+	95,  // 173: users.UserService.UpdateSubscription:input_type -> users.UpdateSubscriptionRequest
+	96,  // 174: users.UserService.CancelSubscription:input_type -> users.CancelSubscriptionRequest
+	100, // 175: users.UserService.CreateOrganization:input_type -> users.CreateOrganizationRequest
+	101, // 176: users.UserService.GetOrganization:input_type -> users.GetOrganizationRequest
+	102, // 177: users.UserService.ListOrganizations:input_type -> users.ListOrganizationsRequest
+	104, // 178: users.UserService.ListOrganizationMembers:input_type -> users.ListOrganizationMembersRequest
+	106, // 179: users.UserService.UpdateOrganizationMember:input_type -> users.UpdateOrganizationMemberRequest
+	107, // 180: users.UserService.RemoveOrganizationMember:input_type -> users.RemoveOrganizationMemberRequest
+	108, // 181: users.UserService.InviteToOrganization:input_type -> users.InviteToOrganizationRequest
+	109, // 182: users.UserService.ListMyInvitations:input_type -> users.ListMyInvitationsRequest
+	111, // 183: users.UserService.AcceptInvitation:input_type -> users.RespondInvitationRequest
+	111, // 184: users.UserService.DeclineInvitation:input_type -> users.RespondInvitationRequest
+	112, // 185: users.UserService.SwitchOrganization:input_type -> users.SwitchOrganizationRequest
+	114, // 186: users.UserService.HealthCheck:input_type -> users.HealthCheckRequest
+	12,  // 187: users.UserService.CreateUser:output_type -> users.User
+	12,  // 188: users.UserService.GetUserById:output_type -> users.User
+	12,  // 189: users.UserService.GetUserByEmail:output_type -> users.User
+	12,  // 190: users.UserService.UpdateUser:output_type -> users.User
+	125, // 191: users.UserService.DeleteUser:output_type -> google.protobuf.Empty
+	113, // 192: users.UserService.ListUsers:output_type -> users.ListUsersResponse
+	22,  // 193: users.UserService.Authenticate:output_type -> users.AuthenticateResponse
+	22,  // 194: users.UserService.VerifyMFA:output_type -> users.AuthenticateResponse
+	25,  // 195: users.UserService.RefreshToken:output_type -> users.RefreshTokenResponse
+	27,  // 196: users.UserService.ValidateToken:output_type -> users.ValidateTokenResponse
+	125, // 197: users.UserService.Logout:output_type -> google.protobuf.Empty
+	125, // 198: users.UserService.RevokeAllSessions:output_type -> google.protobuf.Empty
+	12,  // 199: users.UserService.VerifyEmail:output_type -> users.User
+	125, // 200: users.UserService.ResendVerification:output_type -> google.protobuf.Empty
+	33,  // 201: users.UserService.SendPhoneOTP:output_type -> users.SendPhoneOTPResponse
+	12,  // 202: users.UserService.VerifyPhoneOTP:output_type -> users.User
+	36,  // 203: users.UserService.EnrollTOTP:output_type -> users.EnrollTOTPResponse
+	38,  // 204: users.UserService.ConfirmTOTP:output_type -> users.ConfirmTOTPResponse
+	125, // 205: users.UserService.DisableTOTP:output_type -> google.protobuf.Empty
+	43,  // 206: users.UserService.BeginWebAuthnRegistration:output_type -> users.BeginWebAuthnResponse
+	40,  // 207: users.UserService.FinishWebAuthnRegistration:output_type -> users.WebAuthnCredential
+	43,  // 208: users.UserService.BeginWebAuthnLogin:output_type -> users.BeginWebAuthnResponse
+	22,  // 209: users.UserService.FinishWebAuthnLogin:output_type -> users.AuthenticateResponse
+	47,  // 210: users.UserService.ListWebAuthnCredentials:output_type -> users.ListWebAuthnCredentialsResponse
+	40,  // 211: users.UserService.RenameWebAuthnCredential:output_type -> users.WebAuthnCredential
+	125, // 212: users.UserService.DeleteWebAuthnCredential:output_type -> google.protobuf.Empty
+	52,  // 213: users.UserService.CreateAPIKey:output_type -> users.CreateAPIKeyResponse
+	54,  // 214: users.UserService.ListAPIKeys:output_type -> users.ListAPIKeysResponse
+	125, // 215: users.UserService.RevokeAPIKey:output_type -> google.protobuf.Empty
+	60,  // 216: users.UserService.IssueServiceToken:output_type -> users.IssueServiceTokenResponse
+	125, // 217: users.UserService.RequestPasswordReset:output_type -> google.protobuf.Empty
+	125, // 218: users.UserService.ConfirmPasswordReset:output_type -> google.protobuf.Empty
+	125, // 219: users.UserService.ClearLockout:output_type -> google.protobuf.Empty
+	57,  // 220: users.UserService.ImpersonateUser:output_type -> users.ImpersonateUserResponse
+	125, // 221: users.UserService.StopImpersonation:output_type -> google.protobuf.Empty
+	12,  // 222: users.UserService.BanUser:output_type -> users.User
+	12,  // 223: users.UserService.UnbanUser:output_type -> users.User
+	68,  // 224: users.UserService.GetBanStatistics:output_type -> users.BanStatisticsResponse
+	71,  // 225: users.UserService.IssueWarning:output_type -> users.IssueWarningResponse
+	73,  // 226: users.UserService.ListStrikes:output_type -> users.ListStrikesResponse
+	74,  // 227: users.UserService.SubmitBanAppeal:output_type -> users.BanAppeal
+	77,  // 228: users.UserService.ListBanAppeals:output_type -> users.ListBanAppealsResponse
+	74,  // 229: users.UserService.AssignBanAppeal:output_type -> users.BanAppeal
+	74,  // 230: users.UserService.ApproveBanAppeal:output_type -> users.BanAppeal
+	74,  // 231: users.UserService.RejectBanAppeal:output_type -> users.BanAppeal
+	83,  // 232: users.UserService.ReportUser:output_type -> users.ReportUserResponse
+	85,  // 233: users.UserService.ListModerationQueue:output_type -> users.ListModerationQueueResponse
+	80,  // 234: users.UserService.ClaimReport:output_type -> users.UserReport
+	80,  // 235: users.UserService.ResolveReport:output_type -> users.UserReport
+	88,  // 236: users.UserService.CreateDenyListEntry:output_type -> users.DenyListEntry
+	88,  // 237: users.UserService.GetDenyListEntry:output_type -> users.DenyListEntry
+	92,  // 238: users.UserService.ListDenyListEntries:output_type -> users.ListDenyListEntriesResponse
+	88,  // 239: users.UserService.UpdateDenyListEntry:output_type -> users.DenyListEntry
+	125, // 240: users.UserService.DeleteDenyListEntry:output_type -> google.protobuf.Empty
+	12,  // 241: users.UserService.UpdateSubscription:output_type -> users.User
+	12,  // 242: users.UserService.CancelSubscription:output_type -> users.User
+	97,  // 243: users.UserService.CreateOrganization:output_type -> users.Organization
+	97,  // 244: users.UserService.GetOrganization:output_type -> users.Organization
+	103, // 245: users.UserService.ListOrganizations:output_type -> users.ListOrganizationsResponse
+	105, // 246: users.UserService.ListOrganizationMembers:output_type -> users.ListOrganizationMembersResponse
+	98,  // 247: users.UserService.UpdateOrganizationMember:output_type -> users.OrganizationMember
+	125, // 248: users.UserService.RemoveOrganizationMember:output_type -> google.protobuf.Empty
+	99,  // 249: users.UserService.InviteToOrganization:output_type -> users.OrganizationInvitation
+	110, // 250: users.UserService.ListMyInvitations:output_type -> users.ListMyInvitationsResponse
+	99,  // 251: users.UserService.AcceptInvitation:output_type -> users.OrganizationInvitation
+	99,  // 252: users.UserService.DeclineInvitation:output_type -> users.OrganizationInvitation
+	22,  // 253: users.UserService.SwitchOrganization:output_type -> users.AuthenticateResponse
+	115, // 254: users.UserService.HealthCheck:output_type -> users.HealthCheckResponse
+	187, // [187:255] is the sub-list for method output_type
+	119, // [119:187] is the sub-list for method input_type
+	119, // [119:119] is the sub-list for extension type_name
+	119, // [119:119] is the sub-list for extension extendee
+	0,   // [0:119] is the sub-list for field type_name
 }
 
 func init() { file_v1_user_proto_init() }
@@ -2465,16 +8652,26 @@ func file_v1_user_proto_init() {
 	file_v1_user_proto_msgTypes[3].OneofWrappers = []any{}
 	file_v1_user_proto_msgTypes[6].OneofWrappers = []any{}
 	file_v1_user_proto_msgTypes[8].OneofWrappers = []any{}
-	file_v1_user_proto_msgTypes[13].OneofWrappers = []any{}
-	file_v1_user_proto_msgTypes[15].OneofWrappers = []any{}
 	file_v1_user_proto_msgTypes[16].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[52].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[62].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[64].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[68].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[72].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[75].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[76].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[77].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[79].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[81].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[83].OneofWrappers = []any{}
+	file_v1_user_proto_msgTypes[84].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_user_proto_rawDesc), len(file_v1_user_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   26,
+			NumEnums:      12,
+			NumMessages:   112,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -14,8 +14,28 @@ type Config struct {
 	Postgres PostgresConfig
 	Mongo    MongoConfig
 	JWT      JWTConfig
+	MFA      MFAConfig
+	WebAuthn WebAuthnConfig
+	Lockout  LockoutConfig
+	Password PasswordConfig
 	Redis    RedisConfig
+	Mail     MailConfig
+	SMS      SMSConfig
 	Log      LogConfig
+
+	Organizations OrganizationsConfig
+	Bans          BansConfig
+	Moderation    ModerationConfig
+	Appeals       AppealsConfig
+	Reports       ReportsConfig
+
+	PasswordPolicy    PasswordPolicyConfig    `mapstructure:"password_policy"`
+	PasswordReset     PasswordResetConfig     `mapstructure:"password_reset"`
+	EmailVerification EmailVerificationConfig `mapstructure:"email_verification"`
+	PhoneVerification PhoneVerificationConfig `mapstructure:"phone_verification"`
+	APIKeys           APIKeysConfig           `mapstructure:"api_keys"`
+	ServiceAccounts   ServiceAccountsConfig   `mapstructure:"service_accounts"`
+	DenyLists         DenyListsConfig         `mapstructure:"deny_lists"`
 }
 
 type AppConfig struct {
@@ -25,7 +45,8 @@ type AppConfig struct {
 }
 
 type GRPCConfig struct {
-	Port int
+	Port           int
+	TrustedProxies []string `mapstructure:"trusted_proxies"` // Адреса или CIDR прокси, от которых принимается X-Forwarded-For
 }
 
 type HTTPConfig struct {
@@ -52,8 +73,69 @@ type MongoConfig struct {
 }
 
 type JWTConfig struct {
-	Secret string
-	Expiry time.Duration
+	Secret           string        // Используется только с алгоритмом HS256
+	Algorithm        string        // HS256, RS256 или EdDSA
+	Issuer           string        // iss всех токенов
+	Audience         string        // aud access токенов пользователей; проверяющие по JWKS должны его требовать
+	KeysDir          string        `mapstructure:"keys_dir"`
+	RotationInterval time.Duration `mapstructure:"rotation_interval"`
+	PublishDelay     time.Duration `mapstructure:"publish_delay"` // Новый ключ публикуется в JWKS заранее, дольше всей цепочки кешей JWKS
+	Expiry           time.Duration // Время жизни access токена
+	RefreshExpiry    time.Duration `mapstructure:"refresh_expiry"`
+
+	ImpersonationExpiry time.Duration `mapstructure:"impersonation_expiry"` // Токен входа от имени пользователя
+}
+
+type MFAConfig struct {
+	Issuer        string        // Название сервиса в приложении-аутентификаторе
+	EncryptionKey string        `mapstructure:"encryption_key"` // base64, 32 байта; шифрует TOTP секреты
+	ChallengeTTL  time.Duration `mapstructure:"challenge_ttl"`  // Время на ввод второго фактора
+	RecoveryCodes int           `mapstructure:"recovery_codes"` // Сколько кодов восстановления выдавать
+}
+
+type WebAuthnConfig struct {
+	RPID          string        `mapstructure:"rp_id"`           // Домен, к которому привязываются ключи
+	RPDisplayName string        `mapstructure:"rp_display_name"` // Название, которое видит пользователь
+	RPOrigins     []string      `mapstructure:"rp_origins"`      // Разрешенные origin фронтенда
+	SessionTTL    time.Duration `mapstructure:"session_ttl"`     // Время на завершение церемонии
+}
+
+type LockoutConfig struct {
+	MaxAccountFailures int           `mapstructure:"max_account_failures"` // Неудачных входов в аккаунт до блокировки
+	MaxIPFailures      int           `mapstructure:"max_ip_failures"`      // Неудачных входов с одного IP до блокировки
+	MaxMFAFailures     int           `mapstructure:"max_mfa_failures"`     // Неверных кодов второго фактора до блокировки
+	FailureWindow      time.Duration `mapstructure:"failure_window"`       // Окно подсчета неудачных попыток
+	BaseDuration       time.Duration `mapstructure:"base_duration"`        // Первая блокировка, каждая следующая вдвое дольше
+	MaxDuration        time.Duration `mapstructure:"max_duration"`
+	ResetAfter         time.Duration `mapstructure:"reset_after"` // Через сколько без блокировок длительность снова base
+}
+
+type PasswordConfig struct {
+	Algorithm  string         // argon2id или bcrypt; хеши другим алгоритмом обновляются при входе
+	BcryptCost int            `mapstructure:"bcrypt_cost"`
+	Argon2id   Argon2idConfig `mapstructure:"argon2id"`
+}
+
+type Argon2idConfig struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+}
+
+type PasswordPolicyConfig struct {
+	MinLength        int      `mapstructure:"min_length"`
+	MaxLength        int      `mapstructure:"max_length"` // 0 - без ограничения
+	RequireUpper     bool     `mapstructure:"require_upper"`
+	RequireLower     bool     `mapstructure:"require_lower"`
+	RequireDigit     bool     `mapstructure:"require_digit"`
+	RequireSymbol    bool     `mapstructure:"require_symbol"`
+	MinCharClasses   int      `mapstructure:"min_char_classes"` // Сколько типов символов должно быть в пароле
+	Dictionary       []string // Запрещенные слова
+	DictionaryFile   string   `mapstructure:"dictionary_file"`    // Файл со словами, по одному в строке
+	MinWordLength    int      `mapstructure:"min_word_length"`    // Более короткие слова и части email/имени не проверяются
+	HistorySize      int      `mapstructure:"history_size"`       // Сколько прежних паролей нельзя повторять; 0 - не проверять
+	BreachedPath     string   `mapstructure:"breached_path"`      // Файл или каталог диапазонов SHA-1; пусто - не проверять
+	BreachedMinCount int      `mapstructure:"breached_min_count"` // Сколько раз пароль должен встретиться в утечках
 }
 
 type RedisConfig struct {
@@ -63,6 +145,92 @@ type RedisConfig struct {
 	DB       int
 }
 
+type MailConfig struct {
+	Driver    string // log или file
+	From      string
+	OutboxDir string `mapstructure:"outbox_dir"` // Каталог для драйвера file
+}
+
+type SMSConfig struct {
+	Driver string // Пока поддерживается только log
+	From   string // Имя отправителя
+}
+
+type PasswordResetConfig struct {
+	TokenTTL time.Duration `mapstructure:"token_ttl"`
+	URL      string        // Страница фронтенда, к которой добавляется ?token=
+}
+
+type EmailVerificationConfig struct {
+	Enabled  bool          // Новые пользователи создаются в статусе PENDING
+	Required bool          // Вход запрещен до подтверждения email
+	TokenTTL time.Duration `mapstructure:"token_ttl"`
+	URL      string        // Страница фронтенда, к которой добавляется ?token=
+}
+
+type PhoneVerificationConfig struct {
+	DefaultCountryCode string        `mapstructure:"default_country_code"` // Для номеров без кода страны
+	CodeLength         int           `mapstructure:"code_length"`
+	CodeTTL            time.Duration `mapstructure:"code_ttl"`
+	MaxAttempts        int           `mapstructure:"max_attempts"`    // Неудачных попыток на один код
+	ResendInterval     time.Duration `mapstructure:"resend_interval"` // Минимальный интервал между отправками
+}
+
+type OrganizationsConfig struct {
+	InvitationTTL time.Duration `mapstructure:"invitation_ttl"`
+	InvitationURL string        `mapstructure:"invitation_url"` // Страница фронтенда со списком приглашений
+}
+
+type BansConfig struct {
+	ExpiryCheckInterval time.Duration `mapstructure:"expiry_check_interval"` // Как часто снимать истекшие баны
+	ExpiryBatchSize     int           `mapstructure:"expiry_batch_size"`     // Банов за один запрос к БД
+}
+
+type ModerationConfig struct {
+	StrikeTTL  time.Duration          `mapstructure:"strike_ttl"` // Через сколько страйк перестает учитываться
+	Escalation []EscalationStepConfig // Автоматические баны по числу действующих страйков
+}
+
+type EscalationStepConfig struct {
+	Strikes  int
+	Duration time.Duration // 0 - перманентный бан
+}
+
+type AppealsConfig struct {
+	TokenTTL         time.Duration `mapstructure:"token_ttl"`          // Сколько живет токен подачи апелляции
+	MaxMessageLength int           `mapstructure:"max_message_length"` // Символов в тексте апелляции
+}
+
+type ReportsConfig struct {
+	MaxDescriptionLength int `mapstructure:"max_description_length"` // Символов в тексте жалобы
+	MaxEvidenceItems     int `mapstructure:"max_evidence_items"`     // Доказательств в одной жалобе
+}
+
+type DenyListsConfig struct {
+	DisposableDomains     []string `mapstructure:"disposable_domains"`      // Домены одноразовой почты, запрещенные при регистрации
+	DisposableDomainsFile string   `mapstructure:"disposable_domains_file"` // По домену в строке, дополняет disposable_domains
+}
+
+type APIKeysConfig struct {
+	MaxPerUser int           `mapstructure:"max_per_user"` // Действующих ключей на пользователя
+	MaxTTL     time.Duration `mapstructure:"max_ttl"`      // 0 - разрешены бессрочные ключи
+}
+
+type ServiceAccountsConfig struct {
+	Audience string                 // Audience этого сервиса в токенах сервисных аккаунтов
+	TokenTTL time.Duration          `mapstructure:"token_ttl"`
+	Clients  []ServiceAccountConfig // Внутренние сервисы с доступом по client credentials
+}
+
+type ServiceAccountConfig struct {
+	ClientID    string `mapstructure:"client_id"`
+	Name        string
+	SecretHash  string   `mapstructure:"secret_hash"` // SHA-256 секрета в hex, обязателен у включенного аккаунта
+	Permissions []string // Права из списка прав ролей без привязки к иерархии, например users.read
+	Audiences   []string // Сервисы, для которых выдаются токены
+	Disabled    bool
+}
+
 type LogConfig struct {
 	Level  string
 	Format string
@@ -94,10 +262,77 @@ func LoadConfig(path string) (*Config, error) {
 	viper.SetDefault("mongo.max_pool_size", 100)
 	viper.SetDefault("mongo.min_pool_size", 10)
 	viper.SetDefault("mongo.timeout", "10s")
-	viper.SetDefault("jwt.expiry", "24h")
+	viper.SetDefault("jwt.algorithm", "RS256")
+	viper.SetDefault("jwt.issuer", "user-service")
+	viper.SetDefault("jwt.audience", "api")
+	viper.SetDefault("jwt.keys_dir", "./config/keys")
+	viper.SetDefault("jwt.rotation_interval", "720h")
+	viper.SetDefault("jwt.publish_delay", "20m")
+	viper.SetDefault("jwt.expiry", "15m")
+	viper.SetDefault("jwt.refresh_expiry", "720h")
+	viper.SetDefault("jwt.impersonation_expiry", "15m")
+	viper.SetDefault("mfa.issuer", "UserService")
+	viper.SetDefault("mfa.challenge_ttl", "5m")
+	viper.SetDefault("mfa.recovery_codes", 10)
+	viper.SetDefault("webauthn.rp_id", "localhost")
+	viper.SetDefault("webauthn.rp_display_name", "UserService")
+	viper.SetDefault("webauthn.rp_origins", []string{"http://localhost:3000"})
+	viper.SetDefault("webauthn.session_ttl", "5m")
+	viper.SetDefault("lockout.max_account_failures", 5)
+	viper.SetDefault("lockout.max_ip_failures", 20)
+	viper.SetDefault("lockout.max_mfa_failures", 5)
+	viper.SetDefault("lockout.failure_window", "15m")
+	viper.SetDefault("lockout.base_duration", "1m")
+	viper.SetDefault("lockout.max_duration", "24h")
+	viper.SetDefault("lockout.reset_after", "24h")
+	viper.SetDefault("password.algorithm", "argon2id")
+	viper.SetDefault("password.bcrypt_cost", 10)
+	viper.SetDefault("password.argon2id.memory", 65536)
+	viper.SetDefault("password.argon2id.iterations", 3)
+	viper.SetDefault("password.argon2id.parallelism", 2)
+	viper.SetDefault("password_policy.min_length", 8)
+	viper.SetDefault("password_policy.max_length", 128)
+	viper.SetDefault("password_policy.min_char_classes", 0)
+	viper.SetDefault("password_policy.min_word_length", 4)
+	viper.SetDefault("password_policy.history_size", 0)
+	viper.SetDefault("password_policy.breached_min_count", 1)
 	viper.SetDefault("redis.host", "localhost")
 	viper.SetDefault("redis.port", 6379)
 	viper.SetDefault("redis.db", 0)
+	viper.SetDefault("mail.driver", "log")
+	viper.SetDefault("mail.from", "no-reply@localhost")
+	viper.SetDefault("mail.outbox_dir", "./outbox")
+	viper.SetDefault("sms.driver", "log")
+	viper.SetDefault("sms.from", "UserService")
+	viper.SetDefault("password_reset.token_ttl", "1h")
+	viper.SetDefault("password_reset.url", "http://localhost:3000/reset-password")
+	viper.SetDefault("email_verification.enabled", false)
+	viper.SetDefault("email_verification.required", false)
+	viper.SetDefault("email_verification.token_ttl", "48h")
+	viper.SetDefault("email_verification.url", "http://localhost:3000/verify-email")
+	viper.SetDefault("phone_verification.default_country_code", "")
+	viper.SetDefault("phone_verification.code_length", 6)
+	viper.SetDefault("phone_verification.code_ttl", "5m")
+	viper.SetDefault("phone_verification.max_attempts", 5)
+	viper.SetDefault("phone_verification.resend_interval", "1m")
+	viper.SetDefault("organizations.invitation_ttl", "168h")
+	viper.SetDefault("organizations.invitation_url", "http://localhost:3000/invitations")
+	viper.SetDefault("bans.expiry_check_interval", "1m")
+	viper.SetDefault("bans.expiry_batch_size", 100)
+	viper.SetDefault("moderation.strike_ttl", "2160h")
+	viper.SetDefault("moderation.escalation", []map[string]interface{}{
+		{"strikes": 3, "duration": "24h"},
+		{"strikes": 4, "duration": "168h"},
+		{"strikes": 5, "duration": "0"},
+	})
+	viper.SetDefault("appeals.token_ttl", "30m")
+	viper.SetDefault("appeals.max_message_length", 2000)
+	viper.SetDefault("reports.max_description_length", 2000)
+	viper.SetDefault("reports.max_evidence_items", 10)
+	viper.SetDefault("api_keys.max_per_user", 20)
+	viper.SetDefault("api_keys.max_ttl", "0")
+	viper.SetDefault("service_accounts.audience", "user-service")
+	viper.SetDefault("service_accounts.token_ttl", "5m")
 	viper.SetDefault("log.level", "info")
 	viper.SetDefault("log.format", "json")
 
@@ -113,3 +348,16 @@ func LoadConfig(path string) (*Config, error) {
 
 	return &config, nil
 }
+
+// SigningKeyRetention - сколько ключ подписи хранится после того, как перестал
+// подписывать: пока не истечет самый долгоживущий токен, подписанный им
+func (c *Config) SigningKeyRetention() time.Duration {
+	return max(
+		c.JWT.Expiry,
+		c.JWT.ImpersonationExpiry,
+		c.MFA.ChallengeTTL,
+		c.EmailVerification.TokenTTL,
+		c.Appeals.TokenTTL,
+		c.ServiceAccounts.TokenTTL,
+	)
+}
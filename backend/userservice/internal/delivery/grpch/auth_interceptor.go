@@ -0,0 +1,245 @@
+package grpch
+
+import (
+	"context"
+	"strings"
+	users "userservice/gen/v1"
+	"userservice/internal/domain"
+	"userservice/pkg/jwt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// PublicMethods - методы, доступные без access токена.
+// Запись, оканчивающаяся на "/", открывает все методы сервиса.
+var PublicMethods = []string{
+	users.UserService_CreateUser_FullMethodName,
+	users.UserService_Authenticate_FullMethodName,
+	users.UserService_HealthCheck_FullMethodName,
+
+	// Шаги входа и восстановления доступа, на которых access токена еще нет
+	users.UserService_VerifyMFA_FullMethodName,
+	users.UserService_RefreshToken_FullMethodName,
+	users.UserService_ValidateToken_FullMethodName,
+	users.UserService_VerifyEmail_FullMethodName,
+	users.UserService_ResendVerification_FullMethodName,
+	users.UserService_RequestPasswordReset_FullMethodName,
+	users.UserService_ConfirmPasswordReset_FullMethodName,
+	users.UserService_BeginWebAuthnLogin_FullMethodName,
+	users.UserService_FinishWebAuthnLogin_FullMethodName,
+	users.UserService_IssueServiceToken_FullMethodName,
+	users.UserService_SubmitBanAppeal_FullMethodName, // Авторизуется токеном апелляции из тела запроса
+
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// AuthInterceptor проверяет bearer токен из метаданных и кладет вызывающего в контекст.
+// Вместо JWT пользователя принимается API ключ или токен сервисного аккаунта.
+type AuthInterceptor struct {
+	jwtManager *jwt.JWTManager
+	revoked    domain.TokenRevocationStore
+	apiKeys    domain.APIKeyAuthenticator
+	audience   string // Audience этого сервиса в токенах сервисных аккаунтов
+	public     map[string]bool
+	prefixes   []string
+}
+
+// NewAuthInterceptor создает интерсептор с перечнем публичных методов
+func NewAuthInterceptor(jwtManager *jwt.JWTManager, revoked domain.TokenRevocationStore, apiKeys domain.APIKeyAuthenticator, audience string, publicMethods []string) *AuthInterceptor {
+	i := &AuthInterceptor{
+		jwtManager: jwtManager,
+		revoked:    revoked,
+		apiKeys:    apiKeys,
+		audience:   audience,
+		public:     make(map[string]bool, len(publicMethods)),
+	}
+	for _, m := range publicMethods {
+		if strings.HasSuffix(m, "/") {
+			i.prefixes = append(i.prefixes, m)
+		} else {
+			i.public[m] = true
+		}
+	}
+	return i
+}
+
+// Unary возвращает интерсептор для унарных вызовов
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream возвращает интерсептор для потоковых вызовов
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate проверяет токен вызова. Для публичных методов токен необязателен,
+// но если он передан и действителен, вызывающий тоже попадает в контекст.
+func (i *AuthInterceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	public := i.isPublic(method)
+
+	token := bearerToken(ctx)
+	if token == "" {
+		if public {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	principal, err := i.principal(ctx, token)
+	if err != nil {
+		if public {
+			return ctx, nil
+		}
+		return nil, err
+	}
+
+	return domain.ContextWithPrincipal(ctx, principal), nil
+}
+
+// principal проверяет подпись, срок и отзыв access токена
+func (i *AuthInterceptor) principal(ctx context.Context, token string) (*domain.Principal, error) {
+	if domain.IsAPIKey(token) {
+		return i.apiKeyPrincipal(ctx, token)
+	}
+
+	claims, err := i.jwtManager.ValidateToken(token)
+	if err == jwt.ErrServiceToken {
+		return i.servicePrincipal(ctx, token)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	if claims.ID != "" {
+		revoked, err := i.revoked.IsTokenRevoked(ctx, claims.ID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if revoked {
+			return nil, status.Error(codes.Unauthenticated, "token revoked")
+		}
+	}
+
+	revokedBefore, err := i.revoked.RevokedBefore(ctx, claims.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !revokedBefore.IsZero() && (claims.IssuedAt == nil || claims.IssuedAt.Time.Before(revokedBefore)) {
+		return nil, status.Error(codes.Unauthenticated, "token revoked")
+	}
+
+	p := &domain.Principal{
+		UserID:   claims.UserID,
+		Email:    claims.Email,
+		Role:     domain.UserRole(claims.Role),
+		TenantID: claims.TenantID,
+		TokenID:  claims.ID,
+	}
+	if claims.Actor != nil {
+		p.ImpersonatorID = claims.Actor.Subject
+	}
+	if claims.IssuedAt != nil {
+		p.IssuedAt = claims.IssuedAt.Time
+	}
+	return p, nil
+}
+
+// servicePrincipal проверяет токен сервисного аккаунта, выпущенный для этого сервиса
+func (i *AuthInterceptor) servicePrincipal(ctx context.Context, token string) (*domain.Principal, error) {
+	claims, err := i.jwtManager.ValidateServiceToken(token, i.audience)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid service token")
+	}
+
+	revoked, err := i.revoked.IsTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if revoked {
+		return nil, status.Error(codes.Unauthenticated, "token revoked")
+	}
+
+	p := &domain.Principal{
+		Kind:     domain.PrincipalKindService,
+		ClientID: claims.ClientID,
+		TokenID:  claims.ID,
+	}
+	for _, perm := range claims.Permissions {
+		p.Scopes = append(p.Scopes, domain.Permission(perm))
+	}
+	if claims.IssuedAt != nil {
+		p.IssuedAt = claims.IssuedAt.Time
+	}
+	return p, nil
+}
+
+// apiKeyPrincipal проверяет API ключ
+func (i *AuthInterceptor) apiKeyPrincipal(ctx context.Context, key string) (*domain.Principal, error) {
+	p, err := i.apiKeys.AuthenticateAPIKey(ctx, key)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidToken:
+			return nil, status.Error(codes.Unauthenticated, "invalid api key")
+		case domain.ErrUserBanned, domain.ErrEmailNotVerified:
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return p, nil
+}
+
+func (i *AuthInterceptor) isPublic(method string) bool {
+	if i.public[method] {
+		return true
+	}
+	for _, prefix := range i.prefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// bearerToken извлекает токен из заголовка authorization: Bearer <token>
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, value := range md.Get("authorization") {
+		scheme, token, found := strings.Cut(strings.TrimSpace(value), " ")
+		if found && strings.EqualFold(scheme, "bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// authenticatedStream подменяет контекст потока контекстом с вызывающим
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
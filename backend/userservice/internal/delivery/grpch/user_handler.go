@@ -2,12 +2,19 @@ package grpch
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net"
+	"strings"
 	"time"
 	users "userservice/gen/v1"
 	"userservice/internal/domain"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -15,12 +22,14 @@ import (
 
 type UserHandler struct {
 	users.UnimplementedUserServiceServer
-	service domain.UserService
+	service        domain.UserService
+	trustedProxies []*net.IPNet // Прокси, от которых принимается X-Forwarded-For
 }
 
-func NewUserHandler(service domain.UserService) *UserHandler {
+func NewUserHandler(service domain.UserService, trustedProxies []*net.IPNet) *UserHandler {
 	return &UserHandler{
-		service: service,
+		service:        service,
+		trustedProxies: trustedProxies,
 	}
 }
 
@@ -31,11 +40,23 @@ func (h *UserHandler) CreateUser(ctx context.Context, req *users.CreateUserReque
 	domainUser := domain.CreateUserRequestFromProto(req)
 
 	// Регистрируем пользователя
-	user, err := h.service.Register(domainUser)
+	user, err := h.service.Register(ctx, domainUser, h.clientIP(ctx), deviceID(ctx))
 	if err != nil {
 		if err == domain.ErrUserAlreadyExists {
 			return nil, status.Error(codes.AlreadyExists, "user already exists")
 		}
+		if err == domain.ErrDeniedByDenyList {
+			return nil, status.Error(codes.PermissionDenied, "registration is not allowed")
+		}
+		if err == domain.ErrDisposableEmailDomain {
+			return nil, status.Error(codes.InvalidArgument, "disposable email addresses are not allowed")
+		}
+		if err == domain.ErrInvalidPhone {
+			return nil, status.Error(codes.InvalidArgument, "invalid phone number")
+		}
+		if st := passwordPolicyStatus(err); st != nil {
+			return nil, st
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
 	}
 
@@ -45,8 +66,11 @@ func (h *UserHandler) CreateUser(ctx context.Context, req *users.CreateUserReque
 func (h *UserHandler) GetUserById(ctx context.Context, req *users.GetUserByIdRequest) (*users.User, error) {
 	log.Printf("GetUserById request for ID: %s", req.GetId())
 
-	user, err := h.service.GetUser(req.GetId())
+	user, err := h.service.GetUser(ctx, req.GetId())
 	if err != nil {
+		if st := accessError(err); st != nil {
+			return nil, st
+		}
 		if err == domain.ErrUserNotFound {
 			return nil, status.Error(codes.NotFound, "user not found")
 		}
@@ -63,8 +87,11 @@ func (h *UserHandler) GetUserByEmail(ctx context.Context, req *users.GetUserByEm
 	log.Printf("GetUserByEmail request for email: %s", req.GetEmail())
 
 	// Используем GetUser для консистентности
-	user, err := h.service.GetUser(req.GetEmail())
+	user, err := h.service.GetUser(ctx, req.GetEmail())
 	if err != nil {
+		if st := accessError(err); st != nil {
+			return nil, st
+		}
 		if err == domain.ErrUserNotFound {
 			return nil, status.Error(codes.NotFound, "user not found")
 		}
@@ -78,16 +105,28 @@ func (h *UserHandler) UpdateUser(ctx context.Context, req *users.UpdateUserReque
 	log.Printf("UpdateUser request for ID: %s", req.GetId())
 
 	// Получаем текущего пользователя
-	user, err := h.service.GetUser(req.GetId())
+	user, err := h.service.GetUser(ctx, req.GetId())
 	if err != nil {
+		if st := accessError(err); st != nil {
+			return nil, st
+		}
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
 
 	// Обновляем поля из запроса
 	user.UpdateFromProto(req)
 
-	updatedUser, err := h.service.UpdateUser(user)
+	updatedUser, err := h.service.UpdateUser(ctx, user)
 	if err != nil {
+		if st := accessError(err); st != nil {
+			return nil, st
+		}
+		if err == domain.ErrInvalidPhone {
+			return nil, status.Error(codes.InvalidArgument, "invalid phone number")
+		}
+		if st := passwordPolicyStatus(err); st != nil {
+			return nil, st
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -97,7 +136,10 @@ func (h *UserHandler) UpdateUser(ctx context.Context, req *users.UpdateUserReque
 func (h *UserHandler) DeleteUser(ctx context.Context, req *users.DeleteUserRequest) (*emptypb.Empty, error) {
 	log.Printf("DeleteUser request for ID: %s", req.GetId())
 
-	if err := h.service.DeleteUser(req.GetId()); err != nil {
+	if err := h.service.DeleteUser(ctx, req.GetId()); err != nil {
+		if st := accessError(err); st != nil {
+			return nil, st
+		}
 		if err == domain.ErrUserNotFound {
 			return nil, status.Error(codes.NotFound, "user not found")
 		}
@@ -116,6 +158,8 @@ func (h *UserHandler) ListUsers(ctx context.Context, req *users.ListUsersRequest
 		Search:   req.GetSearch(),
 		Status:   domain.UserStatusFromProto(req.GetStatus()),
 		Role:     domain.UserRoleFromProto(req.GetRole()),
+
+		BanCategory: domain.BanCategoryFromProto(req.GetBanCategory()),
 	}
 
 	if req.GetIsBanned() {
@@ -133,8 +177,11 @@ func (h *UserHandler) ListUsers(ctx context.Context, req *users.ListUsersRequest
 		filter.SubLevel = &subLevel
 	}
 
-	users, total, err := h.service.ListUsers(filter)
+	users, total, err := h.service.ListUsers(ctx, filter)
 	if err != nil {
+		if st := accessError(err); st != nil {
+			return nil, st
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -144,29 +191,113 @@ func (h *UserHandler) ListUsers(ctx context.Context, req *users.ListUsersRequest
 func (h *UserHandler) Authenticate(ctx context.Context, req *users.AuthenticateRequest) (*users.AuthenticateResponse, error) {
 	log.Printf("Authenticate request for email: %s", req.GetEmail())
 
-	user, token, err := h.service.Authenticate(req.GetEmail(), req.GetPassword())
+	user, tokens, err := h.service.Authenticate(ctx, req.GetEmail(), req.GetPassword(), h.clientIP(ctx), deviceID(ctx))
 	if err != nil {
 		if err == domain.ErrInvalidCredentials {
 			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
 		}
+		if err == domain.ErrDeniedByDenyList {
+			return nil, status.Error(codes.PermissionDenied, "login is not allowed")
+		}
+		if err == domain.ErrAccountLocked {
+			return nil, status.Error(codes.ResourceExhausted, "too many failed login attempts, try again later")
+		}
+		var bannedErr *domain.BannedError
+		if errors.As(err, &bannedErr) {
+			return nil, bannedStatus(bannedErr)
+		}
 		if err == domain.ErrUserBanned {
 			return nil, status.Error(codes.PermissionDenied, "user is banned")
 		}
+		if err == domain.ErrEmailNotVerified {
+			return nil, status.Error(codes.FailedPrecondition, "email not verified")
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	if tokens.MFARequired {
+		return &users.AuthenticateResponse{
+			MfaRequired: true,
+			MfaToken:    tokens.MFAToken,
+		}, nil
+	}
+
 	return &users.AuthenticateResponse{
-		Token:     token,
-		User:      user.ToProto(),
-		ExpiresAt: timestamppb.New(time.Now().Add(24 * time.Hour)),
+		Token:            tokens.AccessToken,
+		User:             user.ToProto(),
+		ExpiresAt:        timestamppb.New(tokens.ExpiresAt),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: timestamppb.New(tokens.RefreshExpiresAt),
+	}, nil
+}
+
+func (h *UserHandler) VerifyMFA(ctx context.Context, req *users.VerifyMFARequest) (*users.AuthenticateResponse, error) {
+	log.Printf("VerifyMFA request")
+
+	user, tokens, err := h.service.VerifyMFA(ctx, req.GetMfaToken(), req.GetCode())
+	if err != nil {
+		switch err {
+		case domain.ErrMFAChallengeInvalid:
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired mfa token")
+		case domain.ErrMFACodeInvalid:
+			return nil, status.Error(codes.Unauthenticated, "invalid code")
+		case domain.ErrMFANotEnrolled:
+			return nil, status.Error(codes.FailedPrecondition, "mfa is not enabled")
+		case domain.ErrUserBanned:
+			return nil, status.Error(codes.PermissionDenied, "user is banned")
+		case domain.ErrAccountLocked:
+			return nil, status.Error(codes.ResourceExhausted, "too many invalid codes, try again later")
+		}
+		var bannedErr *domain.BannedError
+		if errors.As(err, &bannedErr) {
+			return nil, bannedStatus(bannedErr)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &users.AuthenticateResponse{
+		Token:            tokens.AccessToken,
+		User:             user.ToProto(),
+		ExpiresAt:        timestamppb.New(tokens.ExpiresAt),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: timestamppb.New(tokens.RefreshExpiresAt),
+	}, nil
+}
+
+func (h *UserHandler) RefreshToken(ctx context.Context, req *users.RefreshTokenRequest) (*users.RefreshTokenResponse, error) {
+	log.Printf("RefreshToken request")
+
+	user, tokens, err := h.service.RefreshToken(ctx, req.GetRefreshToken(), h.clientIP(ctx), deviceID(ctx))
+	if err != nil {
+		if err == domain.ErrRefreshTokenInvalid {
+			return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		}
+		if err == domain.ErrDeniedByDenyList {
+			return nil, status.Error(codes.PermissionDenied, "login is not allowed")
+		}
+		if err == domain.ErrUserBanned {
+			return nil, status.Error(codes.PermissionDenied, "user is banned")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &users.RefreshTokenResponse{
+		Token:            tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
+		ExpiresAt:        timestamppb.New(tokens.ExpiresAt),
+		RefreshExpiresAt: timestamppb.New(tokens.RefreshExpiresAt),
+		User:             user.ToProto(),
 	}, nil
 }
 
 func (h *UserHandler) ValidateToken(ctx context.Context, req *users.ValidateTokenRequest) (*users.ValidateTokenResponse, error) {
 	log.Printf("ValidateToken request")
 
-	user, err := h.service.ValidateToken(req.GetToken())
+	user, err := h.service.ValidateToken(ctx, req.GetToken())
 	if err != nil {
+		if err == domain.ErrTokenRevoked {
+			return nil, status.Error(codes.Unauthenticated, "token revoked")
+		}
 		return nil, status.Error(codes.Unauthenticated, "invalid token")
 	}
 
@@ -176,87 +307,1317 @@ func (h *UserHandler) ValidateToken(ctx context.Context, req *users.ValidateToke
 	}, nil
 }
 
-func (h *UserHandler) BanUser(ctx context.Context, req *users.BanUserRequest) (*users.User, error) {
-	log.Printf("BanUser request for user: %s", req.GetUserId())
+func (h *UserHandler) Logout(ctx context.Context, req *users.LogoutRequest) (*emptypb.Empty, error) {
+	log.Printf("Logout request")
 
-	var duration *time.Duration
-	if req.GetBannedUntil() != nil {
-		dur := time.Until(req.GetBannedUntil().AsTime())
-		duration = &dur
+	if err := h.service.Logout(ctx, req.GetToken(), req.GetRefreshToken()); err != nil {
+		if st := accessError(err); st != nil {
+			return nil, st
+		}
+		if err == domain.ErrInvalidToken {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) RevokeAllSessions(ctx context.Context, req *users.RevokeAllSessionsRequest) (*emptypb.Empty, error) {
+	log.Printf("RevokeAllSessions request for user: %s", req.GetUserId())
+
+	if err := h.service.RevokeAllSessions(ctx, req.GetUserId()); err != nil {
+		if st := accessError(err); st != nil {
+			return nil, st
+		}
+		var domainErr *domain.DomainError
+		if errors.As(err, &domainErr) && domainErr.Code == domain.ErrCodeUserNotFound {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	user, err := h.service.BanUser(req.GetUserId(), req.GetReason(), req.GetBannedBy(), duration)
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) VerifyEmail(ctx context.Context, req *users.VerifyEmailRequest) (*users.User, error) {
+	log.Printf("VerifyEmail request")
+
+	user, err := h.service.VerifyEmail(ctx, req.GetToken())
 	if err != nil {
+		if err == domain.ErrVerificationInvalid {
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired verification token")
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return user.ToProto(), nil
 }
 
-func (h *UserHandler) UnbanUser(ctx context.Context, req *users.UnbanUserRequest) (*users.User, error) {
-	log.Printf("UnbanUser request for user: %s", req.GetUserId())
+func (h *UserHandler) ResendVerification(ctx context.Context, req *users.ResendVerificationRequest) (*emptypb.Empty, error) {
+	log.Printf("ResendVerification request for email: %s", req.GetEmail())
+
+	if err := h.service.ResendVerification(ctx, req.GetEmail()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) SendPhoneOTP(ctx context.Context, req *users.SendPhoneOTPRequest) (*users.SendPhoneOTPResponse, error) {
+	log.Printf("SendPhoneOTP request for user: %s", req.GetUserId())
 
-	user, err := h.service.UnbanUser(req.GetUserId(), req.GetUnbannedBy())
+	expiresAt, err := h.service.SendPhoneOTP(ctx, req.GetUserId())
 	if err != nil {
+		if st := accessError(err); st != nil {
+			return nil, st
+		}
+		var domainErr *domain.DomainError
+		if errors.As(err, &domainErr) && domainErr.Code == domain.ErrCodeUserNotFound {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		switch err {
+		case domain.ErrInvalidPhone:
+			return nil, status.Error(codes.FailedPrecondition, "user has no valid phone number")
+		case domain.ErrPhoneAlreadyVerified:
+			return nil, status.Error(codes.FailedPrecondition, "phone already verified")
+		case domain.ErrOTPTooFrequent:
+			return nil, status.Error(codes.ResourceExhausted, "code was sent recently, try again later")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &users.SendPhoneOTPResponse{
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
+
+func (h *UserHandler) VerifyPhoneOTP(ctx context.Context, req *users.VerifyPhoneOTPRequest) (*users.User, error) {
+	log.Printf("VerifyPhoneOTP request for user: %s", req.GetUserId())
+
+	user, err := h.service.VerifyPhoneOTP(ctx, req.GetUserId(), req.GetCode())
+	if err != nil {
+		if st := accessError(err); st != nil {
+			return nil, st
+		}
+		var domainErr *domain.DomainError
+		if errors.As(err, &domainErr) && domainErr.Code == domain.ErrCodeUserNotFound {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		switch err {
+		case domain.ErrOTPInvalid:
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired code")
+		case domain.ErrOTPAttemptsExceeded:
+			return nil, status.Error(codes.ResourceExhausted, "too many attempts, request a new code")
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return user.ToProto(), nil
 }
 
-func (h *UserHandler) UpdateSubscription(ctx context.Context, req *users.UpdateSubscriptionRequest) (*users.User, error) {
-	log.Printf("UpdateSubscription request for user: %s", req.GetUserId())
+func (h *UserHandler) EnrollTOTP(ctx context.Context, req *users.EnrollTOTPRequest) (*users.EnrollTOTPResponse, error) {
+	log.Printf("EnrollTOTP request for user: %s", req.GetUserId())
+
+	secret, uri, err := h.service.EnrollTOTP(ctx, req.GetUserId())
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &users.EnrollTOTPResponse{
+		Secret:     secret,
+		OtpauthUri: uri,
+	}, nil
+}
+
+func (h *UserHandler) ConfirmTOTP(ctx context.Context, req *users.ConfirmTOTPRequest) (*users.ConfirmTOTPResponse, error) {
+	log.Printf("ConfirmTOTP request for user: %s", req.GetUserId())
+
+	recoveryCodes, err := h.service.ConfirmTOTP(ctx, req.GetUserId(), req.GetCode())
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &users.ConfirmTOTPResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (h *UserHandler) DisableTOTP(ctx context.Context, req *users.DisableTOTPRequest) (*emptypb.Empty, error) {
+	log.Printf("DisableTOTP request for user: %s", req.GetUserId())
 
-	subscription := &domain.SubscriptionInfo{
-		Status:            domain.SubscriptionStatusFromProto(req.GetStatus()),
-		Level:             domain.SubscriptionLevelFromProto(req.GetLevel()),
-		SubscriptionStart: time.Now(),
-		SubscriptionID:    req.GetSubscriptionId(),
-		PaymentMethod:     req.GetPaymentMethod(),
-		AutoRenew:         req.GetAutoRenew(),
-		Amount:            req.GetAmount(),
-		Currency:          req.GetCurrency(),
-		// Features:          req.GetFeatures(),
+	if err := h.service.DisableTOTP(ctx, req.GetUserId(), req.GetCode()); err != nil {
+		return nil, mfaError(err)
 	}
 
-	if req.GetSubscriptionEnd() != nil {
-		end := req.GetSubscriptionEnd().AsTime()
-		subscription.SubscriptionEnd = &end
+	return &emptypb.Empty{}, nil
+}
+
+// mfaError преобразует ошибки управления 2FA в gRPC статусы
+func mfaError(err error) error {
+	if st := accessError(err); st != nil {
+		return st
+	}
+
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) && domainErr.Code == domain.ErrCodeUserNotFound {
+		return status.Error(codes.NotFound, "user not found")
 	}
 
-	if req.GetTrialEnd() != nil {
-		trialEnd := req.GetTrialEnd().AsTime()
-		subscription.TrialEnd = &trialEnd
+	switch err {
+	case domain.ErrMFANotEnrolled:
+		return status.Error(codes.FailedPrecondition, "mfa is not enrolled")
+	case domain.ErrMFAAlreadyEnabled:
+		return status.Error(codes.FailedPrecondition, "mfa is already enabled")
+	case domain.ErrMFACodeInvalid:
+		return status.Error(codes.InvalidArgument, "invalid code")
 	}
 
-	// if req.GetNextBillingDate() != nil {
-	// 	nextBilling := req.GetNextBillingDate().AsTime()
-	// 	subscription.NextBillingDate = &nextBilling
-	// }
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (h *UserHandler) BeginWebAuthnRegistration(ctx context.Context, req *users.BeginWebAuthnRegistrationRequest) (*users.BeginWebAuthnResponse, error) {
+	log.Printf("BeginWebAuthnRegistration request for user: %s", req.GetUserId())
 
-	user, err := h.service.UpdateSubscription(req.GetUserId(), subscription)
+	sessionID, options, err := h.service.BeginWebAuthnRegistration(ctx, req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, webAuthnError(err)
 	}
 
-	return user.ToProto(), nil
+	return &users.BeginWebAuthnResponse{
+		SessionId:   sessionID,
+		OptionsJson: string(options),
+	}, nil
 }
 
-func (h *UserHandler) CancelSubscription(ctx context.Context, req *users.CancelSubscriptionRequest) (*users.User, error) {
-	log.Printf("CancelSubscription request for user: %s", req.GetUserId())
+func (h *UserHandler) FinishWebAuthnRegistration(ctx context.Context, req *users.FinishWebAuthnRegistrationRequest) (*users.WebAuthnCredential, error) {
+	log.Printf("FinishWebAuthnRegistration request for user: %s", req.GetUserId())
+
+	cred, err := h.service.FinishWebAuthnRegistration(ctx, req.GetUserId(), req.GetSessionId(), req.GetName(), []byte(req.GetCredentialJson()))
+	if err != nil {
+		return nil, webAuthnError(err)
+	}
+
+	return cred.ToProto(), nil
+}
+
+func (h *UserHandler) BeginWebAuthnLogin(ctx context.Context, req *users.BeginWebAuthnLoginRequest) (*users.BeginWebAuthnResponse, error) {
+	log.Printf("BeginWebAuthnLogin request")
+
+	sessionID, options, err := h.service.BeginWebAuthnLogin(ctx, req.GetEmail())
+	if err != nil {
+		return nil, webAuthnError(err)
+	}
+
+	return &users.BeginWebAuthnResponse{
+		SessionId:   sessionID,
+		OptionsJson: string(options),
+	}, nil
+}
+
+func (h *UserHandler) FinishWebAuthnLogin(ctx context.Context, req *users.FinishWebAuthnLoginRequest) (*users.AuthenticateResponse, error) {
+	log.Printf("FinishWebAuthnLogin request")
+
+	user, tokens, err := h.service.FinishWebAuthnLogin(ctx, req.GetSessionId(), []byte(req.GetCredentialJson()), h.clientIP(ctx), deviceID(ctx))
+	if err != nil {
+		switch err {
+		case domain.ErrUserBanned:
+			return nil, status.Error(codes.PermissionDenied, "user is banned")
+		case domain.ErrDeniedByDenyList:
+			return nil, status.Error(codes.PermissionDenied, "login is not allowed")
+		case domain.ErrEmailNotVerified:
+			return nil, status.Error(codes.FailedPrecondition, "email not verified")
+		case domain.ErrWebAuthnVerificationFailed:
+			return nil, status.Error(codes.Unauthenticated, "webauthn verification failed")
+		}
+		var bannedErr *domain.BannedError
+		if errors.As(err, &bannedErr) {
+			return nil, bannedStatus(bannedErr)
+		}
+		return nil, webAuthnError(err)
+	}
+
+	return &users.AuthenticateResponse{
+		Token:            tokens.AccessToken,
+		User:             user.ToProto(),
+		ExpiresAt:        timestamppb.New(tokens.ExpiresAt),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: timestamppb.New(tokens.RefreshExpiresAt),
+	}, nil
+}
+
+func (h *UserHandler) ListWebAuthnCredentials(ctx context.Context, req *users.ListWebAuthnCredentialsRequest) (*users.ListWebAuthnCredentialsResponse, error) {
+	log.Printf("ListWebAuthnCredentials request for user: %s", req.GetUserId())
+
+	creds, err := h.service.ListWebAuthnCredentials(ctx, req.GetUserId())
+	if err != nil {
+		return nil, webAuthnError(err)
+	}
+
+	resp := &users.ListWebAuthnCredentialsResponse{
+		Credentials: make([]*users.WebAuthnCredential, 0, len(creds)),
+	}
+	for _, cred := range creds {
+		resp.Credentials = append(resp.Credentials, cred.ToProto())
+	}
+
+	return resp, nil
+}
+
+func (h *UserHandler) RenameWebAuthnCredential(ctx context.Context, req *users.RenameWebAuthnCredentialRequest) (*users.WebAuthnCredential, error) {
+	log.Printf("RenameWebAuthnCredential request for user: %s", req.GetUserId())
+
+	cred, err := h.service.RenameWebAuthnCredential(ctx, req.GetUserId(), req.GetCredentialId(), req.GetName())
+	if err != nil {
+		return nil, webAuthnError(err)
+	}
+
+	return cred.ToProto(), nil
+}
+
+func (h *UserHandler) DeleteWebAuthnCredential(ctx context.Context, req *users.DeleteWebAuthnCredentialRequest) (*emptypb.Empty, error) {
+	log.Printf("DeleteWebAuthnCredential request for user: %s", req.GetUserId())
+
+	if err := h.service.DeleteWebAuthnCredential(ctx, req.GetUserId(), req.GetCredentialId()); err != nil {
+		return nil, webAuthnError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// clientIP возвращает IP адрес клиента - адрес соединения. X-Forwarded-For
+// учитывается, только если соединение пришло от доверенного прокси: тогда
+// берется самый правый адрес цепочки, не принадлежащий доверенным прокси.
+// Левые адреса заголовка подставляет сам клиент, им верить нельзя.
+func (h *UserHandler) clientIP(ctx context.Context) string {
+	ip := peerIP(ctx)
+	if ip == "" || !h.isTrustedProxy(ip) {
+		return ip
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ip
+	}
+
+	var hops []string
+	for _, value := range md.Get("x-forwarded-for") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			// Испорченную цепочку дальше не разбираем
+			break
+		}
+		ip = hop.String()
+		if !h.isTrustedProxy(ip) {
+			break
+		}
+	}
+
+	return ip
+}
+
+// isTrustedProxy проверяет, входит ли адрес в список доверенных прокси
+func (h *UserHandler) isTrustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range h.trustedProxies {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// peerIP возвращает адрес, с которого установлено соединение
+func peerIP(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+
+	return ""
+}
+
+// ParseTrustedProxies разбирает список доверенных прокси из конфигурации.
+// Одиночный адрес считается диапазоном из одного адреса.
+func ParseTrustedProxies(values []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if _, network, err := net.ParseCIDR(value); err == nil {
+			networks = append(networks, network)
+			continue
+		}
 
-	user, err := h.service.CancelSubscription(req.GetUserId(), req.GetReason(), req.GetImmediateCancellation())
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q", value)
+		}
+		bits := 128
+		if v4 := ip.To4(); v4 != nil {
+			ip, bits = v4, 32
+		}
+		networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return networks, nil
+}
+
+// deviceID возвращает ID устройства клиента из метаданных x-device-id
+func deviceID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-device-id"); len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+	}
+	return ""
+}
+
+// webAuthnError преобразует ошибки WebAuthn в gRPC статусы
+func webAuthnError(err error) error {
+	if st := accessError(err); st != nil {
+		return st
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, validationErr.Message)
+	}
+
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case domain.ErrCodeUserNotFound, domain.ErrCodeWebAuthnCredentialNotFound:
+			return status.Error(codes.NotFound, domainErr.Message)
+		case domain.ErrCodeWebAuthnSessionInvalid, domain.ErrCodeWebAuthnVerificationFailed:
+			return status.Error(codes.InvalidArgument, domainErr.Message)
+		case domain.ErrCodeWebAuthnCredentialExists:
+			return status.Error(codes.AlreadyExists, domainErr.Message)
+		}
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (h *UserHandler) CreateAPIKey(ctx context.Context, req *users.CreateAPIKeyRequest) (*users.CreateAPIKeyResponse, error) {
+	log.Printf("CreateAPIKey request for user: %s", req.GetUserId())
+
+	scopes := make([]domain.Permission, 0, len(req.GetScopes()))
+	for _, scope := range req.GetScopes() {
+		scopes = append(scopes, domain.Permission(scope))
+	}
+
+	var expiresAt *time.Time
+	if req.GetExpiresAt() != nil {
+		t := req.GetExpiresAt().AsTime()
+		expiresAt = &t
+	}
+
+	key, plain, err := h.service.CreateAPIKey(ctx, req.GetUserId(), req.GetName(), scopes, expiresAt)
+	if err != nil {
+		return nil, apiKeyError(err)
+	}
+
+	return &users.CreateAPIKeyResponse{
+		ApiKey: key.ToProto(),
+		Key:    plain,
+	}, nil
+}
+
+func (h *UserHandler) ListAPIKeys(ctx context.Context, req *users.ListAPIKeysRequest) (*users.ListAPIKeysResponse, error) {
+	log.Printf("ListAPIKeys request for user: %s", req.GetUserId())
+
+	keys, err := h.service.ListAPIKeys(ctx, req.GetUserId())
+	if err != nil {
+		return nil, apiKeyError(err)
+	}
+
+	resp := &users.ListAPIKeysResponse{
+		ApiKeys: make([]*users.APIKey, 0, len(keys)),
+	}
+	for _, key := range keys {
+		resp.ApiKeys = append(resp.ApiKeys, key.ToProto())
+	}
+
+	return resp, nil
+}
+
+func (h *UserHandler) RevokeAPIKey(ctx context.Context, req *users.RevokeAPIKeyRequest) (*emptypb.Empty, error) {
+	log.Printf("RevokeAPIKey request for user: %s", req.GetUserId())
+
+	if err := h.service.RevokeAPIKey(ctx, req.GetUserId(), req.GetApiKeyId()); err != nil {
+		return nil, apiKeyError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// apiKeyError преобразует ошибки API ключей в gRPC статус
+func apiKeyError(err error) error {
+	if st := accessError(err); st != nil {
+		return st
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, validationErr.Message)
+	}
+
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case domain.ErrCodeUserNotFound, domain.ErrCodeAPIKeyNotFound:
+			return status.Error(codes.NotFound, domainErr.Message)
+		case domain.ErrCodeAPIKeyLimitReached:
+			return status.Error(codes.ResourceExhausted, domainErr.Message)
+		}
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (h *UserHandler) IssueServiceToken(ctx context.Context, req *users.IssueServiceTokenRequest) (*users.IssueServiceTokenResponse, error) {
+	log.Printf("IssueServiceToken request for client: %s", req.GetClientId())
+
+	token, err := h.service.IssueServiceToken(ctx, req.GetClientId(), req.GetClientSecret(), req.GetAudience())
 	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, status.Error(codes.InvalidArgument, validationErr.Message)
+		}
+
+		var domainErr *domain.DomainError
+		if errors.As(err, &domainErr) {
+			switch domainErr.Code {
+			case domain.ErrCodeInvalidClientCredentials:
+				return nil, status.Error(codes.Unauthenticated, domainErr.Message)
+			case domain.ErrCodeAudienceNotAllowed:
+				return nil, status.Error(codes.PermissionDenied, domainErr.Message)
+			}
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return user.ToProto(), nil
+	resp := &users.IssueServiceTokenResponse{
+		AccessToken: token.AccessToken,
+		ExpiresAt:   timestamppb.New(token.ExpiresAt),
+		Audience:    token.Audience,
+		Scopes:      make([]string, 0, len(token.Permissions)),
+	}
+	for _, perm := range token.Permissions {
+		resp.Scopes = append(resp.Scopes, string(perm))
+	}
+
+	return resp, nil
+}
+
+func (h *UserHandler) ImpersonateUser(ctx context.Context, req *users.ImpersonateUserRequest) (*users.ImpersonateUserResponse, error) {
+	log.Printf("ImpersonateUser request for user: %s", req.GetUserId())
+
+	user, token, expiresAt, err := h.service.ImpersonateUser(ctx, req.GetUserId(), req.GetReason())
+	if err != nil {
+		return nil, impersonationError(err)
+	}
+
+	return &users.ImpersonateUserResponse{
+		Token:     token,
+		ExpiresAt: timestamppb.New(expiresAt),
+		User:      user.ToProto(),
+	}, nil
+}
+
+func (h *UserHandler) StopImpersonation(ctx context.Context, req *users.StopImpersonationRequest) (*emptypb.Empty, error) {
+	log.Printf("StopImpersonation request")
+
+	if err := h.service.StopImpersonation(ctx); err != nil {
+		return nil, impersonationError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// impersonationError преобразует ошибки входа от имени пользователя в gRPC статус
+func impersonationError(err error) error {
+	if st := accessError(err); st != nil {
+		return st
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, validationErr.Message)
+	}
+
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case domain.ErrCodeUserNotFound:
+			return status.Error(codes.NotFound, "user not found")
+		case domain.ErrCodeImpersonationNotAllowed:
+			return status.Error(codes.PermissionDenied, domainErr.Message)
+		case domain.ErrCodeNotImpersonating:
+			return status.Error(codes.FailedPrecondition, domainErr.Message)
+		}
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (h *UserHandler) ClearLockout(ctx context.Context, req *users.ClearLockoutRequest) (*emptypb.Empty, error) {
+	log.Printf("ClearLockout request for email: %s, ip: %s", req.GetEmail(), req.GetIpAddress())
+
+	if err := h.service.ClearLockout(ctx, req.GetEmail(), req.GetIpAddress()); err != nil {
+		if st := accessError(err); st != nil {
+			return nil, st
+		}
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, status.Error(codes.InvalidArgument, "email or ip_address is required")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) RequestPasswordReset(ctx context.Context, req *users.RequestPasswordResetRequest) (*emptypb.Empty, error) {
+	log.Printf("RequestPasswordReset request for email: %s", req.GetEmail())
+
+	if err := h.service.RequestPasswordReset(ctx, req.GetEmail()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) ConfirmPasswordReset(ctx context.Context, req *users.ConfirmPasswordResetRequest) (*emptypb.Empty, error) {
+	log.Printf("ConfirmPasswordReset request")
+
+	if err := h.service.ConfirmPasswordReset(ctx, req.GetToken(), req.GetNewPassword()); err != nil {
+		if err == domain.ErrResetTokenInvalid {
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired reset token")
+		}
+		if st := passwordPolicyStatus(err); st != nil {
+			return nil, st
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// accessError преобразует ошибки аутентификации и прав доступа.
+// Для остальных ошибок возвращает nil.
+func accessError(err error) error {
+	if errors.Is(err, domain.ErrTokenNotProvided) {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case domain.ErrCodePermissionDenied, domain.ErrCodeSessionTokenRequired, domain.ErrCodeImpersonationForbidden:
+			return status.Error(codes.PermissionDenied, domainErr.Message)
+		}
+	}
+	return nil
+}
+
+// passwordPolicyStatus преобразует нарушения политики паролей в InvalidArgument.
+// Каждое нарушение передается отдельным FieldViolation в деталях статуса.
+// Для остальных ошибок возвращает nil.
+func passwordPolicyStatus(err error) error {
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Code != domain.ErrCodeInvalidPassword {
+		return nil
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	if violations, ok := validationErr.Details["violations"].([]domain.PasswordViolation); ok {
+		for _, v := range violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       validationErr.Field,
+				Description: v.Message,
+				Reason:      v.Code,
+			})
+		}
+	}
+
+	st, detailsErr := status.New(codes.InvalidArgument, validationErr.Message).WithDetails(badRequest)
+	if detailsErr != nil {
+		return status.Error(codes.InvalidArgument, validationErr.Message)
+	}
+	return st.Err()
+}
+
+func (h *UserHandler) BanUser(ctx context.Context, req *users.BanUserRequest) (*users.User, error) {
+	log.Printf("BanUser request for user: %s", req.GetUserId())
+
+	var duration *time.Duration
+	if req.GetBannedUntil() != nil {
+		dur := time.Until(req.GetBannedUntil().AsTime())
+		duration = &dur
+	}
+
+	user, err := h.service.BanUser(ctx, req.GetUserId(), req.GetReason(), domain.BanCategoryFromProto(req.GetCategory()), duration)
+	if err != nil {
+		return nil, banError(err)
+	}
+
+	return user.ToProto(), nil
+}
+
+func (h *UserHandler) UnbanUser(ctx context.Context, req *users.UnbanUserRequest) (*users.User, error) {
+	log.Printf("UnbanUser request for user: %s", req.GetUserId())
+
+	user, err := h.service.UnbanUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, banError(err)
+	}
+
+	return user.ToProto(), nil
+}
+
+func (h *UserHandler) GetBanStatistics(ctx context.Context, req *users.GetBanStatisticsRequest) (*users.BanStatisticsResponse, error) {
+	log.Printf("GetBanStatistics request")
+
+	stats, err := h.service.GetBanStatistics(ctx)
+	if err != nil {
+		return nil, banError(err)
+	}
+
+	resp := &users.BanStatisticsResponse{}
+	for _, st := range stats {
+		resp.Categories = append(resp.Categories, st.ToProto())
+		resp.TotalActive += st.Active
+	}
+
+	return resp, nil
+}
+
+func (h *UserHandler) IssueWarning(ctx context.Context, req *users.IssueWarningRequest) (*users.IssueWarningResponse, error) {
+	log.Printf("IssueWarning request for user: %s", req.GetUserId())
+
+	result, err := h.service.IssueWarning(ctx, req.GetUserId(), req.GetReason())
+	if err != nil {
+		return nil, banError(err)
+	}
+
+	return &users.IssueWarningResponse{
+		Strike:        result.Strike.ToProto(),
+		ActiveStrikes: int32(result.ActiveStrikes),
+		Banned:        result.Banned,
+		User:          result.User.ToProto(),
+	}, nil
+}
+
+func (h *UserHandler) ListStrikes(ctx context.Context, req *users.ListStrikesRequest) (*users.ListStrikesResponse, error) {
+	log.Printf("ListStrikes request for user: %s", req.GetUserId())
+
+	strikes, err := h.service.ListStrikes(ctx, req.GetUserId())
+	if err != nil {
+		return nil, banError(err)
+	}
+
+	resp := &users.ListStrikesResponse{}
+	for _, strike := range strikes {
+		resp.Strikes = append(resp.Strikes, strike.ToProto())
+	}
+
+	return resp, nil
+}
+
+// bannedStatus возвращает PermissionDenied с токеном подачи апелляции в деталях
+func bannedStatus(bannedErr *domain.BannedError) error {
+	info := &errdetails.ErrorInfo{
+		Reason:   "USER_BANNED",
+		Domain:   "userservice",
+		Metadata: map[string]string{"appeal_token": bannedErr.AppealToken},
+	}
+
+	st, detailsErr := status.New(codes.PermissionDenied, "user is banned").WithDetails(info)
+	if detailsErr != nil {
+		return status.Error(codes.PermissionDenied, "user is banned")
+	}
+	return st.Err()
+}
+
+func (h *UserHandler) SubmitBanAppeal(ctx context.Context, req *users.SubmitBanAppealRequest) (*users.BanAppeal, error) {
+	log.Printf("SubmitBanAppeal request")
+
+	appeal, err := h.service.SubmitBanAppeal(ctx, req.GetAppealToken(), req.GetMessage())
+	if err != nil {
+		return nil, appealError(err)
+	}
+
+	return appeal.ToProto(), nil
+}
+
+func (h *UserHandler) ListBanAppeals(ctx context.Context, req *users.ListBanAppealsRequest) (*users.ListBanAppealsResponse, error) {
+	log.Printf("ListBanAppeals request: page=%d, page_size=%d", req.GetPage(), req.GetPageSize())
+
+	filter := &domain.AppealFilter{
+		Page:       int(req.GetPage()),
+		PageSize:   int(req.GetPageSize()),
+		Status:     domain.AppealStatusFromProto(req.GetStatus()),
+		AssignedTo: req.GetAssignedTo(),
+	}
+
+	appeals, total, err := h.service.ListBanAppeals(ctx, filter)
+	if err != nil {
+		return nil, appealError(err)
+	}
+
+	resp := &users.ListBanAppealsResponse{
+		Total:    int32(total),
+		Page:     int32(filter.Page),
+		PageSize: int32(filter.PageSize),
+	}
+	for _, appeal := range appeals {
+		resp.Appeals = append(resp.Appeals, appeal.ToProto())
+	}
+
+	return resp, nil
+}
+
+func (h *UserHandler) AssignBanAppeal(ctx context.Context, req *users.AssignBanAppealRequest) (*users.BanAppeal, error) {
+	log.Printf("AssignBanAppeal request for appeal: %s", req.GetAppealId())
+
+	appeal, err := h.service.AssignBanAppeal(ctx, req.GetAppealId(), req.GetModeratorId())
+	if err != nil {
+		return nil, appealError(err)
+	}
+
+	return appeal.ToProto(), nil
+}
+
+func (h *UserHandler) ApproveBanAppeal(ctx context.Context, req *users.ResolveBanAppealRequest) (*users.BanAppeal, error) {
+	log.Printf("ApproveBanAppeal request for appeal: %s", req.GetAppealId())
+
+	appeal, err := h.service.ApproveBanAppeal(ctx, req.GetAppealId(), req.GetResolution())
+	if err != nil {
+		return nil, appealError(err)
+	}
+
+	return appeal.ToProto(), nil
+}
+
+func (h *UserHandler) RejectBanAppeal(ctx context.Context, req *users.ResolveBanAppealRequest) (*users.BanAppeal, error) {
+	log.Printf("RejectBanAppeal request for appeal: %s", req.GetAppealId())
+
+	appeal, err := h.service.RejectBanAppeal(ctx, req.GetAppealId(), req.GetResolution())
+	if err != nil {
+		return nil, appealError(err)
+	}
+
+	return appeal.ToProto(), nil
+}
+
+func (h *UserHandler) ReportUser(ctx context.Context, req *users.ReportUserRequest) (*users.ReportUserResponse, error) {
+	log.Printf("ReportUser request for user: %s", req.GetUserId())
+
+	report, duplicate, err := h.service.ReportUser(ctx, req.GetUserId(), domain.ReportCategoryFromProto(req.GetCategory()), req.GetDescription(), req.GetEvidence())
+	if err != nil {
+		return nil, reportError(err)
+	}
+
+	return &users.ReportUserResponse{
+		ReportId:  report.ID,
+		Status:    domain.ReportStatusToProto(report.Status),
+		Duplicate: duplicate,
+	}, nil
+}
+
+func (h *UserHandler) ListModerationQueue(ctx context.Context, req *users.ListModerationQueueRequest) (*users.ListModerationQueueResponse, error) {
+	log.Printf("ListModerationQueue request: page=%d, page_size=%d", req.GetPage(), req.GetPageSize())
+
+	filter := &domain.ReportFilter{
+		Page:      int(req.GetPage()),
+		PageSize:  int(req.GetPageSize()),
+		Status:    domain.ReportStatusFromProto(req.GetStatus()),
+		Category:  domain.ReportCategoryFromProto(req.GetCategory()),
+		ClaimedBy: req.GetClaimedBy(),
+	}
+
+	reports, total, err := h.service.ListModerationQueue(ctx, filter)
+	if err != nil {
+		return nil, reportError(err)
+	}
+
+	resp := &users.ListModerationQueueResponse{
+		Total:    int32(total),
+		Page:     int32(filter.Page),
+		PageSize: int32(filter.PageSize),
+	}
+	for _, report := range reports {
+		resp.Reports = append(resp.Reports, report.ToProto())
+	}
+
+	return resp, nil
+}
+
+func (h *UserHandler) ClaimReport(ctx context.Context, req *users.ClaimReportRequest) (*users.UserReport, error) {
+	log.Printf("ClaimReport request for report: %s", req.GetReportId())
+
+	report, err := h.service.ClaimReport(ctx, req.GetReportId())
+	if err != nil {
+		return nil, reportError(err)
+	}
+
+	return report.ToProto(), nil
+}
+
+func (h *UserHandler) ResolveReport(ctx context.Context, req *users.ResolveReportRequest) (*users.UserReport, error) {
+	log.Printf("ResolveReport request for report: %s", req.GetReportId())
+
+	resolution := &domain.ReportResolution{
+		Action: domain.ReportActionFromProto(req.GetAction()),
+		Note:   req.GetResolution(),
+	}
+	if req.GetBannedUntil() != nil {
+		dur := time.Until(req.GetBannedUntil().AsTime())
+		resolution.BanDuration = &dur
+	}
+
+	report, err := h.service.ResolveReport(ctx, req.GetReportId(), resolution)
+	if err != nil {
+		return nil, reportError(err)
+	}
+
+	return report.ToProto(), nil
+}
+
+// reportError преобразует ошибки жалоб в gRPC статус.
+// Ошибки бана и страйка при решении по жалобе разбирает banError.
+func reportError(err error) error {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case domain.ErrCodeReportNotFound:
+			return status.Error(codes.NotFound, domainErr.Message)
+		case domain.ErrCodeReportClosed, domain.ErrCodeReportNotClaimed:
+			return status.Error(codes.FailedPrecondition, domainErr.Message)
+		case domain.ErrCodeSelfReportNotAllowed:
+			return status.Error(codes.PermissionDenied, domainErr.Message)
+		case domain.ErrCodeInvalidReportCategory, domain.ErrCodeInvalidReportResolution:
+			return status.Error(codes.InvalidArgument, domainErr.Message)
+		}
+	}
+
+	return banError(err)
+}
+
+func (h *UserHandler) CreateDenyListEntry(ctx context.Context, req *users.CreateDenyListEntryRequest) (*users.DenyListEntry, error) {
+	log.Printf("CreateDenyListEntry request: kind=%s", req.GetKind())
+
+	var expiresAt *time.Time
+	if req.GetExpiresAt() != nil {
+		t := req.GetExpiresAt().AsTime()
+		expiresAt = &t
+	}
+
+	entry, err := h.service.CreateDenyListEntry(ctx, domain.DenyListKindFromProto(req.GetKind()), req.GetValue(), req.GetReason(), expiresAt)
+	if err != nil {
+		return nil, denyListError(err)
+	}
+
+	return entry.ToProto(), nil
+}
+
+func (h *UserHandler) GetDenyListEntry(ctx context.Context, req *users.GetDenyListEntryRequest) (*users.DenyListEntry, error) {
+	log.Printf("GetDenyListEntry request for entry: %s", req.GetEntryId())
+
+	entry, err := h.service.GetDenyListEntry(ctx, req.GetEntryId())
+	if err != nil {
+		return nil, denyListError(err)
+	}
+
+	return entry.ToProto(), nil
+}
+
+func (h *UserHandler) ListDenyListEntries(ctx context.Context, req *users.ListDenyListEntriesRequest) (*users.ListDenyListEntriesResponse, error) {
+	log.Printf("ListDenyListEntries request: page=%d, page_size=%d", req.GetPage(), req.GetPageSize())
+
+	filter := &domain.DenyListFilter{
+		Page:           int(req.GetPage()),
+		PageSize:       int(req.GetPageSize()),
+		Kind:           domain.DenyListKindFromProto(req.GetKind()),
+		IncludeExpired: req.GetIncludeExpired(),
+	}
+
+	entries, total, err := h.service.ListDenyListEntries(ctx, filter)
+	if err != nil {
+		return nil, denyListError(err)
+	}
+
+	resp := &users.ListDenyListEntriesResponse{
+		Total:    int32(total),
+		Page:     int32(filter.Page),
+		PageSize: int32(filter.PageSize),
+	}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, entry.ToProto())
+	}
+
+	return resp, nil
+}
+
+func (h *UserHandler) UpdateDenyListEntry(ctx context.Context, req *users.UpdateDenyListEntryRequest) (*users.DenyListEntry, error) {
+	log.Printf("UpdateDenyListEntry request for entry: %s", req.GetEntryId())
+
+	var expiresAt *time.Time
+	if req.GetExpiresAt() != nil {
+		t := req.GetExpiresAt().AsTime()
+		expiresAt = &t
+	}
+
+	entry, err := h.service.UpdateDenyListEntry(ctx, req.GetEntryId(), req.GetReason(), expiresAt)
+	if err != nil {
+		return nil, denyListError(err)
+	}
+
+	return entry.ToProto(), nil
+}
+
+func (h *UserHandler) DeleteDenyListEntry(ctx context.Context, req *users.DeleteDenyListEntryRequest) (*emptypb.Empty, error) {
+	log.Printf("DeleteDenyListEntry request for entry: %s", req.GetEntryId())
+
+	if err := h.service.DeleteDenyListEntry(ctx, req.GetEntryId()); err != nil {
+		return nil, denyListError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// denyListError преобразует ошибки списков запрета в gRPC статус
+func denyListError(err error) error {
+	if st := accessError(err); st != nil {
+		return st
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, validationErr.Message)
+	}
+
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case domain.ErrCodeDenyListEntryNotFound:
+			return status.Error(codes.NotFound, domainErr.Message)
+		case domain.ErrCodeDenyListEntryExists:
+			return status.Error(codes.AlreadyExists, domainErr.Message)
+		case domain.ErrCodeInvalidDenyListKind:
+			return status.Error(codes.InvalidArgument, domainErr.Message)
+		}
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+// appealError преобразует ошибки апелляций в gRPC статус
+func appealError(err error) error {
+	if st := accessError(err); st != nil {
+		return st
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, validationErr.Message)
+	}
+
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case domain.ErrCodeUserNotFound:
+			return status.Error(codes.NotFound, "user not found")
+		case domain.ErrCodeAppealNotFound:
+			return status.Error(codes.NotFound, domainErr.Message)
+		case domain.ErrCodeAppealTokenInvalid:
+			return status.Error(codes.Unauthenticated, domainErr.Message)
+		case domain.ErrCodeAppealAlreadySubmitted:
+			return status.Error(codes.AlreadyExists, domainErr.Message)
+		case domain.ErrCodeAppealClosed, domain.ErrCodeBanNotFound:
+			return status.Error(codes.FailedPrecondition, domainErr.Message)
+		}
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+// banError преобразует ошибки бана, разбана и предупреждений в gRPC статус
+func banError(err error) error {
+	if st := accessError(err); st != nil {
+		return st
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, validationErr.Message)
+	}
+
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case domain.ErrCodeUserNotFound:
+			return status.Error(codes.NotFound, "user not found")
+		case domain.ErrCodeSelfBanNotAllowed, domain.ErrCodeAdminBanNotAllowed, domain.ErrCodeSelfWarnNotAllowed:
+			return status.Error(codes.PermissionDenied, domainErr.Message)
+		case domain.ErrCodeInvalidBanCategory:
+			return status.Error(codes.InvalidArgument, domainErr.Message)
+		case domain.ErrCodeBanAlreadyActive, domain.ErrCodeBanNotFound:
+			return status.Error(codes.FailedPrecondition, domainErr.Message)
+		}
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (h *UserHandler) UpdateSubscription(ctx context.Context, req *users.UpdateSubscriptionRequest) (*users.User, error) {
+	log.Printf("UpdateSubscription request for user: %s", req.GetUserId())
+
+	// Меняем только переданные поля, остальное сервис берет из текущей подписки
+	update := domain.SubscriptionUpdateFromProto(req)
+
+	user, err := h.service.UpdateSubscription(ctx, req.GetUserId(), update)
+	if err != nil {
+		return nil, subscriptionError(err)
+	}
+
+	return user.ToProto(), nil
+}
+
+func (h *UserHandler) CancelSubscription(ctx context.Context, req *users.CancelSubscriptionRequest) (*users.User, error) {
+	log.Printf("CancelSubscription request for user: %s", req.GetUserId())
+
+	user, err := h.service.CancelSubscription(ctx, req.GetUserId(), req.GetReason(), req.GetImmediateCancellation())
+	if err != nil {
+		return nil, subscriptionError(err)
+	}
+
+	return user.ToProto(), nil
+}
+
+// subscriptionError преобразует ошибки подписок в gRPC статус
+func subscriptionError(err error) error {
+	if st := accessError(err); st != nil {
+		return st
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, validationErr.Message)
+	}
+
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case domain.ErrCodeUserNotFound:
+			return status.Error(codes.NotFound, "user not found")
+		case domain.ErrCodeInvalidSubscriptionTransition:
+			return status.Error(codes.FailedPrecondition, domainErr.Message)
+		}
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (h *UserHandler) CreateOrganization(ctx context.Context, req *users.CreateOrganizationRequest) (*users.Organization, error) {
+	log.Printf("CreateOrganization request: %s", req.GetSlug())
+
+	org, err := h.service.CreateOrganization(ctx, req.GetName(), req.GetSlug())
+	if err != nil {
+		return nil, orgError(err)
+	}
+
+	return org.ToProto(), nil
+}
+
+func (h *UserHandler) GetOrganization(ctx context.Context, req *users.GetOrganizationRequest) (*users.Organization, error) {
+	log.Printf("GetOrganization request: %s", req.GetOrganizationId())
+
+	org, err := h.service.GetOrganization(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, orgError(err)
+	}
+
+	return org.ToProto(), nil
+}
+
+func (h *UserHandler) ListOrganizations(ctx context.Context, req *users.ListOrganizationsRequest) (*users.ListOrganizationsResponse, error) {
+	log.Printf("ListOrganizations request")
+
+	orgs, err := h.service.ListOrganizations(ctx)
+	if err != nil {
+		return nil, orgError(err)
+	}
+
+	resp := &users.ListOrganizationsResponse{
+		Organizations: make([]*users.Organization, 0, len(orgs)),
+	}
+	for _, org := range orgs {
+		resp.Organizations = append(resp.Organizations, org.ToProto())
+	}
+
+	return resp, nil
+}
+
+func (h *UserHandler) ListOrganizationMembers(ctx context.Context, req *users.ListOrganizationMembersRequest) (*users.ListOrganizationMembersResponse, error) {
+	log.Printf("ListOrganizationMembers request: %s", req.GetOrganizationId())
+
+	members, err := h.service.ListOrganizationMembers(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, orgError(err)
+	}
+
+	resp := &users.ListOrganizationMembersResponse{
+		Members: make([]*users.OrganizationMember, 0, len(members)),
+	}
+	for _, member := range members {
+		resp.Members = append(resp.Members, member.ToProto())
+	}
+
+	return resp, nil
+}
+
+func (h *UserHandler) UpdateOrganizationMember(ctx context.Context, req *users.UpdateOrganizationMemberRequest) (*users.OrganizationMember, error) {
+	log.Printf("UpdateOrganizationMember request: %s, user: %s", req.GetOrganizationId(), req.GetUserId())
+
+	member, err := h.service.UpdateOrganizationMember(ctx, req.GetOrganizationId(), req.GetUserId(), domain.OrgRoleFromProto(req.GetRole()))
+	if err != nil {
+		return nil, orgError(err)
+	}
+
+	return member.ToProto(), nil
+}
+
+func (h *UserHandler) RemoveOrganizationMember(ctx context.Context, req *users.RemoveOrganizationMemberRequest) (*emptypb.Empty, error) {
+	log.Printf("RemoveOrganizationMember request: %s, user: %s", req.GetOrganizationId(), req.GetUserId())
+
+	if err := h.service.RemoveOrganizationMember(ctx, req.GetOrganizationId(), req.GetUserId()); err != nil {
+		return nil, orgError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) InviteToOrganization(ctx context.Context, req *users.InviteToOrganizationRequest) (*users.OrganizationInvitation, error) {
+	log.Printf("InviteToOrganization request: %s, email: %s", req.GetOrganizationId(), req.GetEmail())
+
+	inv, err := h.service.InviteToOrganization(ctx, req.GetOrganizationId(), req.GetEmail(), domain.OrgRoleFromProto(req.GetRole()))
+	if err != nil {
+		return nil, orgError(err)
+	}
+
+	return inv.ToProto(), nil
+}
+
+func (h *UserHandler) ListMyInvitations(ctx context.Context, req *users.ListMyInvitationsRequest) (*users.ListMyInvitationsResponse, error) {
+	log.Printf("ListMyInvitations request")
+
+	invitations, err := h.service.ListMyInvitations(ctx)
+	if err != nil {
+		return nil, orgError(err)
+	}
+
+	resp := &users.ListMyInvitationsResponse{
+		Invitations: make([]*users.OrganizationInvitation, 0, len(invitations)),
+	}
+	for _, inv := range invitations {
+		resp.Invitations = append(resp.Invitations, inv.ToProto())
+	}
+
+	return resp, nil
+}
+
+func (h *UserHandler) AcceptInvitation(ctx context.Context, req *users.RespondInvitationRequest) (*users.OrganizationInvitation, error) {
+	log.Printf("AcceptInvitation request: %s", req.GetInvitationId())
+
+	inv, err := h.service.AcceptInvitation(ctx, req.GetOrganizationId(), req.GetInvitationId())
+	if err != nil {
+		return nil, orgError(err)
+	}
+
+	return inv.ToProto(), nil
+}
+
+func (h *UserHandler) DeclineInvitation(ctx context.Context, req *users.RespondInvitationRequest) (*users.OrganizationInvitation, error) {
+	log.Printf("DeclineInvitation request: %s", req.GetInvitationId())
+
+	inv, err := h.service.DeclineInvitation(ctx, req.GetOrganizationId(), req.GetInvitationId())
+	if err != nil {
+		return nil, orgError(err)
+	}
+
+	return inv.ToProto(), nil
+}
+
+func (h *UserHandler) SwitchOrganization(ctx context.Context, req *users.SwitchOrganizationRequest) (*users.AuthenticateResponse, error) {
+	log.Printf("SwitchOrganization request: %s", req.GetOrganizationId())
+
+	user, tokens, err := h.service.SwitchOrganization(ctx, req.GetOrganizationId(), h.clientIP(ctx), deviceID(ctx))
+	if err != nil {
+		if err == domain.ErrUserBanned {
+			return nil, status.Error(codes.PermissionDenied, "user is banned")
+		}
+		if err == domain.ErrDeniedByDenyList {
+			return nil, status.Error(codes.PermissionDenied, "login is not allowed")
+		}
+		return nil, orgError(err)
+	}
+
+	return &users.AuthenticateResponse{
+		Token:            tokens.AccessToken,
+		User:             user.ToProto(),
+		ExpiresAt:        timestamppb.New(tokens.ExpiresAt),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: timestamppb.New(tokens.RefreshExpiresAt),
+	}, nil
+}
+
+// orgError преобразует ошибки организаций и приглашений в gRPC статус
+func orgError(err error) error {
+	if st := accessError(err); st != nil {
+		return st
+	}
+
+	if err == domain.ErrEmailNotVerified {
+		return status.Error(codes.FailedPrecondition, "email is not verified")
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, validationErr.Message)
+	}
+
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case domain.ErrCodeOrganizationNotFound, domain.ErrCodeNotOrganizationMember,
+			domain.ErrCodeInvitationNotFound, domain.ErrCodeUserNotFound:
+			return status.Error(codes.NotFound, domainErr.Message)
+		case domain.ErrCodeOrganizationSlugTaken, domain.ErrCodeAlreadyOrganizationMember:
+			return status.Error(codes.AlreadyExists, domainErr.Message)
+		case domain.ErrCodeLastOrganizationOwner, domain.ErrCodeInvitationExpired:
+			return status.Error(codes.FailedPrecondition, domainErr.Message)
+		case domain.ErrCodeInvalidOrgRole:
+			return status.Error(codes.InvalidArgument, domainErr.Message)
+		}
+	}
+
+	return status.Error(codes.Internal, err.Error())
 }
 
 func (h *UserHandler) HealthCheck(ctx context.Context, req *users.HealthCheckRequest) (*users.HealthCheckResponse, error) {
 	log.Printf("HealthCheck request")
 
-	healthy, err := h.service.HealthCheck()
+	healthy, err := h.service.HealthCheck(ctx)
 	if err != nil || !healthy {
 		return nil, status.Error(codes.Internal, "service unhealthy")
 	}
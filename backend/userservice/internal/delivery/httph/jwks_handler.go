@@ -0,0 +1,49 @@
+package httph
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"userservice/pkg/jwt"
+)
+
+// JWKSPath - стандартный путь набора открытых ключей
+const JWKSPath = "/.well-known/jwks.json"
+
+// JWKSHandler отдает открытые ключи подписи токенов
+type JWKSHandler struct {
+	jwtManager *jwt.JWTManager
+}
+
+func NewJWKSHandler(jwtManager *jwt.JWTManager) *JWKSHandler {
+	return &JWKSHandler{
+		jwtManager: jwtManager,
+	}
+}
+
+func (h *JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := json.Marshal(h.jwtManager.JWKS())
+	if err != nil {
+		log.Printf("Failed to marshal JWKS: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Новый ключ попадает в набор за publish_delay до того, как начнет подписывать.
+	// max-age входит в цепочку кешей, которую должна перекрывать эта задержка.
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Write(body)
+}
+
+// NewRouter создает HTTP маршруты сервиса
+func NewRouter(jwtManager *jwt.JWTManager) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(JWKSPath, NewJWKSHandler(jwtManager))
+	return mux
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// APIKeyPrefix - префикс, по которому API ключ отличается от JWT
+const APIKeyPrefix = "usk_"
+
+// APIKey - ключ доступа для машинных клиентов (personal access token).
+// Ключ имеет вид usk_<prefix>_<secret>, в хранилище попадает только его хеш.
+type APIKey struct {
+	ID         string
+	UserID     string
+	Name       string
+	Prefix     string       // Открытая часть ключа для поиска и отображения
+	KeyHash    string       // SHA-256 всего ключа
+	Scopes     []Permission // Ограничивает права ключа, пусто - все права владельца
+	ExpiresAt  *time.Time   // nil - бессрочный
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// NewAPIKey создает запись API ключа
+func NewAPIKey(userID, name, prefix, keyHash string, scopes []Permission, expiresAt *time.Time) *APIKey {
+	return &APIKey{
+		ID:        GenerateUUID(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsActive проверяет, что ключ не отозван и не истек
+func (k *APIKey) IsActive() bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || time.Now().Before(*k.ExpiresAt)
+}
+
+// IsAPIKey проверяет, похож ли bearer токен на API ключ
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// ParseAPIKey возвращает открытую часть ключа. ok равен false, если формат неверный.
+func ParseAPIKey(key string) (prefix string, ok bool) {
+	prefix, secret, found := strings.Cut(strings.TrimPrefix(key, APIKeyPrefix), "_")
+	if !IsAPIKey(key) || !found || prefix == "" || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+// APIKeyRepository определяет хранилище API ключей
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	FindByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+
+	// ListByUser возвращает неотозванные ключи пользователя
+	ListByUser(ctx context.Context, userID string) ([]*APIKey, error)
+	Revoke(ctx context.Context, userID, id string, at time.Time) error
+	UpdateLastUsed(ctx context.Context, id string, at time.Time) error
+}
+
+// APIKeyAuthenticator проверяет API ключ и возвращает его владельца как вызывающего
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*Principal, error)
+}
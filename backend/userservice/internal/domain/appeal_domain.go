@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AppealStatus - статус апелляции на бан
+type AppealStatus string
+
+const (
+	AppealStatusPending  AppealStatus = "PENDING"
+	AppealStatusInReview AppealStatus = "IN_REVIEW" // Назначена модератору
+	AppealStatusApproved AppealStatus = "APPROVED"  // Бан снят
+	AppealStatusRejected AppealStatus = "REJECTED"
+)
+
+// IsOpen проверяет, что по апелляции еще не принято решение
+func (s AppealStatus) IsOpen() bool {
+	return s == AppealStatusPending || s == AppealStatusInReview
+}
+
+// BanAppeal - апелляция пользователя на бан. На каждый бан - не больше одной.
+type BanAppeal struct {
+	ID         string
+	UserID     string
+	BannedAt   time.Time // Начало обжалуемого бана, вместе с UserID определяет бан
+	Message    string
+	Status     AppealStatus
+	AssignedTo string // ID модератора, пусто - не назначена
+	ResolvedBy string
+	Resolution string // Комментарий модератора к решению
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	ResolvedAt *time.Time
+}
+
+// NewBanAppeal создает апелляцию на бан, начавшийся в bannedAt
+func NewBanAppeal(userID string, bannedAt time.Time, message string) *BanAppeal {
+	now := time.Now()
+
+	return &BanAppeal{
+		ID:     GenerateUUID(),
+		UserID: userID,
+		// Точность времени в PostgreSQL - микросекунды
+		BannedAt:  bannedAt.Truncate(time.Microsecond),
+		Message:   message,
+		Status:    AppealStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsFor проверяет, что апелляция подана на этот бан, а не на более ранний
+func (a *BanAppeal) IsFor(ban *BanInfo) bool {
+	return ban != nil && a.BannedAt.Equal(ban.BannedAt.Truncate(time.Microsecond))
+}
+
+// AppealFilter - фильтр очереди апелляций
+type AppealFilter struct {
+	Page       int
+	PageSize   int
+	Status     AppealStatus // Пусто - все открытые
+	AssignedTo string
+}
+
+// BannedError - вход забаненного пользователя с верным паролем.
+// AppealToken позволяет только подать апелляцию на текущий бан.
+type BannedError struct {
+	AppealToken string
+}
+
+func (e *BannedError) Error() string {
+	return ErrUserBanned.Error()
+}
+
+func (e *BannedError) Unwrap() error {
+	return ErrUserBanned
+}
+
+// BanAppealRepository определяет хранилище апелляций на баны
+type BanAppealRepository interface {
+	// Create сохраняет апелляцию. Повторная апелляция на тот же бан - ErrAppealAlreadySubmitted.
+	Create(ctx context.Context, appeal *BanAppeal) error
+	FindByID(ctx context.Context, id string) (*BanAppeal, error)
+	List(ctx context.Context, filter *AppealFilter) ([]*BanAppeal, int64, error)
+
+	// Assign назначает открытую апелляцию модератору и переводит ее в IN_REVIEW.
+	// Возвращает false, если по апелляции уже принято решение.
+	Assign(ctx context.Context, id, moderatorID string, at time.Time) (bool, error)
+
+	// Resolve закрывает открытую апелляцию со статусом status.
+	// Возвращает false, если по апелляции уже принято решение.
+	Resolve(ctx context.Context, id string, status AppealStatus, moderatorID, resolution string, at time.Time) (bool, error)
+}
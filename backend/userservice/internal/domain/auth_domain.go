@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PrincipalKind - вид вызывающего: человек или внутренний сервис
+type PrincipalKind string
+
+const (
+	PrincipalKindUser    PrincipalKind = "USER"
+	PrincipalKindService PrincipalKind = "SERVICE"
+	PrincipalKindSystem  PrincipalKind = "SYSTEM" // Фоновые задачи самого сервиса
+)
+
+// Principal - аутентифицированный вызывающий, извлеченный из access токена
+type Principal struct {
+	Kind     PrincipalKind // Пусто равносильно USER
+	UserID   string        // Пусто у сервисного аккаунта
+	ClientID string        // ID сервисного аккаунта
+	Email    string
+	Role     UserRole
+	TenantID string // Активная организация из токена, пусто вне организации
+	TokenID  string // jti access токена
+	IssuedAt time.Time
+
+	ImpersonatorID string // Администратор, действующий от имени UserID
+
+	APIKeyID string       // Заполнен, если вызов выполнен по API ключу
+	Scopes   []Permission // Ограничения API ключа или права сервисного аккаунта
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal добавляет вызывающего в контекст запроса
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext возвращает вызывающего. ok равен false для анонимных запросов.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// Permission - право на выполнение операции
+type Permission string
+
+const (
+	PermissionUsersRead          Permission = "users.read"          // Просмотр чужих профилей и списка пользователей
+	PermissionUsersWrite         Permission = "users.write"         // Изменение чужих профилей
+	PermissionUsersDelete        Permission = "users.delete"        // Удаление чужих аккаунтов
+	PermissionUsersBan           Permission = "users.ban"           // Бан и разбан
+	PermissionSessionsRevoke     Permission = "sessions.revoke"     // Завершение чужих сессий
+	PermissionLockoutClear       Permission = "lockout.clear"       // Снятие блокировки входа
+	PermissionSubscriptionsRead  Permission = "subscriptions.read"  // Просмотр чужих подписок
+	PermissionSubscriptionsWrite Permission = "subscriptions.write" // Изменение подписок
+	PermissionRolesAssign        Permission = "roles.assign"        // Назначение ролей
+	PermissionUsersImpersonate   Permission = "users.impersonate"   // Вход от имени пользователя
+	PermissionReportsModerate    Permission = "reports.moderate"    // Разбор жалоб на пользователей
+	PermissionDenyListManage     Permission = "denylist.manage"     // Списки запрета IP, доменов и устройств
+	PermissionSelfWrite          Permission = "self.write"          // Изменение своего аккаунта, есть у всех ролей
+)
+
+// rolePermissions - права каждой роли. Свои данные пользователь может читать
+// без отдельных прав, а менять - с правом self.write, которое есть у всех ролей.
+// Через него API ключ с ограниченными правами лишается доступа к аккаунту владельца.
+var rolePermissions = map[UserRole][]Permission{
+	UserRoleUser: {
+		PermissionSelfWrite,
+	},
+	UserRoleModerator: {
+		PermissionSelfWrite,
+		PermissionUsersRead,
+		PermissionUsersBan,
+		PermissionLockoutClear,
+		PermissionReportsModerate,
+	},
+	UserRoleAdmin: {
+		PermissionSelfWrite,
+		PermissionUsersRead,
+		PermissionUsersWrite,
+		PermissionUsersDelete,
+		PermissionUsersBan,
+		PermissionSessionsRevoke,
+		PermissionLockoutClear,
+		PermissionSubscriptionsRead,
+		PermissionSubscriptionsWrite,
+		PermissionRolesAssign,
+		PermissionReportsModerate,
+		PermissionDenyListManage,
+	},
+	UserRoleSuperAdmin: {
+		PermissionSelfWrite,
+		PermissionUsersRead,
+		PermissionUsersWrite,
+		PermissionUsersDelete,
+		PermissionUsersBan,
+		PermissionSessionsRevoke,
+		PermissionLockoutClear,
+		PermissionSubscriptionsRead,
+		PermissionSubscriptionsWrite,
+		PermissionRolesAssign,
+		PermissionUsersImpersonate,
+		PermissionReportsModerate,
+		PermissionDenyListManage,
+	},
+}
+
+// roleBoundPermissions - права, которые проверяются вместе с иерархией ролей:
+// цель должна иметь роль ниже вызывающего. У сервисного аккаунта роли нет,
+// поэтому такие права ему не выдаются.
+var roleBoundPermissions = []Permission{
+	PermissionSelfWrite,
+	PermissionUsersWrite,
+	PermissionUsersDelete,
+	PermissionUsersBan,
+	PermissionSessionsRevoke,
+	PermissionRolesAssign,
+	PermissionUsersImpersonate,
+	PermissionReportsModerate, // Решение по жалобе банит через BanUser
+}
+
+// AllowedForService проверяет, можно ли выдать право сервисному аккаунту
+func (p Permission) AllowedForService() bool {
+	return p.IsValid() && !hasPermission(roleBoundPermissions, p)
+}
+
+// HasPermission проверяет, есть ли у роли право
+func (r UserRole) HasPermission(perm Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid проверяет, что право известно. У SUPER_ADMIN есть все права.
+func (p Permission) IsValid() bool {
+	return UserRoleSuperAdmin.HasPermission(p)
+}
+
+// Permissions возвращает права роли
+func (r UserRole) Permissions() []Permission {
+	return append([]Permission(nil), rolePermissions[r]...)
+}
+
+// Rank возвращает уровень роли в иерархии: чем больше, тем больше полномочий
+func (r UserRole) Rank() int {
+	switch r {
+	case UserRoleSuperAdmin:
+		return 4
+	case UserRoleAdmin:
+		return 3
+	case UserRoleModerator:
+		return 2
+	case UserRoleUser:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CanManage проверяет, может ли роль управлять пользователем с ролью target.
+// Управлять можно только ролями ниже своей; SUPER_ADMIN управляет всеми.
+func (r UserRole) CanManage(target UserRole) bool {
+	return r == UserRoleSuperAdmin || r.Rank() > target.Rank()
+}
+
+// CanAssign проверяет, может ли роль назначить роль role.
+// ADMIN и SUPER_ADMIN назначает только SUPER_ADMIN.
+func (r UserRole) CanAssign(role UserRole) bool {
+	if !r.HasPermission(PermissionRolesAssign) || role.Rank() == 0 {
+		return false
+	}
+	if role == UserRoleAdmin || role == UserRoleSuperAdmin {
+		return r == UserRoleSuperAdmin
+	}
+	return r.CanManage(role)
+}
+
+// Can проверяет право вызывающего с учетом ограничений API ключа.
+// У сервисного аккаунта нет роли, его права перечислены в Scopes.
+func (p *Principal) Can(perm Permission) bool {
+	if p.IsService() {
+		return hasPermission(p.Scopes, perm)
+	}
+	if !p.Role.HasPermission(perm) {
+		return false
+	}
+	return len(p.Scopes) == 0 || hasPermission(p.Scopes, perm)
+}
+
+// IsService проверяет, что вызывающий - сервисный аккаунт
+func (p *Principal) IsService() bool {
+	return p.Kind == PrincipalKindService
+}
+
+// IsImpersonated проверяет, что администратор действует от имени пользователя
+func (p *Principal) IsImpersonated() bool {
+	return p.ImpersonatorID != ""
+}
+
+// ActorID возвращает ID реального исполнителя для аудита:
+// пользователя, администратора при имперсонации или сервисного аккаунта
+func (p *Principal) ActorID() string {
+	if p.IsService() {
+		return p.ClientID
+	}
+	if p.IsImpersonated() {
+		return p.ImpersonatorID
+	}
+	return p.UserID
+}
+
+// ActorKind возвращает вид вызывающего для аудита
+func (p *Principal) ActorKind() PrincipalKind {
+	if p.Kind == "" {
+		return PrincipalKindUser
+	}
+	return p.Kind
+}
+
+func hasPermission(perms []Permission, perm Permission) bool {
+	for _, p := range perms {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAPIKey проверяет, что вызов выполнен по API ключу
+func (p *Principal) IsAPIKey() bool {
+	return p.APIKeyID != ""
+}
+
+// IsSelf проверяет, относится ли операция к самому вызывающему
+func (p *Principal) IsSelf(userID string) bool {
+	return userID != "" && p.UserID == userID
+}
@@ -0,0 +1,172 @@
+package domain
+
+import "testing"
+
+func TestPrincipalCan(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *Principal
+		perm      Permission
+		want      bool
+	}{
+		{
+			name:      "role has permission",
+			principal: &Principal{UserID: "u1", Role: UserRoleAdmin},
+			perm:      PermissionUsersWrite,
+			want:      true,
+		},
+		{
+			name:      "role lacks permission",
+			principal: &Principal{UserID: "u1", Role: UserRoleModerator},
+			perm:      PermissionUsersWrite,
+			want:      false,
+		},
+		{
+			name:      "user writes own account",
+			principal: &Principal{UserID: "u1", Role: UserRoleUser},
+			perm:      PermissionSelfWrite,
+			want:      true,
+		},
+		{
+			name:      "banned role has no permissions",
+			principal: &Principal{UserID: "u1", Role: UserRoleBannedUser},
+			perm:      PermissionSelfWrite,
+			want:      false,
+		},
+		{
+			name:      "api key scope allows",
+			principal: &Principal{UserID: "u1", Role: UserRoleAdmin, APIKeyID: "k1", Scopes: []Permission{PermissionUsersRead}},
+			perm:      PermissionUsersRead,
+			want:      true,
+		},
+		{
+			name:      "api key scope restricts role",
+			principal: &Principal{UserID: "u1", Role: UserRoleAdmin, APIKeyID: "k1", Scopes: []Permission{PermissionUsersRead}},
+			perm:      PermissionUsersWrite,
+			want:      false,
+		},
+		{
+			name:      "api key without self.write",
+			principal: &Principal{UserID: "u1", Role: UserRoleUser, APIKeyID: "k1", Scopes: []Permission{PermissionUsersRead}},
+			perm:      PermissionSelfWrite,
+			want:      false,
+		},
+		{
+			name:      "api key scope does not extend role",
+			principal: &Principal{UserID: "u1", Role: UserRoleUser, APIKeyID: "k1", Scopes: []Permission{PermissionUsersDelete}},
+			perm:      PermissionUsersDelete,
+			want:      false,
+		},
+		{
+			name:      "service uses its scopes",
+			principal: &Principal{Kind: PrincipalKindService, ClientID: "billing", Scopes: []Permission{PermissionSubscriptionsWrite}},
+			perm:      PermissionSubscriptionsWrite,
+			want:      true,
+		},
+		{
+			name:      "service without scope",
+			principal: &Principal{Kind: PrincipalKindService, ClientID: "billing", Scopes: []Permission{PermissionSubscriptionsWrite}},
+			perm:      PermissionUsersRead,
+			want:      false,
+		},
+		{
+			name:      "service ignores role",
+			principal: &Principal{Kind: PrincipalKindService, ClientID: "billing", Role: UserRoleSuperAdmin},
+			perm:      PermissionUsersRead,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.principal.Can(tt.perm); got != tt.want {
+				t.Errorf("Can(%s) = %v, want %v", tt.perm, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserRoleCanManage(t *testing.T) {
+	tests := []struct {
+		role   UserRole
+		target UserRole
+		want   bool
+	}{
+		{UserRoleSuperAdmin, UserRoleSuperAdmin, true},
+		{UserRoleSuperAdmin, UserRoleAdmin, true},
+		{UserRoleAdmin, UserRoleSuperAdmin, false},
+		{UserRoleAdmin, UserRoleAdmin, false},
+		{UserRoleAdmin, UserRoleModerator, true},
+		{UserRoleAdmin, UserRoleUser, true},
+		{UserRoleAdmin, UserRoleBannedUser, true},
+		{UserRoleModerator, UserRoleModerator, false},
+		{UserRoleModerator, UserRoleUser, true},
+		{UserRoleUser, UserRoleUser, false},
+		{UserRoleUser, UserRoleBannedUser, true},
+		{UserRoleBannedUser, UserRoleBannedUser, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"->"+string(tt.target), func(t *testing.T) {
+			if got := tt.role.CanManage(tt.target); got != tt.want {
+				t.Errorf("CanManage = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserRoleCanAssign(t *testing.T) {
+	tests := []struct {
+		role UserRole
+		to   UserRole
+		want bool
+	}{
+		{UserRoleSuperAdmin, UserRoleSuperAdmin, true},
+		{UserRoleSuperAdmin, UserRoleAdmin, true},
+		{UserRoleSuperAdmin, UserRoleModerator, true},
+		{UserRoleSuperAdmin, UserRoleBannedUser, false}, // Роль без ранга назначается баном
+		{UserRoleSuperAdmin, UserRoleUnspecified, false},
+		{UserRoleAdmin, UserRoleSuperAdmin, false},
+		{UserRoleAdmin, UserRoleAdmin, false},
+		{UserRoleAdmin, UserRoleModerator, true},
+		{UserRoleAdmin, UserRoleUser, true},
+		{UserRoleModerator, UserRoleUser, false}, // Нет права roles.assign
+		{UserRoleUser, UserRoleUser, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.role.CanAssign(tt.to); got != tt.want {
+				t.Errorf("CanAssign = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionAllowedForService(t *testing.T) {
+	tests := []struct {
+		perm Permission
+		want bool
+	}{
+		{PermissionUsersRead, true},
+		{PermissionSubscriptionsRead, true},
+		{PermissionSubscriptionsWrite, true},
+		{PermissionLockoutClear, true},
+		{PermissionDenyListManage, true},
+		{PermissionSelfWrite, false},
+		{PermissionUsersWrite, false},
+		{PermissionUsersBan, false},
+		{PermissionRolesAssign, false},
+		{PermissionUsersImpersonate, false},
+		{PermissionReportsModerate, false},
+		{Permission("unknown.perm"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.perm), func(t *testing.T) {
+			if got := tt.perm.AllowedForService(); got != tt.want {
+				t.Errorf("AllowedForService = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
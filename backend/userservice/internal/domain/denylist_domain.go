@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+)
+
+// DenyListKind - тип записи списка запрета
+type DenyListKind string
+
+const (
+	DenyListKindIP          DenyListKind = "IP"           // Адрес или CIDR диапазон
+	DenyListKindEmailDomain DenyListKind = "EMAIL_DOMAIN" // Домен вместе с поддоменами
+	DenyListKindDevice      DenyListKind = "DEVICE"       // UserActivity.DeviceID
+)
+
+// IsValid проверяет, что тип записи известен
+func (k DenyListKind) IsValid() bool {
+	switch k {
+	case DenyListKindIP, DenyListKindEmailDomain, DenyListKindDevice:
+		return true
+	default:
+		return false
+	}
+}
+
+// DenyListEntry - запрет регистрации и входа с адреса, email домена или устройства
+type DenyListEntry struct {
+	ID        string
+	Kind      DenyListKind
+	Value     string // Нормализованное значение: CIDR, домен в нижнем регистре или ID устройства
+	Reason    string
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ExpiresAt *time.Time // nil - бессрочно
+}
+
+// NewDenyListEntry создает запись списка запрета с нормализованным значением
+func NewDenyListEntry(kind DenyListKind, value, reason, createdBy string, expiresAt *time.Time) (*DenyListEntry, error) {
+	normalized, err := NormalizeDenyListValue(kind, value)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &DenyListEntry{
+		ID:        GenerateUUID(),
+		Kind:      kind,
+		Value:     normalized,
+		Reason:    reason,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// IsActive проверяет, что запрет не истек
+func (e *DenyListEntry) IsActive(now time.Time) bool {
+	return e.ExpiresAt == nil || e.ExpiresAt.After(now)
+}
+
+// NormalizeDenyListValue приводит значение к виду, в котором оно хранится.
+// Одиночный IP становится диапазоном /32 или /128.
+func NormalizeDenyListValue(kind DenyListKind, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", NewRequiredFieldError("value")
+	}
+
+	switch kind {
+	case DenyListKindIP:
+		if _, network, err := net.ParseCIDR(value); err == nil {
+			return network.String(), nil
+		}
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return "", NewInvalidFormatError("value", "IP адрес или CIDR диапазон")
+		}
+		if v4 := ip.To4(); v4 != nil {
+			return (&net.IPNet{IP: v4, Mask: net.CIDRMask(32, 32)}).String(), nil
+		}
+		return (&net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}).String(), nil
+	case DenyListKindEmailDomain:
+		domain := normalizeEmailDomain(value)
+		if !strings.Contains(domain, ".") || strings.ContainsAny(domain, "@ ") {
+			return "", NewInvalidFormatError("value", "email домен, например example.com")
+		}
+		return domain, nil
+	case DenyListKindDevice:
+		return value, nil
+	default:
+		return "", ErrInvalidDenyListKind
+	}
+}
+
+// EmailDomain возвращает домен email адреса в нижнем регистре
+func EmailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return normalizeEmailDomain(email[at+1:])
+}
+
+// EmailDomainCandidates возвращает домен и все его родительские домены:
+// запрет example.com действует и на mail.example.com
+func EmailDomainCandidates(domain string) []string {
+	var candidates []string
+	for domain != "" {
+		candidates = append(candidates, domain)
+		dot := strings.Index(domain, ".")
+		if dot < 0 || !strings.Contains(domain[dot+1:], ".") {
+			break
+		}
+		domain = domain[dot+1:]
+	}
+	return candidates
+}
+
+func normalizeEmailDomain(domain string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "@"), ".")
+}
+
+// EmailDomainSet - набор доменов одноразовой почты
+type EmailDomainSet map[string]struct{}
+
+// NewEmailDomainSet строит набор доменов, пустые строки пропускаются
+func NewEmailDomainSet(domains []string) EmailDomainSet {
+	set := make(EmailDomainSet, len(domains))
+	for _, d := range domains {
+		if d = normalizeEmailDomain(d); d != "" {
+			set[d] = struct{}{}
+		}
+	}
+	return set
+}
+
+// Contains проверяет домен и его родительские домены
+func (s EmailDomainSet) Contains(domain string) bool {
+	for _, candidate := range EmailDomainCandidates(domain) {
+		if _, ok := s[candidate]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DenyCheck - данные запроса, которые проверяются по спискам запрета.
+// Пустые поля не проверяются.
+type DenyCheck struct {
+	IP          string
+	EmailDomain string
+	DeviceID    string
+}
+
+// DenyListFilter - фильтр списка запретов
+type DenyListFilter struct {
+	Page           int
+	PageSize       int
+	Kind           DenyListKind
+	IncludeExpired bool
+}
+
+// DenyListRepository определяет хранилище списков запрета
+type DenyListRepository interface {
+	Create(ctx context.Context, entry *DenyListEntry) error
+	FindByID(ctx context.Context, id string) (*DenyListEntry, error)
+	List(ctx context.Context, filter *DenyListFilter) ([]*DenyListEntry, int64, error)
+	Update(ctx context.Context, entry *DenyListEntry) error // Только причина и срок
+	Delete(ctx context.Context, id string) error
+
+	// Match возвращает действующий запрет, под который попадает запрос, или nil
+	Match(ctx context.Context, check *DenyCheck, now time.Time) (*DenyListEntry, error)
+}
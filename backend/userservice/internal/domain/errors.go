@@ -43,7 +43,9 @@ const (
 	ErrCodeInvalidCredentials   = "INVALID_CREDENTIALS"
 	ErrCodeUserBanned           = "USER_BANNED"
 	ErrCodeUserInactive         = "USER_INACTIVE"
+	ErrCodeAccountLocked        = "ACCOUNT_LOCKED"
 	ErrCodeInvalidEmail         = "INVALID_EMAIL"
+	ErrCodeInvalidPhone         = "INVALID_PHONE"
 	ErrCodeInvalidPassword      = "INVALID_PASSWORD"
 	ErrCodeEmailNotVerified     = "EMAIL_NOT_VERIFIED"
 	ErrCodePhoneNotVerified     = "PHONE_NOT_VERIFIED"
@@ -60,7 +62,9 @@ var (
 	ErrInvalidCredentials   = errors.New("invalid credentials")
 	ErrUserBanned           = errors.New("user is banned")
 	ErrUserInactive         = errors.New("user is inactive")
+	ErrAccountLocked        = errors.New("account is temporarily locked")
 	ErrInvalidEmail         = errors.New("invalid email")
+	ErrInvalidPhone         = errors.New("invalid phone")
 	ErrInvalidPassword      = errors.New("invalid password")
 	ErrEmailNotVerified     = errors.New("email not verified")
 	ErrPhoneNotVerified     = errors.New("phone not verified")
@@ -126,13 +130,13 @@ func NewUserBannedError(banInfo *BanInfo) *DomainError {
 	return NewDomainError(ErrCodeUserBanned, msg, nil)
 }
 
-func NewPermissionDeniedError(requiredRole UserRole, actualRole UserRole) *DomainError {
+func NewPermissionDeniedError(required Permission, actualRole UserRole) *DomainError {
 	msg := "Доступ запрещен"
-	if requiredRole != "" {
-		msg = fmt.Sprintf("Требуется роль: %s. Ваша роль: %s", requiredRole, actualRole)
+	if required != "" {
+		msg = fmt.Sprintf("Требуется право: %s. Ваша роль: %s", required, actualRole)
 	}
 
-	return NewDomainError(ErrCodePermissionDenied, msg, nil)
+	return NewDomainError(ErrCodePermissionDenied, msg, ErrPermissionDenied)
 }
 
 // NewSubscriptionNotFoundError создает ошибку ненайденной подписки
@@ -154,6 +158,8 @@ const (
 	ErrCodeInvalidBanReason   = "INVALID_BAN_REASON"
 	ErrCodeSelfBanNotAllowed  = "SELF_BAN_NOT_ALLOWED"
 	ErrCodeAdminBanNotAllowed = "ADMIN_BAN_NOT_ALLOWED"
+	ErrCodeInvalidBanCategory = "INVALID_BAN_CATEGORY"
+	ErrCodeSelfWarnNotAllowed = "SELF_WARNING_NOT_ALLOWED"
 )
 
 // Обертки для ошибок банов
@@ -164,6 +170,8 @@ var (
 	ErrInvalidBanReason   = NewDomainError(ErrCodeInvalidBanReason, "Некорректная причина бана", nil)
 	ErrSelfBanNotAllowed  = NewDomainError(ErrCodeSelfBanNotAllowed, "Нельзя забанить самого себя", nil)
 	ErrAdminBanNotAllowed = NewDomainError(ErrCodeAdminBanNotAllowed, "Нельзя забанить администратора", nil)
+	ErrInvalidBanCategory = NewDomainError(ErrCodeInvalidBanCategory, "Категория бана недоступна", nil)
+	ErrSelfWarnNotAllowed = NewDomainError(ErrCodeSelfWarnNotAllowed, "Нельзя выдать предупреждение самому себе", nil)
 )
 
 // Функции для создания ошибок банов с контекстом
@@ -187,16 +195,17 @@ func NewAdminBanNotAllowedError(adminRole UserRole) *DomainError {
 
 // SubscriptionError коды ошибок подписок
 const (
-	ErrCodeSubscriptionRequired      = "SUBSCRIPTION_REQUIRED"
-	ErrCodeInvalidSubscriptionLevel  = "INVALID_SUBSCRIPTION_LEVEL"
-	ErrCodeSubscriptionExpired       = "SUBSCRIPTION_EXPIRED"
-	ErrCodeTrialExpired              = "TRIAL_EXPIRED"
-	ErrCodePaymentRequired           = "PAYMENT_REQUIRED"
-	ErrCodeInvalidPaymentMethod      = "INVALID_PAYMENT_METHOD"
-	ErrCodeFeatureNotAvailable       = "FEATURE_NOT_AVAILABLE"
-	ErrCodeSubscriptionAlreadyActive = "SUBSCRIPTION_ALREADY_ACTIVE"
-	ErrCodeInvalidAmount             = "INVALID_AMOUNT"
-	ErrCodeInvalidCurrency           = "INVALID_CURRENCY"
+	ErrCodeSubscriptionRequired          = "SUBSCRIPTION_REQUIRED"
+	ErrCodeInvalidSubscriptionLevel      = "INVALID_SUBSCRIPTION_LEVEL"
+	ErrCodeSubscriptionExpired           = "SUBSCRIPTION_EXPIRED"
+	ErrCodeTrialExpired                  = "TRIAL_EXPIRED"
+	ErrCodePaymentRequired               = "PAYMENT_REQUIRED"
+	ErrCodeInvalidPaymentMethod          = "INVALID_PAYMENT_METHOD"
+	ErrCodeFeatureNotAvailable           = "FEATURE_NOT_AVAILABLE"
+	ErrCodeSubscriptionAlreadyActive     = "SUBSCRIPTION_ALREADY_ACTIVE"
+	ErrCodeInvalidAmount                 = "INVALID_AMOUNT"
+	ErrCodeInvalidCurrency               = "INVALID_CURRENCY"
+	ErrCodeInvalidSubscriptionTransition = "INVALID_SUBSCRIPTION_TRANSITION"
 )
 
 // Обертки для ошибок подписок
@@ -231,6 +240,17 @@ func NewFeatureNotAvailableError(feature string, currentLevel SubscriptionLevel)
 	)
 }
 
+func NewInvalidSubscriptionTransitionError(from, to SubscriptionStatus) *DomainError {
+	if from == "" {
+		from = SubscriptionStatusUnspecified
+	}
+	return NewDomainError(
+		ErrCodeInvalidSubscriptionTransition,
+		fmt.Sprintf("Недопустимый переход подписки из статуса '%s' в '%s'", from, to),
+		nil,
+	)
+}
+
 func NewSubscriptionExpiredError(subscriptionEnd string) *DomainError {
 	msg := "Подписка истекла"
 	if subscriptionEnd != "" {
@@ -338,6 +358,8 @@ const (
 	ErrCodeTokenRevoked        = "TOKEN_REVOKED"
 	ErrCodeTokenNotProvided    = "TOKEN_NOT_PROVIDED"
 	ErrCodeRefreshTokenInvalid = "REFRESH_TOKEN_INVALID"
+	ErrCodeResetTokenInvalid   = "RESET_TOKEN_INVALID"
+	ErrCodeVerificationInvalid = "VERIFICATION_TOKEN_INVALID"
 	ErrCodeTokenGeneration     = "TOKEN_GENERATION_FAILED"
 )
 
@@ -348,9 +370,141 @@ var (
 	ErrTokenRevoked        = NewDomainError(ErrCodeTokenRevoked, "Токен отозван", nil)
 	ErrTokenNotProvided    = NewDomainError(ErrCodeTokenNotProvided, "Токен не предоставлен", nil)
 	ErrRefreshTokenInvalid = NewDomainError(ErrCodeRefreshTokenInvalid, "Некорректный refresh токен", nil)
+	ErrResetTokenInvalid   = NewDomainError(ErrCodeResetTokenInvalid, "Ссылка для сброса пароля недействительна или устарела", nil)
+	ErrVerificationInvalid = NewDomainError(ErrCodeVerificationInvalid, "Ссылка подтверждения недействительна или устарела", nil)
 	ErrTokenGeneration     = NewDomainError(ErrCodeTokenGeneration, "Ошибка генерации токена", nil)
 )
 
+// ===== Ошибки подтверждения телефона =====
+
+// PhoneOTPError коды ошибок одноразовых кодов
+const (
+	ErrCodeOTPInvalid           = "OTP_INVALID"
+	ErrCodeOTPAttemptsExceeded  = "OTP_ATTEMPTS_EXCEEDED"
+	ErrCodeOTPTooFrequent       = "OTP_TOO_FREQUENT"
+	ErrCodePhoneAlreadyVerified = "PHONE_ALREADY_VERIFIED"
+)
+
+// Обертки для ошибок одноразовых кодов
+var (
+	ErrOTPInvalid           = NewDomainError(ErrCodeOTPInvalid, "Неверный или устаревший код", nil)
+	ErrOTPAttemptsExceeded  = NewDomainError(ErrCodeOTPAttemptsExceeded, "Превышено число попыток ввода кода", nil)
+	ErrOTPTooFrequent       = NewDomainError(ErrCodeOTPTooFrequent, "Код можно запросить повторно позже", nil)
+	ErrPhoneAlreadyVerified = NewDomainError(ErrCodePhoneAlreadyVerified, "Телефон уже подтвержден", nil)
+)
+
+// ===== Ошибки двухфакторной аутентификации =====
+
+// MFAError коды ошибок 2FA
+const (
+	ErrCodeMFANotEnrolled      = "MFA_NOT_ENROLLED"
+	ErrCodeMFAAlreadyEnabled   = "MFA_ALREADY_ENABLED"
+	ErrCodeMFACodeInvalid      = "MFA_CODE_INVALID"
+	ErrCodeMFAChallengeInvalid = "MFA_CHALLENGE_INVALID"
+)
+
+// Обертки для ошибок 2FA
+var (
+	ErrMFANotEnrolled      = NewDomainError(ErrCodeMFANotEnrolled, "Двухфакторная аутентификация не настроена", nil)
+	ErrMFAAlreadyEnabled   = NewDomainError(ErrCodeMFAAlreadyEnabled, "Двухфакторная аутентификация уже включена", nil)
+	ErrMFACodeInvalid      = NewDomainError(ErrCodeMFACodeInvalid, "Неверный код подтверждения", nil)
+	ErrMFAChallengeInvalid = NewDomainError(ErrCodeMFAChallengeInvalid, "Сессия входа недействительна или устарела", nil)
+)
+
+// ===== Ошибки WebAuthn =====
+
+// WebAuthnError коды ошибок WebAuthn
+const (
+	ErrCodeWebAuthnSessionInvalid     = "WEBAUTHN_SESSION_INVALID"
+	ErrCodeWebAuthnVerificationFailed = "WEBAUTHN_VERIFICATION_FAILED"
+	ErrCodeWebAuthnCredentialNotFound = "WEBAUTHN_CREDENTIAL_NOT_FOUND"
+	ErrCodeWebAuthnCredentialExists   = "WEBAUTHN_CREDENTIAL_EXISTS"
+	ErrCodeWebAuthnNoCredentials      = "WEBAUTHN_NO_CREDENTIALS"
+)
+
+// Обертки для ошибок WebAuthn
+var (
+	ErrWebAuthnSessionInvalid     = NewDomainError(ErrCodeWebAuthnSessionInvalid, "Сессия WebAuthn недействительна или устарела", nil)
+	ErrWebAuthnVerificationFailed = NewDomainError(ErrCodeWebAuthnVerificationFailed, "Не удалось проверить ответ аутентификатора", nil)
+	ErrWebAuthnCredentialNotFound = NewDomainError(ErrCodeWebAuthnCredentialNotFound, "Ключ не найден", nil)
+	ErrWebAuthnCredentialExists   = NewDomainError(ErrCodeWebAuthnCredentialExists, "Ключ уже зарегистрирован", nil)
+	ErrWebAuthnNoCredentials      = NewDomainError(ErrCodeWebAuthnNoCredentials, "У пользователя нет зарегистрированных ключей", nil)
+)
+
+// ===== Ошибки организаций =====
+
+// OrganizationError коды ошибок организаций
+const (
+	ErrCodeOrganizationNotFound      = "ORGANIZATION_NOT_FOUND"
+	ErrCodeOrganizationSlugTaken     = "ORGANIZATION_SLUG_TAKEN"
+	ErrCodeNotOrganizationMember     = "NOT_ORGANIZATION_MEMBER"
+	ErrCodeAlreadyOrganizationMember = "ALREADY_ORGANIZATION_MEMBER"
+	ErrCodeLastOrganizationOwner     = "LAST_ORGANIZATION_OWNER"
+	ErrCodeInvalidOrgRole            = "INVALID_ORG_ROLE"
+	ErrCodeInvitationNotFound        = "INVITATION_NOT_FOUND"
+	ErrCodeInvitationExpired         = "INVITATION_EXPIRED"
+)
+
+// Обертки для ошибок организаций
+var (
+	ErrOrganizationNotFound      = NewDomainError(ErrCodeOrganizationNotFound, "Организация не найдена", nil)
+	ErrOrganizationSlugTaken     = NewDomainError(ErrCodeOrganizationSlugTaken, "Короткое имя организации уже занято", nil)
+	ErrNotOrganizationMember     = NewDomainError(ErrCodeNotOrganizationMember, "Пользователь не состоит в организации", nil)
+	ErrAlreadyOrganizationMember = NewDomainError(ErrCodeAlreadyOrganizationMember, "Пользователь уже состоит в организации", nil)
+	ErrLastOrganizationOwner     = NewDomainError(ErrCodeLastOrganizationOwner, "Нельзя убрать последнего владельца организации", nil)
+	ErrInvalidOrgRole            = NewDomainError(ErrCodeInvalidOrgRole, "Некорректная роль в организации", nil)
+	ErrInvitationNotFound        = NewDomainError(ErrCodeInvitationNotFound, "Приглашение не найдено или уже обработано", nil)
+	ErrInvitationExpired         = NewDomainError(ErrCodeInvitationExpired, "Срок действия приглашения истек", nil)
+)
+
+// ===== Ошибки API ключей =====
+
+// APIKeyError коды ошибок API ключей
+const (
+	ErrCodeAPIKeyNotFound       = "API_KEY_NOT_FOUND"
+	ErrCodeAPIKeyLimitReached   = "API_KEY_LIMIT_REACHED"
+	ErrCodeSessionTokenRequired = "SESSION_TOKEN_REQUIRED"
+)
+
+// Обертки для ошибок API ключей
+var (
+	ErrAPIKeyNotFound       = NewDomainError(ErrCodeAPIKeyNotFound, "API ключ не найден", nil)
+	ErrAPIKeyLimitReached   = NewDomainError(ErrCodeAPIKeyLimitReached, "Достигнут лимит API ключей", nil)
+	ErrSessionTokenRequired = NewDomainError(ErrCodeSessionTokenRequired, "Операция недоступна по API ключу, нужен вход по паролю", nil)
+)
+
+// ===== Ошибки сервисных аккаунтов =====
+
+// ServiceAccountError коды ошибок сервисных аккаунтов
+const (
+	ErrCodeServiceAccountNotFound   = "SERVICE_ACCOUNT_NOT_FOUND"
+	ErrCodeInvalidClientCredentials = "INVALID_CLIENT_CREDENTIALS"
+	ErrCodeAudienceNotAllowed       = "AUDIENCE_NOT_ALLOWED"
+)
+
+// Обертки для ошибок сервисных аккаунтов
+var (
+	ErrServiceAccountNotFound   = NewDomainError(ErrCodeServiceAccountNotFound, "Сервисный аккаунт не найден", nil)
+	ErrInvalidClientCredentials = NewDomainError(ErrCodeInvalidClientCredentials, "Неверный client_id или client_secret", nil)
+	ErrAudienceNotAllowed       = NewDomainError(ErrCodeAudienceNotAllowed, "Сервису не разрешен токен для этого audience", nil)
+)
+
+// ===== Ошибки имперсонации =====
+
+// ImpersonationError коды ошибок имперсонации
+const (
+	ErrCodeImpersonationForbidden  = "IMPERSONATION_FORBIDDEN"
+	ErrCodeImpersonationNotAllowed = "IMPERSONATION_NOT_ALLOWED"
+	ErrCodeNotImpersonating        = "NOT_IMPERSONATING"
+)
+
+// Обертки для ошибок имперсонации
+var (
+	ErrImpersonationForbidden  = NewDomainError(ErrCodeImpersonationForbidden, "Операция недоступна при входе от имени пользователя", nil)
+	ErrImpersonationNotAllowed = NewDomainError(ErrCodeImpersonationNotAllowed, "Нельзя войти от имени этого пользователя", nil)
+	ErrNotImpersonating        = NewDomainError(ErrCodeNotImpersonating, "Токен выпущен не для входа от имени пользователя", nil)
+)
+
 // ===== Ошибки базы данных =====
 
 // DatabaseError коды ошибок базы данных
@@ -387,3 +541,63 @@ func NewDBConstraintError(constraint string, cause error) *DomainError {
 		cause,
 	)
 }
+
+// ===== Ошибки апелляций =====
+
+// AppealError коды ошибок апелляций на баны
+const (
+	ErrCodeAppealNotFound         = "APPEAL_NOT_FOUND"
+	ErrCodeAppealAlreadySubmitted = "APPEAL_ALREADY_SUBMITTED"
+	ErrCodeAppealClosed           = "APPEAL_CLOSED"
+	ErrCodeAppealTokenInvalid     = "APPEAL_TOKEN_INVALID"
+)
+
+// Обертки для ошибок апелляций
+var (
+	ErrAppealNotFound         = NewDomainError(ErrCodeAppealNotFound, "Апелляция не найдена", nil)
+	ErrAppealAlreadySubmitted = NewDomainError(ErrCodeAppealAlreadySubmitted, "Апелляция на этот бан уже подана", nil)
+	ErrAppealClosed           = NewDomainError(ErrCodeAppealClosed, "По апелляции уже принято решение", nil)
+	ErrAppealTokenInvalid     = NewDomainError(ErrCodeAppealTokenInvalid, "Токен апелляции недействителен или истек", nil)
+)
+
+// ===== Ошибки жалоб =====
+
+// ReportError коды ошибок жалоб на пользователей
+const (
+	ErrCodeReportNotFound          = "REPORT_NOT_FOUND"
+	ErrCodeReportClosed            = "REPORT_CLOSED"
+	ErrCodeReportNotClaimed        = "REPORT_NOT_CLAIMED"
+	ErrCodeSelfReportNotAllowed    = "SELF_REPORT_NOT_ALLOWED"
+	ErrCodeInvalidReportCategory   = "INVALID_REPORT_CATEGORY"
+	ErrCodeInvalidReportResolution = "INVALID_REPORT_RESOLUTION"
+)
+
+// Обертки для ошибок жалоб
+var (
+	ErrReportNotFound          = NewDomainError(ErrCodeReportNotFound, "Жалоба не найдена", nil)
+	ErrReportClosed            = NewDomainError(ErrCodeReportClosed, "Жалоба закрыта или взята другим модератором", nil)
+	ErrReportNotClaimed        = NewDomainError(ErrCodeReportNotClaimed, "Сначала возьмите жалобу в работу", nil)
+	ErrSelfReportNotAllowed    = NewDomainError(ErrCodeSelfReportNotAllowed, "Нельзя пожаловаться на самого себя", nil)
+	ErrInvalidReportCategory   = NewDomainError(ErrCodeInvalidReportCategory, "Некорректная категория жалобы", nil)
+	ErrInvalidReportResolution = NewDomainError(ErrCodeInvalidReportResolution, "Некорректное решение по жалобе", nil)
+)
+
+// ===== Ошибки списков запрета =====
+
+// DenyListError коды ошибок списков запрета
+const (
+	ErrCodeDenyListEntryNotFound = "DENY_LIST_ENTRY_NOT_FOUND"
+	ErrCodeDenyListEntryExists   = "DENY_LIST_ENTRY_EXISTS"
+	ErrCodeInvalidDenyListKind   = "INVALID_DENY_LIST_KIND"
+	ErrCodeDeniedByDenyList      = "DENIED_BY_DENY_LIST"
+	ErrCodeDisposableEmailDomain = "DISPOSABLE_EMAIL_DOMAIN"
+)
+
+// Обертки для ошибок списков запрета
+var (
+	ErrDenyListEntryNotFound = NewDomainError(ErrCodeDenyListEntryNotFound, "Запись списка запрета не найдена", nil)
+	ErrDenyListEntryExists   = NewDomainError(ErrCodeDenyListEntryExists, "Такая запись уже есть в списке запрета", nil)
+	ErrInvalidDenyListKind   = NewDomainError(ErrCodeInvalidDenyListKind, "Некорректный тип записи списка запрета", nil)
+	ErrDeniedByDenyList      = NewDomainError(ErrCodeDeniedByDenyList, "Регистрация и вход с этого адреса, почты или устройства запрещены", nil)
+	ErrDisposableEmailDomain = NewDomainError(ErrCodeDisposableEmailDomain, "Регистрация на одноразовую почту запрещена", nil)
+)
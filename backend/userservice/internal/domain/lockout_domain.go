@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Ключи счетчиков неудачных входов
+const (
+	LockoutKeyAccountPrefix = "account:"
+	LockoutKeyIPPrefix      = "ip:"
+	LockoutKeyMFAPrefix     = "mfa:"
+)
+
+// AccountLockoutKey возвращает ключ счетчика для email
+func AccountLockoutKey(email string) string {
+	return LockoutKeyAccountPrefix + strings.ToLower(strings.TrimSpace(email))
+}
+
+// IPLockoutKey возвращает ключ счетчика для IP адреса
+func IPLockoutKey(ip string) string {
+	return LockoutKeyIPPrefix + ip
+}
+
+// MFALockoutKey возвращает ключ счетчика неверных кодов второго фактора
+func MFALockoutKey(userID string) string {
+	return LockoutKeyMFAPrefix + userID
+}
+
+// LockoutDuration вычисляет длительность блокировки: base, 2*base, 4*base...
+// level - номер блокировки подряд, начиная с 1. Результат не превышает max.
+func LockoutDuration(base, max time.Duration, level int) time.Duration {
+	d := base
+	for i := 1; i < level && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// LoginAttemptStore определяет хранилище счетчиков неудачных входов и блокировок.
+// Ключ - аккаунт, IP адрес или второй фактор пользователя
+// (см. AccountLockoutKey, IPLockoutKey, MFALockoutKey).
+type LoginAttemptStore interface {
+	IncrementFailures(ctx context.Context, key string, window time.Duration) (int, error) // Счетчик живет window с первой ошибки
+	ResetFailures(ctx context.Context, key string) error
+
+	IncrementLockouts(ctx context.Context, key string, ttl time.Duration) (int, error) // Номер блокировки подряд
+	Lock(ctx context.Context, key string, until time.Time) error
+	LockedUntil(ctx context.Context, key string) (time.Time, error) // Нулевое время, если блокировки нет
+
+	Clear(ctx context.Context, key string) error // Снимает блокировку и сбрасывает все счетчики
+}
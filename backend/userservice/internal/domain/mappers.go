@@ -26,6 +26,10 @@ func (u *User) ToProto() *users.User {
 		protoUser.LastLoginAt = timestamppb.New(*u.LastLoginAt)
 	}
 
+	if u.PhoneVerifiedAt != nil {
+		protoUser.PhoneVerifiedAt = timestamppb.New(*u.PhoneVerifiedAt)
+	}
+
 	if u.BanInfo != nil {
 		protoUser.BanInfo = u.BanInfo.ToProto()
 	}
@@ -44,6 +48,8 @@ func (b *BanInfo) ToProto() *users.BanInfo {
 		BannedAt: timestamppb.New(b.BannedAt),
 		Reason:   b.Reason,
 		BannedBy: b.BannedBy,
+		Category: BanCategoryToProto(b.Category),
+		ReportId: b.ReportID,
 	}
 
 	if b.BannedUntil != nil {
@@ -53,6 +59,113 @@ func (b *BanInfo) ToProto() *users.BanInfo {
 	return protoBan
 }
 
+// ToProto преобразует BanCategoryStats в protobuf BanCategoryStats
+func (s *BanCategoryStats) ToProto() *users.BanCategoryStats {
+	return &users.BanCategoryStats{
+		Category:  BanCategoryToProto(s.Category),
+		Active:    s.Active,
+		Temporary: s.Temporary,
+		Permanent: s.Permanent,
+	}
+}
+
+// ToProto преобразует Strike в protobuf Strike
+func (s *Strike) ToProto() *users.Strike {
+	return &users.Strike{
+		Id:        s.ID,
+		UserId:    s.UserID,
+		Reason:    s.Reason,
+		IssuedBy:  s.IssuedBy,
+		IssuedAt:  timestamppb.New(s.IssuedAt),
+		ExpiresAt: timestamppb.New(s.ExpiresAt),
+	}
+}
+
+// ToProto преобразует BanAppeal в protobuf BanAppeal
+func (a *BanAppeal) ToProto() *users.BanAppeal {
+	protoAppeal := &users.BanAppeal{
+		Id:         a.ID,
+		UserId:     a.UserID,
+		BannedAt:   timestamppb.New(a.BannedAt),
+		Message:    a.Message,
+		Status:     AppealStatusToProto(a.Status),
+		AssignedTo: a.AssignedTo,
+		ResolvedBy: a.ResolvedBy,
+		Resolution: a.Resolution,
+		CreatedAt:  timestamppb.New(a.CreatedAt),
+		UpdatedAt:  timestamppb.New(a.UpdatedAt),
+	}
+
+	if a.ResolvedAt != nil {
+		protoAppeal.ResolvedAt = timestamppb.New(*a.ResolvedAt)
+	}
+
+	return protoAppeal
+}
+
+// ToProto преобразует UserReport в protobuf UserReport
+func (r *UserReport) ToProto() *users.UserReport {
+	protoReport := &users.UserReport{
+		Id:             r.ID,
+		ReportedUserId: r.ReportedUserID,
+		Category:       ReportCategoryToProto(r.Category),
+		Status:         ReportStatusToProto(r.Status),
+		ReportCount:    int32(r.ReportCount),
+		Priority:       int32(r.Priority),
+		ClaimedBy:      r.ClaimedBy,
+		CreatedAt:      timestamppb.New(r.CreatedAt),
+		UpdatedAt:      timestamppb.New(r.UpdatedAt),
+		Action:         ReportActionToProto(r.Action),
+		Resolution:     r.Resolution,
+		ResolvedBy:     r.ResolvedBy,
+		StrikeId:       r.StrikeID,
+	}
+
+	if r.ClaimedAt != nil {
+		protoReport.ClaimedAt = timestamppb.New(*r.ClaimedAt)
+	}
+	if r.ResolvedAt != nil {
+		protoReport.ResolvedAt = timestamppb.New(*r.ResolvedAt)
+	}
+	if r.BannedAt != nil {
+		protoReport.BannedAt = timestamppb.New(*r.BannedAt)
+	}
+	for _, entry := range r.Entries {
+		protoReport.Entries = append(protoReport.Entries, entry.ToProto())
+	}
+
+	return protoReport
+}
+
+// ToProto преобразует ReportEntry в protobuf ReportEntry
+func (e *ReportEntry) ToProto() *users.ReportEntry {
+	return &users.ReportEntry{
+		ReporterId:  e.ReporterID,
+		Description: e.Description,
+		Evidence:    e.Evidence,
+		CreatedAt:   timestamppb.New(e.CreatedAt),
+	}
+}
+
+// ToProto преобразует DenyListEntry в protobuf DenyListEntry
+func (e *DenyListEntry) ToProto() *users.DenyListEntry {
+	protoEntry := &users.DenyListEntry{
+		Id:        e.ID,
+		Kind:      DenyListKindToProto(e.Kind),
+		Value:     e.Value,
+		Reason:    e.Reason,
+		CreatedBy: e.CreatedBy,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		UpdatedAt: timestamppb.New(e.UpdatedAt),
+	}
+
+	if e.ExpiresAt != nil {
+		protoEntry.ExpiresAt = timestamppb.New(*e.ExpiresAt)
+	}
+
+	return protoEntry
+}
+
 // ToProto преобразует SubscriptionInfo в protobuf SubscriptionInfo
 func (s *SubscriptionInfo) ToProto() *users.SubscriptionInfo {
 	protoSub := &users.SubscriptionInfo{
@@ -91,12 +204,91 @@ func (s *SubscriptionInfo) ToProto() *users.SubscriptionInfo {
 	return protoSub
 }
 
+// ToProto преобразует WebAuthnCredential в protobuf WebAuthnCredential
+func (c *WebAuthnCredential) ToProto() *users.WebAuthnCredential {
+	protoCred := &users.WebAuthnCredential{
+		Id:             c.ID,
+		Name:           c.Name,
+		Transports:     c.Transports,
+		BackupEligible: c.BackupEligible,
+		SignCount:      c.SignCount,
+		CreatedAt:      timestamppb.New(c.CreatedAt),
+	}
+
+	if c.LastUsedAt != nil {
+		protoCred.LastUsedAt = timestamppb.New(*c.LastUsedAt)
+	}
+
+	return protoCred
+}
+
+// ToProto преобразует APIKey в protobuf APIKey. Хеш ключа наружу не отдается.
+func (k *APIKey) ToProto() *users.APIKey {
+	protoKey := &users.APIKey{
+		Id:        k.ID,
+		Name:      k.Name,
+		Prefix:    APIKeyPrefix + k.Prefix,
+		Scopes:    make([]string, 0, len(k.Scopes)),
+		CreatedAt: timestamppb.New(k.CreatedAt),
+	}
+
+	for _, scope := range k.Scopes {
+		protoKey.Scopes = append(protoKey.Scopes, string(scope))
+	}
+
+	if k.ExpiresAt != nil {
+		protoKey.ExpiresAt = timestamppb.New(*k.ExpiresAt)
+	}
+
+	if k.LastUsedAt != nil {
+		protoKey.LastUsedAt = timestamppb.New(*k.LastUsedAt)
+	}
+
+	return protoKey
+}
+
+// ToProto преобразует Organization в protobuf Organization
+func (o *Organization) ToProto() *users.Organization {
+	return &users.Organization{
+		Id:        o.ID,
+		Name:      o.Name,
+		Slug:      o.Slug,
+		OwnerId:   o.OwnerID,
+		CreatedAt: timestamppb.New(o.CreatedAt),
+		UpdatedAt: timestamppb.New(o.UpdatedAt),
+	}
+}
+
+// ToProto преобразует OrgMember в protobuf OrganizationMember
+func (m *OrgMember) ToProto() *users.OrganizationMember {
+	return &users.OrganizationMember{
+		OrganizationId: m.OrganizationID,
+		UserId:         m.UserID,
+		Role:           OrgRoleToProto(m.Role),
+		JoinedAt:       timestamppb.New(m.JoinedAt),
+	}
+}
+
+// ToProto преобразует OrgInvitation в protobuf OrganizationInvitation
+func (i *OrgInvitation) ToProto() *users.OrganizationInvitation {
+	return &users.OrganizationInvitation{
+		Id:             i.ID,
+		OrganizationId: i.OrganizationID,
+		Email:          i.Email,
+		Role:           OrgRoleToProto(i.Role),
+		InvitedBy:      i.InvitedBy,
+		Status:         InvitationStatusToProto(i.Status),
+		ExpiresAt:      timestamppb.New(i.ExpiresAt),
+		CreatedAt:      timestamppb.New(i.CreatedAt),
+	}
+}
+
 // CreateUserRequestFromProto преобразует protobuf CreateUserRequest в доменную модель
 func CreateUserRequestFromProto(req *users.CreateUserRequest) *User {
 	user := &User{
 		Name:         req.GetName(),
 		Email:        req.GetEmail(),
-		Password:     req.GetPassword(), // Уже хешированный
+		Password:     req.GetPassword(), // Открытый пароль, хешируется в сервисе
 		Phone:        req.GetPhone(),
 		ServiceEmail: req.GetServiceEmail(),
 		Role:         UserRoleFromProto(req.GetRole()),
@@ -116,7 +308,7 @@ func (u *User) UpdateFromProto(req *users.UpdateUserRequest) {
 	}
 
 	if req.GetPassword() != "" {
-		u.Password = req.GetPassword() // Уже хешированный
+		u.Password = req.GetPassword() // Открытый пароль, хешируется в сервисе
 	}
 
 	if req.GetPhone() != "" {
@@ -146,6 +338,40 @@ func (u *User) UpdateFromProto(req *users.UpdateUserRequest) {
 	}
 }
 
+// SubscriptionUpdateFromProto собирает изменения подписки из запроса.
+// В изменения попадают только переданные поля, UNSPECIFIED не меняет уровень и статус.
+func SubscriptionUpdateFromProto(req *users.UpdateSubscriptionRequest) *SubscriptionUpdate {
+	update := &SubscriptionUpdate{
+		SubscriptionID: req.SubscriptionId,
+		PaymentMethod:  req.PaymentMethod,
+		AutoRenew:      req.AutoRenew,
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+	}
+
+	if req.Level != nil && req.GetLevel() != users.SubscriptionLevel_SUBSCRIPTION_LEVEL_UNSPECIFIED {
+		level := SubscriptionLevelFromProto(req.GetLevel())
+		update.Level = &level
+	}
+
+	if req.Status != nil && req.GetStatus() != users.SubscriptionStatus_SUBSCRIPTION_STATUS_UNSPECIFIED {
+		status := SubscriptionStatusFromProto(req.GetStatus())
+		update.Status = &status
+	}
+
+	if req.SubscriptionEnd != nil {
+		end := req.GetSubscriptionEnd().AsTime()
+		update.SubscriptionEnd = &end
+	}
+
+	if req.TrialEnd != nil {
+		trialEnd := req.GetTrialEnd().AsTime()
+		update.TrialEnd = &trialEnd
+	}
+
+	return update
+}
+
 // ListUsersResponseToProto преобразует доменные данные в protobuf ListUsersResponse
 func ListUsersResponseToProto(userses []*User, total int64, page, pageSize int32) *users.ListUsersResponse {
 	var protoUsers []*users.User
@@ -382,3 +608,236 @@ func SubscriptionLevelFromProto(protoLevel users.SubscriptionLevel) Subscription
 		return SubscriptionLevelUnspecified
 	}
 }
+
+// OrgRoleToProto преобразует доменный OrgRole в protobuf
+func OrgRoleToProto(role OrgRole) users.OrganizationRole {
+	switch role {
+	case OrgRoleOwner:
+		return users.OrganizationRole_ORGANIZATION_ROLE_OWNER
+	case OrgRoleAdmin:
+		return users.OrganizationRole_ORGANIZATION_ROLE_ADMIN
+	case OrgRoleMember:
+		return users.OrganizationRole_ORGANIZATION_ROLE_MEMBER
+	default:
+		return users.OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED
+	}
+}
+
+// OrgRoleFromProto преобразует protobuf OrganizationRole в доменный.
+// Для UNSPECIFIED возвращает пустую роль.
+func OrgRoleFromProto(protoRole users.OrganizationRole) OrgRole {
+	switch protoRole {
+	case users.OrganizationRole_ORGANIZATION_ROLE_OWNER:
+		return OrgRoleOwner
+	case users.OrganizationRole_ORGANIZATION_ROLE_ADMIN:
+		return OrgRoleAdmin
+	case users.OrganizationRole_ORGANIZATION_ROLE_MEMBER:
+		return OrgRoleMember
+	default:
+		return ""
+	}
+}
+
+// InvitationStatusToProto преобразует доменный InvitationStatus в protobuf
+func InvitationStatusToProto(status InvitationStatus) users.InvitationStatus {
+	switch status {
+	case InvitationStatusPending:
+		return users.InvitationStatus_INVITATION_STATUS_PENDING
+	case InvitationStatusAccepted:
+		return users.InvitationStatus_INVITATION_STATUS_ACCEPTED
+	case InvitationStatusDeclined:
+		return users.InvitationStatus_INVITATION_STATUS_DECLINED
+	default:
+		return users.InvitationStatus_INVITATION_STATUS_UNSPECIFIED
+	}
+}
+
+// BanCategoryToProto преобразует доменную BanCategory в protobuf
+func BanCategoryToProto(category BanCategory) users.BanCategory {
+	switch category {
+	case BanCategoryAdmin:
+		return users.BanCategory_BAN_CATEGORY_ADMIN
+	case BanCategorySystem:
+		return users.BanCategory_BAN_CATEGORY_SYSTEM
+	case BanCategorySpam:
+		return users.BanCategory_BAN_CATEGORY_SPAM
+	case BanCategoryAbuse:
+		return users.BanCategory_BAN_CATEGORY_ABUSE
+	case BanCategoryFraud:
+		return users.BanCategory_BAN_CATEGORY_FRAUD
+	default:
+		return users.BanCategory_BAN_CATEGORY_UNSPECIFIED
+	}
+}
+
+// BanCategoryFromProto преобразует protobuf BanCategory в доменную.
+// Для UNSPECIFIED возвращает пустую категорию.
+func BanCategoryFromProto(protoCategory users.BanCategory) BanCategory {
+	switch protoCategory {
+	case users.BanCategory_BAN_CATEGORY_ADMIN:
+		return BanCategoryAdmin
+	case users.BanCategory_BAN_CATEGORY_SYSTEM:
+		return BanCategorySystem
+	case users.BanCategory_BAN_CATEGORY_SPAM:
+		return BanCategorySpam
+	case users.BanCategory_BAN_CATEGORY_ABUSE:
+		return BanCategoryAbuse
+	case users.BanCategory_BAN_CATEGORY_FRAUD:
+		return BanCategoryFraud
+	default:
+		return BanCategoryUnspecified
+	}
+}
+
+// AppealStatusToProto преобразует доменный AppealStatus в protobuf
+func AppealStatusToProto(status AppealStatus) users.AppealStatus {
+	switch status {
+	case AppealStatusPending:
+		return users.AppealStatus_APPEAL_STATUS_PENDING
+	case AppealStatusInReview:
+		return users.AppealStatus_APPEAL_STATUS_IN_REVIEW
+	case AppealStatusApproved:
+		return users.AppealStatus_APPEAL_STATUS_APPROVED
+	case AppealStatusRejected:
+		return users.AppealStatus_APPEAL_STATUS_REJECTED
+	default:
+		return users.AppealStatus_APPEAL_STATUS_UNSPECIFIED
+	}
+}
+
+// AppealStatusFromProto преобразует protobuf AppealStatus в доменный.
+// Для UNSPECIFIED возвращает пустой статус.
+func AppealStatusFromProto(protoStatus users.AppealStatus) AppealStatus {
+	switch protoStatus {
+	case users.AppealStatus_APPEAL_STATUS_PENDING:
+		return AppealStatusPending
+	case users.AppealStatus_APPEAL_STATUS_IN_REVIEW:
+		return AppealStatusInReview
+	case users.AppealStatus_APPEAL_STATUS_APPROVED:
+		return AppealStatusApproved
+	case users.AppealStatus_APPEAL_STATUS_REJECTED:
+		return AppealStatusRejected
+	default:
+		return ""
+	}
+}
+
+// ReportCategoryToProto преобразует доменный ReportCategory в protobuf
+func ReportCategoryToProto(category ReportCategory) users.ReportCategory {
+	switch category {
+	case ReportCategorySpam:
+		return users.ReportCategory_REPORT_CATEGORY_SPAM
+	case ReportCategoryAbuse:
+		return users.ReportCategory_REPORT_CATEGORY_ABUSE
+	case ReportCategoryFraud:
+		return users.ReportCategory_REPORT_CATEGORY_FRAUD
+	case ReportCategoryOther:
+		return users.ReportCategory_REPORT_CATEGORY_OTHER
+	default:
+		return users.ReportCategory_REPORT_CATEGORY_UNSPECIFIED
+	}
+}
+
+// ReportCategoryFromProto преобразует protobuf ReportCategory в доменный.
+// Для UNSPECIFIED возвращает пустую категорию.
+func ReportCategoryFromProto(protoCategory users.ReportCategory) ReportCategory {
+	switch protoCategory {
+	case users.ReportCategory_REPORT_CATEGORY_SPAM:
+		return ReportCategorySpam
+	case users.ReportCategory_REPORT_CATEGORY_ABUSE:
+		return ReportCategoryAbuse
+	case users.ReportCategory_REPORT_CATEGORY_FRAUD:
+		return ReportCategoryFraud
+	case users.ReportCategory_REPORT_CATEGORY_OTHER:
+		return ReportCategoryOther
+	default:
+		return ""
+	}
+}
+
+// ReportStatusToProto преобразует доменный ReportStatus в protobuf
+func ReportStatusToProto(status ReportStatus) users.ReportStatus {
+	switch status {
+	case ReportStatusOpen:
+		return users.ReportStatus_REPORT_STATUS_OPEN
+	case ReportStatusClaimed:
+		return users.ReportStatus_REPORT_STATUS_CLAIMED
+	case ReportStatusResolved:
+		return users.ReportStatus_REPORT_STATUS_RESOLVED
+	default:
+		return users.ReportStatus_REPORT_STATUS_UNSPECIFIED
+	}
+}
+
+// ReportStatusFromProto преобразует protobuf ReportStatus в доменный.
+// Для UNSPECIFIED возвращает пустой статус.
+func ReportStatusFromProto(protoStatus users.ReportStatus) ReportStatus {
+	switch protoStatus {
+	case users.ReportStatus_REPORT_STATUS_OPEN:
+		return ReportStatusOpen
+	case users.ReportStatus_REPORT_STATUS_CLAIMED:
+		return ReportStatusClaimed
+	case users.ReportStatus_REPORT_STATUS_RESOLVED:
+		return ReportStatusResolved
+	default:
+		return ""
+	}
+}
+
+// ReportActionToProto преобразует доменный ReportAction в protobuf
+func ReportActionToProto(action ReportAction) users.ReportAction {
+	switch action {
+	case ReportActionDismiss:
+		return users.ReportAction_REPORT_ACTION_DISMISS
+	case ReportActionWarn:
+		return users.ReportAction_REPORT_ACTION_WARN
+	case ReportActionBan:
+		return users.ReportAction_REPORT_ACTION_BAN
+	default:
+		return users.ReportAction_REPORT_ACTION_UNSPECIFIED
+	}
+}
+
+// ReportActionFromProto преобразует protobuf ReportAction в доменный.
+// Для UNSPECIFIED возвращает пустое решение.
+func ReportActionFromProto(protoAction users.ReportAction) ReportAction {
+	switch protoAction {
+	case users.ReportAction_REPORT_ACTION_DISMISS:
+		return ReportActionDismiss
+	case users.ReportAction_REPORT_ACTION_WARN:
+		return ReportActionWarn
+	case users.ReportAction_REPORT_ACTION_BAN:
+		return ReportActionBan
+	default:
+		return ""
+	}
+}
+
+// DenyListKindToProto преобразует доменный DenyListKind в protobuf
+func DenyListKindToProto(kind DenyListKind) users.DenyListKind {
+	switch kind {
+	case DenyListKindIP:
+		return users.DenyListKind_DENY_LIST_KIND_IP
+	case DenyListKindEmailDomain:
+		return users.DenyListKind_DENY_LIST_KIND_EMAIL_DOMAIN
+	case DenyListKindDevice:
+		return users.DenyListKind_DENY_LIST_KIND_DEVICE
+	default:
+		return users.DenyListKind_DENY_LIST_KIND_UNSPECIFIED
+	}
+}
+
+// DenyListKindFromProto преобразует protobuf DenyListKind в доменный.
+// Для UNSPECIFIED возвращает пустой тип.
+func DenyListKindFromProto(protoKind users.DenyListKind) DenyListKind {
+	switch protoKind {
+	case users.DenyListKind_DENY_LIST_KIND_IP:
+		return DenyListKindIP
+	case users.DenyListKind_DENY_LIST_KIND_EMAIL_DOMAIN:
+		return DenyListKindEmailDomain
+	case users.DenyListKind_DENY_LIST_KIND_DEVICE:
+		return DenyListKindDevice
+	default:
+		return ""
+	}
+}
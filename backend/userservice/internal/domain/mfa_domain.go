@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TOTPCredential - настройки двухфакторной аутентификации по TOTP
+type TOTPCredential struct {
+	UserID          string
+	SecretEncrypted string     // Секрет, зашифрованный ключом сервиса
+	ConfirmedAt     *time.Time // nil, пока пользователь не ввел первый код
+	LastUsedStep    int64      // Шаг последнего принятого кода, защита от повторного ввода
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// IsConfirmed проверяет, завершена ли настройка 2FA
+func (c *TOTPCredential) IsConfirmed() bool {
+	return c.ConfirmedAt != nil
+}
+
+// MFARepository определяет хранилище настроек 2FA и кодов восстановления
+type MFARepository interface {
+	// SaveTOTP создает или заменяет неподтвержденный секрет пользователя
+	SaveTOTP(ctx context.Context, cred *TOTPCredential) error
+	FindTOTP(ctx context.Context, userID string) (*TOTPCredential, error)
+	ConfirmTOTP(ctx context.Context, userID string, step int64) error
+	DeleteTOTP(ctx context.Context, userID string) error
+
+	// UseStep атомарно запоминает шаг принятого кода.
+	// Возвращает false, если код этого или более позднего шага уже использовался.
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+
+	// ReplaceRecoveryCodes заменяет все коды восстановления пользователя
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+
+	// UseRecoveryCode атомарно помечает код восстановления использованным.
+	// Возвращает false, если кода нет или он уже использован.
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+}
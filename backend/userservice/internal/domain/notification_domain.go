@@ -0,0 +1,26 @@
+package domain
+
+import "context"
+
+// EmailMessage - письмо пользователю
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer определяет интерфейс отправки писем
+type Mailer interface {
+	Send(ctx context.Context, msg *EmailMessage) error
+}
+
+// SMSMessage - SMS сообщение пользователю
+type SMSMessage struct {
+	To   string // Номер в формате E.164
+	Body string
+}
+
+// SMSSender определяет интерфейс отправки SMS
+type SMSSender interface {
+	Send(ctx context.Context, msg *SMSMessage) error
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Organization - организация (тенант), объединяющая пользователей
+type Organization struct {
+	ID        string
+	Name      string
+	Slug      string // Уникальное короткое имя для URL
+	OwnerID   string // Создатель организации
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OrgRole - роль пользователя внутри организации
+type OrgRole string
+
+const (
+	OrgRoleOwner  OrgRole = "OWNER"
+	OrgRoleAdmin  OrgRole = "ADMIN"
+	OrgRoleMember OrgRole = "MEMBER"
+)
+
+// OrgMember - членство пользователя в организации
+type OrgMember struct {
+	OrganizationID string
+	UserID         string
+	Role           OrgRole
+	JoinedAt       time.Time
+}
+
+// InvitationStatus - статус приглашения в организацию
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "PENDING"
+	InvitationStatusAccepted InvitationStatus = "ACCEPTED"
+	InvitationStatusDeclined InvitationStatus = "DECLINED"
+)
+
+// OrgInvitation - приглашение в организацию по email
+type OrgInvitation struct {
+	ID             string
+	OrganizationID string
+	Email          string
+	Role           OrgRole // Роль, которую получит приглашенный
+	InvitedBy      string
+	Status         InvitationStatus
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	RespondedAt    *time.Time
+}
+
+// ===== Методы для OrgRole =====
+
+// IsValid проверяет, что роль известна
+func (r OrgRole) IsValid() bool {
+	return r == OrgRoleOwner || r == OrgRoleAdmin || r == OrgRoleMember
+}
+
+// rank возвращает уровень роли в организации
+func (r OrgRole) rank() int {
+	switch r {
+	case OrgRoleOwner:
+		return 3
+	case OrgRoleAdmin:
+		return 2
+	case OrgRoleMember:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CanManageMembers разрешает приглашать, исключать участников и менять их роли
+func (r OrgRole) CanManageMembers() bool {
+	return r.rank() >= OrgRoleAdmin.rank()
+}
+
+// CanManage проверяет, может ли роль управлять участником с ролью target.
+// Владелец управляет всеми, администратор - только обычными участниками.
+func (r OrgRole) CanManage(target OrgRole) bool {
+	if r == OrgRoleOwner {
+		return true
+	}
+	return r.CanManageMembers() && r.rank() > target.rank()
+}
+
+// CanAssign проверяет, может ли роль выдать роль role.
+// Владельцев назначает только владелец.
+func (r OrgRole) CanAssign(role OrgRole) bool {
+	if !role.IsValid() || !r.CanManageMembers() {
+		return false
+	}
+	if role == OrgRoleOwner {
+		return r == OrgRoleOwner
+	}
+	return r.rank() >= role.rank()
+}
+
+// ===== Методы для Organization и OrgInvitation =====
+
+// NewOrganization создает организацию
+func NewOrganization(name, slug, ownerID string) *Organization {
+	now := time.Now()
+
+	return &Organization{
+		ID:        GenerateUUID(),
+		Name:      name,
+		Slug:      strings.ToLower(slug),
+		OwnerID:   ownerID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// NewOrgInvitation создает приглашение со сроком действия ttl
+func NewOrgInvitation(orgID, email string, role OrgRole, invitedBy string, ttl time.Duration) *OrgInvitation {
+	now := time.Now()
+
+	return &OrgInvitation{
+		ID:             GenerateUUID(),
+		OrganizationID: orgID,
+		Email:          strings.ToLower(email),
+		Role:           role,
+		InvitedBy:      invitedBy,
+		Status:         InvitationStatusPending,
+		ExpiresAt:      now.Add(ttl),
+		CreatedAt:      now,
+	}
+}
+
+// IsExpired проверяет, истек ли срок приглашения
+func (i *OrgInvitation) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
+
+// IsFor проверяет, адресовано ли приглашение этому email
+func (i *OrgInvitation) IsFor(email string) bool {
+	return strings.EqualFold(i.Email, email)
+}
+
+// OrganizationRepository определяет хранилище организаций, участников и приглашений.
+// Все запросы к участникам и приглашениям ограничены ID организации.
+type OrganizationRepository interface {
+	// Create сохраняет организацию и ее владельца одной транзакцией
+	Create(ctx context.Context, org *Organization, owner *OrgMember) error
+	FindByID(ctx context.Context, id string) (*Organization, error)
+	ListForUser(ctx context.Context, userID string) ([]*Organization, error)
+
+	FindMember(ctx context.Context, orgID, userID string) (*OrgMember, error)
+	ListMembers(ctx context.Context, orgID string) ([]*OrgMember, error)
+	UpdateMemberRole(ctx context.Context, orgID, userID string, role OrgRole) error
+	RemoveMember(ctx context.Context, orgID, userID string) error
+	CountOwners(ctx context.Context, orgID string) (int, error)
+
+	CreateInvitation(ctx context.Context, inv *OrgInvitation) error
+	FindInvitation(ctx context.Context, orgID, id string) (*OrgInvitation, error)
+	ListInvitationsForEmail(ctx context.Context, email string) ([]*OrgInvitation, error)
+
+	// RespondInvitation переводит ожидающее приглашение в статус status.
+	// При принятии в той же транзакции добавляет пользователя userID в организацию.
+	// Возвращает false, если приглашение уже обработано.
+	RespondInvitation(ctx context.Context, inv *OrgInvitation, userID string, status InvitationStatus, at time.Time) (bool, error)
+}
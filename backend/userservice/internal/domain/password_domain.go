@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordHasher определяет интерфейс хеширования паролей.
+// Алгоритм и параметры хранятся в самом хеше (формат PHC или bcrypt).
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, encoded string) (bool, error) // Неверный пароль - false без ошибки
+	NeedsRehash(encoded string) bool               // Хеш сделан другим алгоритмом или с устаревшими параметрами
+}
+
+// BreachedPasswordChecker определяет проверку пароля по базе утекших паролей
+type BreachedPasswordChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+// PasswordHistoryRepository определяет хранилище прежних хешей паролей
+type PasswordHistoryRepository interface {
+	// Add сохраняет хеш и оставляет у пользователя не больше keep последних записей
+	Add(ctx context.Context, userID, passwordHash string, keep int) error
+	// List возвращает до limit последних хешей, новые первыми
+	List(ctx context.Context, userID string, limit int) ([]string, error)
+}
+
+// Нарушения политики паролей
+const (
+	PasswordViolationTooShort     = "too_short"
+	PasswordViolationTooLong      = "too_long"
+	PasswordViolationNoUpper      = "no_upper"
+	PasswordViolationNoLower      = "no_lower"
+	PasswordViolationNoDigit      = "no_digit"
+	PasswordViolationNoSymbol     = "no_symbol"
+	PasswordViolationCharClasses  = "char_classes"
+	PasswordViolationDictionary   = "dictionary_word"
+	PasswordViolationPersonalInfo = "personal_info"
+	PasswordViolationBreached     = "breached"
+	PasswordViolationReused       = "reused"
+)
+
+// PasswordViolation - одно нарушение политики паролей
+type PasswordViolation struct {
+	Code    string
+	Message string
+}
+
+// PasswordPolicy - правила для новых паролей
+type PasswordPolicy struct {
+	MinLength      int
+	MaxLength      int // 0 - без ограничения
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSymbol  bool
+	MinCharClasses int // Сколько разных классов символов должно быть в пароле
+
+	// Dictionary - запрещенные слова в нижнем регистре. Пароль не должен их содержать.
+	Dictionary map[string]struct{}
+	// MinWordLength - более короткие слова словаря и личных данных не проверяются
+	MinWordLength int
+}
+
+// NewPasswordPolicyDictionary строит словарь запрещенных слов для PasswordPolicy
+func NewPasswordPolicyDictionary(words []string) map[string]struct{} {
+	dict := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w != "" {
+			dict[w] = struct{}{}
+		}
+	}
+	return dict
+}
+
+// Check проверяет пароль по правилам политики. personal - email, имя и другие
+// данные пользователя, на которые пароль не должен быть похож.
+func (p *PasswordPolicy) Check(password string, personal ...string) []PasswordViolation {
+	var violations []PasswordViolation
+	add := func(code, format string, args ...interface{}) {
+		violations = append(violations, PasswordViolation{Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		add(PasswordViolationTooShort, "Пароль должен содержать минимум %d символов", p.MinLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		add(PasswordViolationTooLong, "Пароль должен содержать максимум %d символов", p.MaxLength)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		add(PasswordViolationNoUpper, "Пароль должен содержать заглавную букву")
+	}
+	if p.RequireLower && !lower {
+		add(PasswordViolationNoLower, "Пароль должен содержать строчную букву")
+	}
+	if p.RequireDigit && !digit {
+		add(PasswordViolationNoDigit, "Пароль должен содержать цифру")
+	}
+	if p.RequireSymbol && !symbol {
+		add(PasswordViolationNoSymbol, "Пароль должен содержать спецсимвол")
+	}
+	classes := 0
+	for _, ok := range []bool{upper, lower, digit, symbol} {
+		if ok {
+			classes++
+		}
+	}
+	if classes < p.MinCharClasses {
+		add(PasswordViolationCharClasses, "Пароль должен содержать символы минимум %d типов: заглавные, строчные, цифры, спецсимволы", p.MinCharClasses)
+	}
+
+	lowered := strings.ToLower(password)
+	for word := range p.Dictionary {
+		if utf8.RuneCountInString(word) >= p.MinWordLength && strings.Contains(lowered, word) {
+			add(PasswordViolationDictionary, "Пароль не должен содержать распространенные слова")
+			break
+		}
+	}
+
+	for _, part := range personalTokens(personal) {
+		if utf8.RuneCountInString(part) >= p.MinWordLength && strings.Contains(lowered, part) {
+			add(PasswordViolationPersonalInfo, "Пароль не должен содержать email или имя")
+			break
+		}
+	}
+
+	return violations
+}
+
+// personalTokens разбивает личные данные на части: значение целиком,
+// локальную часть email и отдельные слова
+func personalTokens(values []string) []string {
+	var tokens []string
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		tokens = append(tokens, v)
+
+		if local, _, ok := strings.Cut(v, "@"); ok {
+			tokens = append(tokens, local)
+			v = local
+		}
+
+		tokens = append(tokens, strings.FieldsFunc(v, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+	return tokens
+}
+
+// NewPasswordPolicyError собирает нарушения политики паролей в ошибку валидации
+func NewPasswordPolicyError(violations []PasswordViolation) *ValidationError {
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = v.Message
+	}
+
+	err := NewValidationError(
+		"password",
+		strings.Join(messages, "; "),
+		map[string]interface{}{
+			"field":      "password",
+			"type":       "policy",
+			"violations": violations,
+		},
+	)
+	err.Code = ErrCodeInvalidPassword
+	return err
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"strings"
+)
+
+// NormalizePhone приводит номер телефона к формату E.164 (+79991234567).
+// Пробелы, дефисы, точки и скобки удаляются, префикс 00 заменяется на +.
+// Номер без кода страны дополняется defaultCountryCode, ведущий 0 при этом отбрасывается.
+func NormalizePhone(phone, defaultCountryCode string) (string, error) {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+			// Разделители игнорируем
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+
+	normalized := b.String()
+	switch {
+	case strings.HasPrefix(normalized, "+"):
+	case strings.HasPrefix(normalized, "00"):
+		normalized = "+" + normalized[2:]
+	case defaultCountryCode != "":
+		normalized = "+" + strings.TrimPrefix(defaultCountryCode, "+") + strings.TrimPrefix(normalized, "0")
+	default:
+		return "", ErrInvalidPhone
+	}
+
+	// E.164: до 15 цифр, код страны не начинается с 0
+	digits := normalized[1:]
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return "", ErrInvalidPhone
+	}
+
+	return normalized, nil
+}
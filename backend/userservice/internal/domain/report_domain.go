@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ReportCategory - причина жалобы на пользователя
+type ReportCategory string
+
+const (
+	ReportCategorySpam  ReportCategory = "SPAM"
+	ReportCategoryAbuse ReportCategory = "ABUSE"
+	ReportCategoryFraud ReportCategory = "FRAUD"
+	ReportCategoryOther ReportCategory = "OTHER"
+)
+
+// IsValid проверяет, что категория известна
+func (c ReportCategory) IsValid() bool {
+	switch c {
+	case ReportCategorySpam, ReportCategoryAbuse, ReportCategoryFraud, ReportCategoryOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// Weight - базовый приоритет категории в очереди модерации
+func (c ReportCategory) Weight() int {
+	switch c {
+	case ReportCategoryFraud:
+		return 30
+	case ReportCategoryAbuse:
+		return 20
+	case ReportCategorySpam:
+		return 10
+	default:
+		return 0
+	}
+}
+
+// BanCategory возвращает категорию бана для жалобы этой категории
+func (c ReportCategory) BanCategory() BanCategory {
+	switch c {
+	case ReportCategorySpam:
+		return BanCategorySpam
+	case ReportCategoryAbuse:
+		return BanCategoryAbuse
+	case ReportCategoryFraud:
+		return BanCategoryFraud
+	default:
+		return BanCategoryAdmin
+	}
+}
+
+// ReportStatus - статус жалобы в очереди модерации
+type ReportStatus string
+
+const (
+	ReportStatusOpen     ReportStatus = "OPEN"
+	ReportStatusClaimed  ReportStatus = "CLAIMED" // Взята модератором в работу
+	ReportStatusResolved ReportStatus = "RESOLVED"
+)
+
+// IsOpen проверяет, что по жалобе еще не принято решение
+func (s ReportStatus) IsOpen() bool {
+	return s == ReportStatusOpen || s == ReportStatusClaimed
+}
+
+// ReportAction - решение модератора по жалобе
+type ReportAction string
+
+const (
+	ReportActionDismiss ReportAction = "DISMISS" // Нарушения нет
+	ReportActionWarn    ReportAction = "WARN"    // Страйк с эскалацией
+	ReportActionBan     ReportAction = "BAN"
+)
+
+// UserReport - жалоба на пользователя. Повторные жалобы той же категории
+// на того же пользователя, пока жалоба открыта, попадают в нее же.
+type UserReport struct {
+	ID             string
+	ReportedUserID string
+	Category       ReportCategory
+	Status         ReportStatus
+	ReportCount    int // Число разных заявителей
+	Priority       int // Вес категории плюс число заявителей
+	ClaimedBy      string
+	ClaimedAt      *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	// Решение
+	Action     ReportAction
+	Resolution string
+	ResolvedBy string
+	ResolvedAt *time.Time
+	BannedAt   *time.Time // Начало выданного по жалобе бана, вместе с ReportedUserID определяет бан
+	StrikeID   string     // Страйк, выданный по жалобе
+
+	Entries []*ReportEntry // Заполняется только при запросе деталей
+}
+
+// ReportEntry - жалоба одного заявителя с доказательствами
+type ReportEntry struct {
+	ReportID    string
+	ReporterID  string
+	Description string
+	Evidence    map[string]string // Ссылки, ID сообщений и т.п.
+	CreatedAt   time.Time
+}
+
+// NewUserReport создает открытую жалобу
+func NewUserReport(reportedUserID string, category ReportCategory) *UserReport {
+	now := time.Now()
+
+	return &UserReport{
+		ID:             GenerateUUID(),
+		ReportedUserID: reportedUserID,
+		Category:       category,
+		Status:         ReportStatusOpen,
+		Priority:       category.Weight(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// IsClaimedBy проверяет, что жалоба взята в работу модератором moderatorID.
+// Решение выносит только он, так одно нарушение не наказывают дважды.
+func (r *UserReport) IsClaimedBy(moderatorID string) bool {
+	return r.Status == ReportStatusClaimed && r.ClaimedBy == moderatorID
+}
+
+// ReportFilter - фильтр очереди модерации
+type ReportFilter struct {
+	Page      int
+	PageSize  int
+	Status    ReportStatus // Пусто - все открытые
+	Category  ReportCategory
+	ClaimedBy string
+}
+
+// ReportResolution - решение модератора по жалобе
+type ReportResolution struct {
+	Action      ReportAction
+	Note        string
+	BanDuration *time.Duration // Для BAN, nil - перманентный бан
+}
+
+// ReportRepository определяет хранилище жалоб на пользователей
+type ReportRepository interface {
+	// Submit добавляет жалобу заявителя в открытую жалобу той же категории
+	// или создает новую. duplicate равен true, если заявитель уже жаловался.
+	Submit(ctx context.Context, report *UserReport, entry *ReportEntry) (saved *UserReport, duplicate bool, err error)
+	FindByID(ctx context.Context, id string) (*UserReport, error)
+	ListEntries(ctx context.Context, reportID string) ([]*ReportEntry, error)
+
+	// ListQueue возвращает жалобы по убыванию приоритета, старые первыми
+	ListQueue(ctx context.Context, filter *ReportFilter) ([]*UserReport, int64, error)
+
+	// Claim возвращает false, если жалоба закрыта или взята другим модератором
+	Claim(ctx context.Context, id, moderatorID string, at time.Time) (bool, error)
+
+	// Resolve закрывает жалобу, взятую модератором report.ResolvedBy.
+	// Возвращает false, если жалоба уже закрыта или не взята им в работу.
+	Resolve(ctx context.Context, report *UserReport) (bool, error)
+
+	// SetOutcome связывает закрытую жалобу с выданным баном или страйком
+	SetOutcome(ctx context.Context, report *UserReport) error
+
+	// Reopen возвращает жалобу, закрытую модератором, в работу к нему же,
+	// если наказание выдать не удалось
+	Reopen(ctx context.Context, id, moderatorID string) error
+}
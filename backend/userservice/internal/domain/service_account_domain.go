@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+)
+
+// ServiceAccount - учетная запись внутреннего сервиса (шлюз, WebRTC и т.п.),
+// который обращается к API от своего имени, а не от имени пользователя
+type ServiceAccount struct {
+	ClientID    string
+	Name        string
+	SecretHash  string       // SHA-256 секрета клиента в hex
+	Permissions []Permission // Права сервиса, роли у него нет (см. Permission.AllowedForService)
+	Audiences   []string     // Сервисы, для которых можно получить токен
+	Disabled    bool
+}
+
+// ServiceToken - access токен сервисного аккаунта
+type ServiceToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	Audience    string
+	Permissions []Permission
+}
+
+// AllowsAudience проверяет, может ли сервис получить токен для audience
+func (a *ServiceAccount) AllowsAudience(audience string) bool {
+	for _, aud := range a.Audiences {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidSecretHash проверяет, что хеш секрета - SHA-256 в hex.
+// Заглушка вместо хеша в конфигурации - ошибка, а не аккаунт, в который нельзя войти.
+func IsValidSecretHash(hash string) bool {
+	sum, err := hex.DecodeString(hash)
+	return err == nil && len(sum) == 32
+}
+
+// ServiceAccountRepository определяет хранилище сервисных аккаунтов
+type ServiceAccountRepository interface {
+	FindByClientID(ctx context.Context, clientID string) (*ServiceAccount, error)
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+)
+
+// Strike - предупреждение модератора. Страйк перестает учитываться
+// в эскалации после ExpiresAt.
+type Strike struct {
+	ID        string
+	UserID    string
+	Reason    string
+	IssuedBy  string // ID модератора
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// NewStrike создает страйк, который сгорает через ttl
+func NewStrike(userID, reason, issuedBy string, ttl time.Duration) *Strike {
+	now := time.Now()
+	return &Strike{
+		ID:        GenerateUUID(),
+		UserID:    userID,
+		Reason:    reason,
+		IssuedBy:  issuedBy,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+// IsActive проверяет, что страйк еще не сгорел
+func (s *Strike) IsActive(now time.Time) bool {
+	return now.Before(s.ExpiresAt)
+}
+
+// EscalationStep - автоматический бан при накоплении Strikes действующих страйков
+type EscalationStep struct {
+	Strikes  int
+	Duration time.Duration // 0 - перманентный бан
+}
+
+// EscalationLadder - ступени эскалации по возрастанию числа страйков
+type EscalationLadder []EscalationStep
+
+// StepFor возвращает самую строгую ступень, которой достигло число
+// действующих страйков, или nil, если банить еще рано
+func (l EscalationLadder) StepFor(strikes int) *EscalationStep {
+	var step *EscalationStep
+	for i := range l {
+		if strikes >= l[i].Strikes && (step == nil || l[i].Strikes > step.Strikes) {
+			step = &l[i]
+		}
+	}
+	return step
+}
+
+// WarningResult - итог предупреждения: страйк и бан, если сработала эскалация
+type WarningResult struct {
+	Strike        *Strike
+	ActiveStrikes int
+	User          *User // С BanInfo, если пользователь забанен автоматически
+	Banned        bool
+}
@@ -0,0 +1,167 @@
+package domain
+
+// subscriptionTransitions - допустимые переходы статусов подписки.
+// Остаться в текущем статусе можно всегда: так меняются сумма и даты.
+// Уровень действующей подписки меняется через UPGRADING и DOWNGRADING.
+var subscriptionTransitions = map[SubscriptionStatus][]SubscriptionStatus{
+	// Подписки еще не было
+	SubscriptionStatusUnspecified: {
+		SubscriptionStatusInactive,
+		SubscriptionStatusPending,
+		SubscriptionStatusTrial,
+		SubscriptionStatusActive,
+	},
+	SubscriptionStatusInactive: {
+		SubscriptionStatusPending,
+		SubscriptionStatusTrial,
+		SubscriptionStatusActive,
+	},
+	// Ждет первой оплаты
+	SubscriptionStatusPending: {
+		SubscriptionStatusTrial,
+		SubscriptionStatusActive,
+		SubscriptionStatusCanceled,
+		SubscriptionStatusExpired,
+	},
+	SubscriptionStatusTrial: {
+		SubscriptionStatusActive,
+		SubscriptionStatusPastDue, // Первое списание после триала не прошло
+		SubscriptionStatusCanceled,
+		SubscriptionStatusExpired,
+	},
+	SubscriptionStatusActive: {
+		SubscriptionStatusPastDue,
+		SubscriptionStatusPaused,
+		SubscriptionStatusUpgrading,
+		SubscriptionStatusDowngrading,
+		SubscriptionStatusCanceled,
+		SubscriptionStatusExpired,
+	},
+	// Списание не прошло, ждем повторной оплаты
+	SubscriptionStatusPastDue: {
+		SubscriptionStatusActive,
+		SubscriptionStatusGracePeriod,
+		SubscriptionStatusCanceled,
+	},
+	SubscriptionStatusGracePeriod: {
+		SubscriptionStatusActive,
+		SubscriptionStatusExpired,
+		SubscriptionStatusCanceled,
+	},
+	SubscriptionStatusPaused: {
+		SubscriptionStatusActive,
+		SubscriptionStatusCanceled,
+	},
+	// Смена уровня - промежуточные статусы до подтверждения оплаты
+	SubscriptionStatusUpgrading: {
+		SubscriptionStatusActive,
+		SubscriptionStatusPastDue, // Доплата не прошла
+	},
+	SubscriptionStatusDowngrading: {
+		SubscriptionStatusActive,
+		SubscriptionStatusInactive, // Переход на бесплатный уровень
+	},
+	// Отмененная подписка действует до конца оплаченного периода
+	SubscriptionStatusCanceled: {
+		SubscriptionStatusActive, // Возобновление до конца периода
+		SubscriptionStatusExpired,
+	},
+	SubscriptionStatusExpired: {
+		SubscriptionStatusPending,
+		SubscriptionStatusActive,
+	},
+}
+
+// CanTransitionTo проверяет, допустим ли переход в статус next
+func (s SubscriptionStatus) CanTransitionTo(next SubscriptionStatus) bool {
+	if s == "" {
+		s = SubscriptionStatusUnspecified
+	}
+	if s == next {
+		return true
+	}
+
+	for _, allowed := range subscriptionTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo переводит подписку в статус next или возвращает ошибку
+// недопустимого перехода. Выход из UPGRADING или DOWNGRADING завершает
+// смену уровня (см. settlePendingLevel).
+func (s *SubscriptionInfo) TransitionTo(next SubscriptionStatus) error {
+	if !s.Status.CanTransitionTo(next) {
+		return NewInvalidSubscriptionTransitionError(s.Status, next)
+	}
+
+	if next != s.Status {
+		s.settlePendingLevel(next)
+	}
+	s.Status = next
+	return nil
+}
+
+// settlePendingLevel применяет запрошенный уровень, если смена оплачена
+// (ACTIVE, а для понижения до бесплатного уровня - INACTIVE), и отменяет его
+// при любом другом исходе: не прошедшая доплата оставляет прежний уровень.
+func (s *SubscriptionInfo) settlePendingLevel(next SubscriptionStatus) {
+	if s.PendingLevel == "" {
+		return
+	}
+
+	if next == SubscriptionStatusActive || next == SubscriptionStatusInactive {
+		s.Level = s.PendingLevel
+		s.Amount = s.PendingAmount
+	}
+	s.PendingLevel = ""
+	s.PendingAmount = 0
+}
+
+// HoldsLevel проверяет, действует ли в этом статусе оплаченный уровень.
+// Такой уровень меняется только через UPGRADING или DOWNGRADING.
+func (s SubscriptionStatus) HoldsLevel() bool {
+	switch s {
+	case SubscriptionStatusActive,
+		SubscriptionStatusPastDue,
+		SubscriptionStatusGracePeriod,
+		SubscriptionStatusPaused,
+		SubscriptionStatusUpgrading,
+		SubscriptionStatusDowngrading,
+		SubscriptionStatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rank возвращает место уровня в линейке тарифов: чем больше, тем выше уровень.
+// Порядок не совпадает с номерами в proto, уровни добавлялись не по порядку.
+func (l SubscriptionLevel) Rank() int {
+	switch l {
+	case SubscriptionLevelFree:
+		return 1
+	case SubscriptionLevelStarter:
+		return 2
+	case SubscriptionLevelBasic:
+		return 3
+	case SubscriptionLevelStandard:
+		return 4
+	case SubscriptionLevelPro:
+		return 5
+	case SubscriptionLevelBusiness:
+		return 6
+	case SubscriptionLevelPremium:
+		return 7
+	case SubscriptionLevelEnterprise:
+		return 8
+	case SubscriptionLevelUltimate:
+		return 9
+	case SubscriptionLevelLifetime:
+		return 10
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSubscriptionStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from SubscriptionStatus
+		to   SubscriptionStatus
+		want bool
+	}{
+		{"", SubscriptionStatusActive, true}, // Подписки еще не было
+		{SubscriptionStatusUnspecified, SubscriptionStatusTrial, true},
+		{SubscriptionStatusUnspecified, SubscriptionStatusCanceled, false},
+		{SubscriptionStatusActive, SubscriptionStatusActive, true},
+		{SubscriptionStatusActive, SubscriptionStatusUpgrading, true},
+		{SubscriptionStatusActive, SubscriptionStatusDowngrading, true},
+		{SubscriptionStatusActive, SubscriptionStatusPending, false},
+		{SubscriptionStatusActive, SubscriptionStatusTrial, false},
+		{SubscriptionStatusTrial, SubscriptionStatusPastDue, true},
+		{SubscriptionStatusTrial, SubscriptionStatusUpgrading, false},
+		{SubscriptionStatusPastDue, SubscriptionStatusGracePeriod, true},
+		{SubscriptionStatusPastDue, SubscriptionStatusExpired, false},
+		{SubscriptionStatusGracePeriod, SubscriptionStatusExpired, true},
+		{SubscriptionStatusPaused, SubscriptionStatusActive, true},
+		{SubscriptionStatusPaused, SubscriptionStatusExpired, false},
+		{SubscriptionStatusUpgrading, SubscriptionStatusActive, true},
+		{SubscriptionStatusUpgrading, SubscriptionStatusPastDue, true},
+		{SubscriptionStatusUpgrading, SubscriptionStatusDowngrading, false},
+		{SubscriptionStatusDowngrading, SubscriptionStatusInactive, true},
+		{SubscriptionStatusDowngrading, SubscriptionStatusUpgrading, false},
+		{SubscriptionStatusCanceled, SubscriptionStatusActive, true},
+		{SubscriptionStatusCanceled, SubscriptionStatusTrial, false},
+		{SubscriptionStatusExpired, SubscriptionStatusPending, true},
+		{SubscriptionStatusExpired, SubscriptionStatusCanceled, false},
+		{SubscriptionStatusInactive, SubscriptionStatusExpired, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionInfoApply(t *testing.T) {
+	level := func(l SubscriptionLevel) *SubscriptionLevel { return &l }
+	status := func(s SubscriptionStatus) *SubscriptionStatus { return &s }
+	amount := func(a float64) *float64 { return &a }
+
+	tests := []struct {
+		name        string
+		current     SubscriptionInfo
+		update      SubscriptionUpdate
+		wantStatus  SubscriptionStatus
+		wantLevel   SubscriptionLevel
+		wantPending SubscriptionLevel
+		wantAmount  float64
+		wantErr     bool
+	}{
+		{
+			name:        "upgrade waits for payment",
+			current:     SubscriptionInfo{Status: SubscriptionStatusActive, Level: SubscriptionLevelBasic, Amount: 10},
+			update:      SubscriptionUpdate{Level: level(SubscriptionLevelPro), Amount: amount(20)},
+			wantStatus:  SubscriptionStatusUpgrading,
+			wantLevel:   SubscriptionLevelBasic,
+			wantPending: SubscriptionLevelPro,
+			wantAmount:  10,
+		},
+		{
+			name:        "upgrade to a cheaper plan is still an upgrade",
+			current:     SubscriptionInfo{Status: SubscriptionStatusActive, Level: SubscriptionLevelBasic, Amount: 10},
+			update:      SubscriptionUpdate{Level: level(SubscriptionLevelPremium), Amount: amount(5)},
+			wantStatus:  SubscriptionStatusUpgrading,
+			wantLevel:   SubscriptionLevelBasic,
+			wantPending: SubscriptionLevelPremium,
+			wantAmount:  10,
+		},
+		{
+			name:        "downgrade with matching status",
+			current:     SubscriptionInfo{Status: SubscriptionStatusActive, Level: SubscriptionLevelPro, Amount: 20},
+			update:      SubscriptionUpdate{Level: level(SubscriptionLevelStarter), Status: status(SubscriptionStatusDowngrading)},
+			wantStatus:  SubscriptionStatusDowngrading,
+			wantLevel:   SubscriptionLevelPro,
+			wantPending: SubscriptionLevelStarter,
+			wantAmount:  20,
+		},
+		{
+			name:       "upgrade paid",
+			current:    SubscriptionInfo{Status: SubscriptionStatusUpgrading, Level: SubscriptionLevelBasic, Amount: 10, PendingLevel: SubscriptionLevelPro, PendingAmount: 20},
+			update:     SubscriptionUpdate{Status: status(SubscriptionStatusActive)},
+			wantStatus: SubscriptionStatusActive,
+			wantLevel:  SubscriptionLevelPro,
+			wantAmount: 20,
+		},
+		{
+			name:       "upgrade paid with the requested level",
+			current:    SubscriptionInfo{Status: SubscriptionStatusUpgrading, Level: SubscriptionLevelBasic, Amount: 10, PendingLevel: SubscriptionLevelPro, PendingAmount: 20},
+			update:     SubscriptionUpdate{Status: status(SubscriptionStatusActive), Level: level(SubscriptionLevelPro)},
+			wantStatus: SubscriptionStatusActive,
+			wantLevel:  SubscriptionLevelPro,
+			wantAmount: 20,
+		},
+		{
+			name:       "upgrade payment failed keeps the paid level",
+			current:    SubscriptionInfo{Status: SubscriptionStatusUpgrading, Level: SubscriptionLevelBasic, Amount: 10, PendingLevel: SubscriptionLevelPro, PendingAmount: 20},
+			update:     SubscriptionUpdate{Status: status(SubscriptionStatusPastDue)},
+			wantStatus: SubscriptionStatusPastDue,
+			wantLevel:  SubscriptionLevelBasic,
+			wantAmount: 10,
+		},
+		{
+			name:       "downgrade to the free level",
+			current:    SubscriptionInfo{Status: SubscriptionStatusDowngrading, Level: SubscriptionLevelPro, Amount: 20, PendingLevel: SubscriptionLevelFree},
+			update:     SubscriptionUpdate{Status: status(SubscriptionStatusInactive)},
+			wantStatus: SubscriptionStatusInactive,
+			wantLevel:  SubscriptionLevelFree,
+			wantAmount: 0,
+		},
+		{
+			name:        "amount during upgrade goes to the requested level",
+			current:     SubscriptionInfo{Status: SubscriptionStatusUpgrading, Level: SubscriptionLevelBasic, Amount: 10, PendingLevel: SubscriptionLevelPro, PendingAmount: 20},
+			update:      SubscriptionUpdate{Amount: amount(25)},
+			wantStatus:  SubscriptionStatusUpgrading,
+			wantLevel:   SubscriptionLevelBasic,
+			wantPending: SubscriptionLevelPro,
+			wantAmount:  10,
+		},
+		{
+			name:    "level change while staying active",
+			current: SubscriptionInfo{Status: SubscriptionStatusActive, Level: SubscriptionLevelBasic},
+			update:  SubscriptionUpdate{Level: level(SubscriptionLevelPro), Status: status(SubscriptionStatusActive)},
+			wantErr: true,
+		},
+		{
+			name:    "upgrade marked as downgrade",
+			current: SubscriptionInfo{Status: SubscriptionStatusActive, Level: SubscriptionLevelBasic},
+			update:  SubscriptionUpdate{Level: level(SubscriptionLevelPro), Status: status(SubscriptionStatusDowngrading)},
+			wantErr: true,
+		},
+		{
+			name:    "level change while past due",
+			current: SubscriptionInfo{Status: SubscriptionStatusPastDue, Level: SubscriptionLevelBasic},
+			update:  SubscriptionUpdate{Level: level(SubscriptionLevelPro)},
+			wantErr: true,
+		},
+		{
+			name:    "level change during upgrade",
+			current: SubscriptionInfo{Status: SubscriptionStatusUpgrading, Level: SubscriptionLevelBasic, PendingLevel: SubscriptionLevelPro},
+			update:  SubscriptionUpdate{Level: level(SubscriptionLevelPremium)},
+			wantErr: true,
+		},
+		{
+			name:       "upgrade started without a pending level",
+			current:    SubscriptionInfo{Status: SubscriptionStatusUpgrading, Level: SubscriptionLevelPro},
+			update:     SubscriptionUpdate{Status: status(SubscriptionStatusActive)},
+			wantStatus: SubscriptionStatusActive,
+			wantLevel:  SubscriptionLevelPro,
+		},
+		{
+			name:       "new subscription chooses any level",
+			current:    SubscriptionInfo{Status: SubscriptionStatusUnspecified},
+			update:     SubscriptionUpdate{Level: level(SubscriptionLevelPro), Status: status(SubscriptionStatusActive)},
+			wantStatus: SubscriptionStatusActive,
+			wantLevel:  SubscriptionLevelPro,
+		},
+		{
+			name:       "resubscribe after expiry",
+			current:    SubscriptionInfo{Status: SubscriptionStatusExpired, Level: SubscriptionLevelPro},
+			update:     SubscriptionUpdate{Level: level(SubscriptionLevelBasic), Status: status(SubscriptionStatusActive)},
+			wantStatus: SubscriptionStatusActive,
+			wantLevel:  SubscriptionLevelBasic,
+		},
+		{
+			name:       "same level keeps status",
+			current:    SubscriptionInfo{Status: SubscriptionStatusActive, Level: SubscriptionLevelPro},
+			update:     SubscriptionUpdate{Level: level(SubscriptionLevelPro), Amount: amount(20)},
+			wantStatus: SubscriptionStatusActive,
+			wantLevel:  SubscriptionLevelPro,
+			wantAmount: 20,
+		},
+		{
+			name:    "invalid transition",
+			current: SubscriptionInfo{Status: SubscriptionStatusActive, Level: SubscriptionLevelPro},
+			update:  SubscriptionUpdate{Status: status(SubscriptionStatusTrial)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := tt.current
+			err := sub.Apply(&tt.update)
+
+			if tt.wantErr {
+				var domainErr *DomainError
+				if !errors.As(err, &domainErr) || domainErr.Code != ErrCodeInvalidSubscriptionTransition {
+					t.Fatalf("Apply error = %v, want %s", err, ErrCodeInvalidSubscriptionTransition)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if sub.Status != tt.wantStatus || sub.Level != tt.wantLevel {
+				t.Errorf("got %s/%s, want %s/%s", sub.Status, sub.Level, tt.wantStatus, tt.wantLevel)
+			}
+			if sub.PendingLevel != tt.wantPending {
+				t.Errorf("PendingLevel = %q, want %q", sub.PendingLevel, tt.wantPending)
+			}
+			if sub.Amount != tt.wantAmount {
+				t.Errorf("Amount = %v, want %v", sub.Amount, tt.wantAmount)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TokenPair - пара токенов, выдаваемая при входе и при обновлении.
+// Если у пользователя включена 2FA, Authenticate вместо пары токенов
+// возвращает только MFAToken, который обменивается на токены через VerifyMFA.
+type TokenPair struct {
+	AccessToken      string
+	RefreshToken     string
+	ExpiresAt        time.Time
+	RefreshExpiresAt time.Time
+
+	MFARequired bool
+	MFAToken    string
+}
+
+// RefreshToken - серверная запись refresh токена.
+// Все токены, полученные последовательной ротацией от одного входа,
+// принадлежат одному семейству (FamilyID).
+type RefreshToken struct {
+	ID         string
+	UserID     string
+	FamilyID   string
+	TenantID   string // Организация, для которой выдано семейство токенов
+	TokenHash  string // SHA-256 от выданного токена, сам токен не хранится
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	RotatedAt  *time.Time // Когда токен был обменян на новый
+	RevokedAt  *time.Time
+	ReplacedBy string // ID токена, выданного взамен
+}
+
+// PasswordResetToken - одноразовый токен сброса пароля
+type PasswordResetToken struct {
+	ID        string
+	UserID    string
+	TokenHash string // SHA-256 от отправленного токена
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
+
+// PhoneOTP - одноразовый код подтверждения телефона
+type PhoneOTP struct {
+	ID         string
+	UserID     string
+	Phone      string // Номер, на который отправлен код
+	CodeHash   string // SHA-256 от кода, сам код не хранится
+	Attempts   int    // Число неудачных попыток ввода
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	ConsumedAt *time.Time
+}
+
+// ===== Методы для RefreshToken =====
+
+// NewRefreshToken создает запись refresh токена.
+// Пустой familyID означает начало нового семейства.
+func NewRefreshToken(userID, familyID, tenantID, tokenHash string, ttl time.Duration) *RefreshToken {
+	now := time.Now()
+	if familyID == "" {
+		familyID = GenerateUUID()
+	}
+
+	return &RefreshToken{
+		ID:        GenerateUUID(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TenantID:  tenantID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+// IsExpired проверяет, истек ли срок действия токена
+func (t *RefreshToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+// IsRevoked проверяет, отозван ли токен
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsRotated проверяет, был ли токен уже использован для ротации
+func (t *RefreshToken) IsRotated() bool {
+	return t.RotatedAt != nil
+}
+
+// ===== Методы для PasswordResetToken =====
+
+// NewPasswordResetToken создает запись токена сброса пароля
+func NewPasswordResetToken(userID, tokenHash string, ttl time.Duration) *PasswordResetToken {
+	now := time.Now()
+
+	return &PasswordResetToken{
+		ID:        GenerateUUID(),
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+// IsUsable проверяет, что токен не использован и не истек
+func (t *PasswordResetToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
+
+// ===== Методы для PhoneOTP =====
+
+// NewPhoneOTP создает запись одноразового кода
+func NewPhoneOTP(userID, phone, codeHash string, ttl time.Duration) *PhoneOTP {
+	now := time.Now()
+
+	return &PhoneOTP{
+		ID:        GenerateUUID(),
+		UserID:    userID,
+		Phone:     phone,
+		CodeHash:  codeHash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+// IsUsable проверяет, что код не использован и не истек
+func (o *PhoneOTP) IsUsable() bool {
+	return o.ConsumedAt == nil && time.Now().Before(o.ExpiresAt)
+}
+
+// ===== Интерфейсы репозиториев =====
+
+// RefreshTokenRepository определяет интерфейс хранилища refresh токенов
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+
+	// MarkRotated атомарно помечает токен использованным.
+	// Возвращает false, если токен уже был использован или отозван.
+	MarkRotated(ctx context.Context, id, replacedBy string) (bool, error)
+
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
+
+// TokenRevocationStore определяет хранилище отозванных access токенов.
+// Отдельные токены отзываются по jti, все сессии пользователя - отметкой
+// времени: токены, выпущенные раньше нее, считаются отозванными.
+type TokenRevocationStore interface {
+	RevokeToken(ctx context.Context, jti string, ttl time.Duration) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+
+	RevokeAllForUser(ctx context.Context, userID string, at time.Time, ttl time.Duration) error
+	RevokedBefore(ctx context.Context, userID string) (time.Time, error) // Нулевое время, если отзыва не было
+}
+
+// PasswordResetRepository определяет хранилище токенов сброса пароля
+type PasswordResetRepository interface {
+	Create(ctx context.Context, token *PasswordResetToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+
+	// MarkUsed атомарно помечает токен использованным.
+	// Возвращает false, если токен уже был использован.
+	MarkUsed(ctx context.Context, id string) (bool, error)
+
+	// InvalidateForUser помечает использованными все неиспользованные токены пользователя
+	InvalidateForUser(ctx context.Context, userID string) error
+}
+
+// PhoneOTPRepository определяет хранилище кодов подтверждения телефона
+type PhoneOTPRepository interface {
+	Create(ctx context.Context, otp *PhoneOTP) error
+	FindLatest(ctx context.Context, userID string) (*PhoneOTP, error) // Последний неиспользованный код
+
+	// IncrementAttempts увеличивает счетчик неудачных попыток и возвращает новое значение
+	IncrementAttempts(ctx context.Context, id string) (int, error)
+
+	// MarkConsumed атомарно помечает код использованным.
+	// Возвращает false, если код уже был использован.
+	MarkConsumed(ctx context.Context, id string) (bool, error)
+
+	// InvalidateForUser помечает использованными все неиспользованные коды пользователя
+	InvalidateForUser(ctx context.Context, userID string) error
+}
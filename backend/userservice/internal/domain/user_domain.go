@@ -9,20 +9,22 @@ import (
 
 // User - основная доменная модель пользователя
 type User struct {
-	ID           string
-	ServiceEmail string
-	Name         string
-	Password     string // Уже хешированный пароль
-	Email        string
-	Phone        string
-	Status       UserStatus
-	Role         UserRole
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	LastLoginAt  *time.Time
-	BanInfo      *BanInfo
-	Subscription *SubscriptionInfo
-	Metadata     map[string]string
+	ID              string
+	ServiceEmail    string
+	Name            string
+	Password        string // Уже хешированный пароль
+	Email           string
+	EmailVerifiedAt *time.Time // Сбрасывается при смене адреса
+	Phone           string     // В формате E.164
+	PhoneVerifiedAt *time.Time
+	Status          UserStatus
+	Role            UserRole
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	LastLoginAt     *time.Time
+	BanInfo         *BanInfo
+	Subscription    *SubscriptionInfo
+	Metadata        map[string]string
 }
 
 // BanInfo - информация о бане пользователя
@@ -31,13 +33,19 @@ type BanInfo struct {
 	BannedAt    time.Time
 	BannedUntil *time.Time // nil для перманентного бана
 	Reason      string
-	BannedBy    string // ID администратора
+	BannedBy    string      // ID администратора
+	Category    BanCategory // Пусто - бан без категории
+	ReportID    string      // Жалоба, по которой выдан бан
+
+	PreviousStatus UserStatus // Статус до бана, возвращается при снятии
 }
 
 // SubscriptionInfo - информация о подписке пользователя
 type SubscriptionInfo struct {
 	Status            SubscriptionStatus
-	Level             SubscriptionLevel
+	Level             SubscriptionLevel // Оплаченный уровень, действует и во время смены
+	PendingLevel      SubscriptionLevel // Запрошенный уровень в UPGRADING и DOWNGRADING
+	PendingAmount     float64           // Сумма для PendingLevel
 	SubscriptionStart time.Time
 	SubscriptionEnd   *time.Time
 	TrialEnd          *time.Time
@@ -53,10 +61,25 @@ type SubscriptionInfo struct {
 	Features          []string
 }
 
+// SubscriptionUpdate - изменения подписки. nil - поле не меняется.
+type SubscriptionUpdate struct {
+	Status          *SubscriptionStatus
+	Level           *SubscriptionLevel
+	SubscriptionEnd *time.Time
+	TrialEnd        *time.Time
+	SubscriptionID  *string
+	PaymentMethod   *string
+	AutoRenew       *bool
+	Amount          *float64
+	Currency        *string
+}
+
 // UserActivity - активность пользователя (для аудита в MongoDB)
 type UserActivity struct {
 	ID           string
 	UserID       string
+	ActorID      string        // Кто выполнил действие (пусто для анонимных запросов)
+	ActorKind    PrincipalKind // Человек или сервис
 	ActivityType ActivityType
 	IPAddress    string
 	UserAgent    string
@@ -98,6 +121,37 @@ const (
 	UserStatusBannedForFraud    UserStatus = "BANNED_FOR_FRAUD"
 )
 
+// BanCategory - причина бана для модерационной отчетности
+type BanCategory string
+
+const (
+	BanCategoryUnspecified BanCategory = ""
+	BanCategoryAdmin       BanCategory = "ADMIN"
+	BanCategorySystem      BanCategory = "SYSTEM" // Только автоматические баны самого сервиса
+	BanCategorySpam        BanCategory = "SPAM"
+	BanCategoryAbuse       BanCategory = "ABUSE"
+	BanCategoryFraud       BanCategory = "FRAUD"
+)
+
+// BanCategories - все категории банов в порядке отчетности
+var BanCategories = []BanCategory{
+	BanCategoryAdmin,
+	BanCategorySystem,
+	BanCategorySpam,
+	BanCategoryAbuse,
+	BanCategoryFraud,
+}
+
+// IsValid проверяет, что категория известна
+func (c BanCategory) IsValid() bool {
+	for _, known := range BanCategories {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
 // UserRole - роли пользователя
 type UserRole string
 
@@ -155,10 +209,20 @@ const (
 	ActivityTypePasswordChange    ActivityType = "PASSWORD_CHANGE"
 	ActivityTypeProfileUpdate     ActivityType = "PROFILE_UPDATE"
 	ActivityTypeEmailVerification ActivityType = "EMAIL_VERIFICATION"
+	ActivityTypePhoneVerification ActivityType = "PHONE_VERIFICATION"
+	ActivityTypeMFAChange         ActivityType = "MFA_CHANGE"
 	ActivityTypeSubscriptionStart ActivityType = "SUBSCRIPTION_START"
 	ActivityTypeSubscriptionEnd   ActivityType = "SUBSCRIPTION_END"
 	ActivityTypeBan               ActivityType = "BAN"
 	ActivityTypeUnban             ActivityType = "UNBAN"
+	ActivityTypeOrganization      ActivityType = "ORGANIZATION"
+	ActivityTypeAPIKey            ActivityType = "API_KEY"
+	ActivityTypeServiceToken      ActivityType = "SERVICE_TOKEN"
+	ActivityTypeImpersonation     ActivityType = "IMPERSONATION"
+	ActivityTypeWarning           ActivityType = "WARNING"
+	ActivityTypeBanAppeal         ActivityType = "BAN_APPEAL"
+	ActivityTypeReport            ActivityType = "REPORT"
+	ActivityTypeDenyList          ActivityType = "DENY_LIST"
 )
 
 // Domain ошибки
@@ -245,6 +309,16 @@ func (u *User) CanAccessFeature(feature string) bool {
 	return false
 }
 
+// IsEmailVerified проверяет, подтвержден ли текущий email
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsPhoneVerified проверяет, подтвержден ли телефон
+func (u *User) IsPhoneVerified() bool {
+	return u.Phone != "" && u.PhoneVerifiedAt != nil
+}
+
 // IsAdmin проверяет, является ли пользователь администратором
 func (u *User) IsAdmin() bool {
 	return u.Role == UserRoleAdmin || u.Role == UserRoleSuperAdmin || u.Role == UserRoleModerator
@@ -290,6 +364,37 @@ func (b *BanInfo) Unban() {
 	b.BannedUntil = nil
 }
 
+// RestoredStatus возвращает статус пользователя после снятия бана.
+// У банов, выданных до сохранения прежнего статуса, это ACTIVE.
+func (b *BanInfo) RestoredStatus() UserStatus {
+	if b.PreviousStatus == "" {
+		return UserStatusActive
+	}
+	return b.PreviousStatus
+}
+
+// Status возвращает статус пользователя для бана: по категории,
+// а для бана без категории - временный или перманентный
+func (b *BanInfo) Status() UserStatus {
+	switch b.Category {
+	case BanCategoryAdmin:
+		return UserStatusBannedByAdmin
+	case BanCategorySystem:
+		return UserStatusBannedBySystem
+	case BanCategorySpam:
+		return UserStatusBannedForSpam
+	case BanCategoryAbuse:
+		return UserStatusBannedForAbuse
+	case BanCategoryFraud:
+		return UserStatusBannedForFraud
+	}
+
+	if b.BannedUntil != nil {
+		return UserStatusBannedTemporarily
+	}
+	return UserStatusBannedPermanently
+}
+
 // IsTemporaryBan проверяет, временный ли бан
 func (b *BanInfo) IsTemporaryBan() bool {
 	return b.BannedUntil != nil
@@ -312,8 +417,11 @@ func (b *BanInfo) BanDurationRemaining() *time.Duration {
 // ===== Методы для SubscriptionInfo =====
 
 // Activate активирует подписку
-func (s *SubscriptionInfo) Activate(level SubscriptionLevel, amount float64, currency string) {
-	s.Status = SubscriptionStatusActive
+func (s *SubscriptionInfo) Activate(level SubscriptionLevel, amount float64, currency string) error {
+	if err := s.TransitionTo(SubscriptionStatusActive); err != nil {
+		return err
+	}
+
 	s.Level = level
 	s.Amount = amount
 	s.Currency = currency
@@ -323,11 +431,15 @@ func (s *SubscriptionInfo) Activate(level SubscriptionLevel, amount float64, cur
 	end := time.Now().AddDate(0, 1, 0)
 	s.SubscriptionEnd = &end
 	s.NextBillingDate = &end
+	return nil
 }
 
 // Cancel отменяет подписку
-func (s *SubscriptionInfo) Cancel(reason string, immediate bool) {
-	s.Status = SubscriptionStatusCanceled
+func (s *SubscriptionInfo) Cancel(reason string, immediate bool) error {
+	if err := s.TransitionTo(SubscriptionStatusCanceled); err != nil {
+		return err
+	}
+
 	s.CancelReason = reason
 	now := time.Now()
 	s.CanceledAt = &now
@@ -337,6 +449,7 @@ func (s *SubscriptionInfo) Cancel(reason string, immediate bool) {
 	}
 
 	s.AutoRenew = false
+	return nil
 }
 
 // IsTrial проверяет, пробный ли период
@@ -367,20 +480,102 @@ func (s *SubscriptionInfo) DaysUntilExpiration() *int {
 	return &days
 }
 
-// UpdateLevel обновляет уровень подписки
-func (s *SubscriptionInfo) UpdateLevel(newLevel SubscriptionLevel, newAmount float64) {
-	oldLevel := s.Level
-	s.Level = newLevel
-	s.Amount = newAmount
+// UpdateLevel начинает смену уровня ACTIVE подписки: она переходит
+// в UPGRADING или DOWNGRADING (см. LevelChangeStatus), а новый уровень
+// и сумма ждут в PendingLevel и PendingAmount. Уровень меняется только
+// после подтверждения оплаты (см. TransitionTo).
+func (s *SubscriptionInfo) UpdateLevel(newLevel SubscriptionLevel, newAmount float64) error {
+	if newLevel == s.Level {
+		s.Amount = newAmount
+		return nil
+	}
+
+	next := s.LevelChangeStatus(newLevel)
+	if s.Status != SubscriptionStatusActive {
+		return NewInvalidSubscriptionTransitionError(s.Status, next)
+	}
+	if err := s.TransitionTo(next); err != nil {
+		return err
+	}
 
-	if newLevel != oldLevel {
-		// Обновляем статус при смене уровня
-		if newLevel == SubscriptionLevelFree {
-			s.Status = SubscriptionStatusInactive
+	s.PendingLevel = newLevel
+	s.PendingAmount = newAmount
+	return nil
+}
+
+// RequestedLevel возвращает уровень, на который переходит подписка,
+// или текущий, если смены уровня нет
+func (s *SubscriptionInfo) RequestedLevel() SubscriptionLevel {
+	if s.PendingLevel != "" {
+		return s.PendingLevel
+	}
+	return s.Level
+}
+
+// LevelChangeStatus возвращает статус перехода на уровень level:
+// UPGRADING для более высокого уровня, DOWNGRADING для более низкого
+func (s *SubscriptionInfo) LevelChangeStatus(level SubscriptionLevel) SubscriptionStatus {
+	if level.Rank() < s.Level.Rank() {
+		return SubscriptionStatusDowngrading
+	}
+	return SubscriptionStatusUpgrading
+}
+
+// Apply применяет изменения подписки. Пока оплаченный уровень действует
+// (см. HoldsLevel), сменить его можно только через UpdateLevel:
+// переданный статус должен совпадать с направлением смены уровня.
+func (s *SubscriptionInfo) Apply(update *SubscriptionUpdate) error {
+	// Повтор запрошенного уровня во время смены - не новая смена
+	levelChanged := update.Level != nil && *update.Level != s.RequestedLevel()
+	if levelChanged && s.Status.HoldsLevel() {
+		next := s.LevelChangeStatus(*update.Level)
+		if update.Status != nil && *update.Status != next {
+			return NewInvalidSubscriptionTransitionError(s.Status, *update.Status)
+		}
+		amount := s.Amount
+		if update.Amount != nil {
+			amount = *update.Amount
+		}
+		if err := s.UpdateLevel(*update.Level, amount); err != nil {
+			return err
+		}
+	} else {
+		if update.Status != nil {
+			if err := s.TransitionTo(*update.Status); err != nil {
+				return err
+			}
+		}
+		if levelChanged {
+			s.Level = *update.Level
+		}
+	}
+
+	if update.SubscriptionEnd != nil {
+		s.SubscriptionEnd = update.SubscriptionEnd
+	}
+	if update.TrialEnd != nil {
+		s.TrialEnd = update.TrialEnd
+	}
+	if update.SubscriptionID != nil {
+		s.SubscriptionID = *update.SubscriptionID
+	}
+	if update.PaymentMethod != nil {
+		s.PaymentMethod = *update.PaymentMethod
+	}
+	if update.AutoRenew != nil {
+		s.AutoRenew = *update.AutoRenew
+	}
+	if update.Amount != nil {
+		if s.PendingLevel != "" {
+			s.PendingAmount = *update.Amount
 		} else {
-			s.Status = SubscriptionStatusActive
+			s.Amount = *update.Amount
 		}
 	}
+	if update.Currency != nil {
+		s.Currency = *update.Currency
+	}
+	return nil
 }
 
 // ===== Методы для UserActivity =====
@@ -436,7 +631,8 @@ func (e *SubscriptionHistoryEntry) AddMetadata(key string, value interface{}) {
 
 // ===== Интерфейсы репозиториев =====
 
-// UserRepository определяет интерфейс для работы с хранилищем пользователей
+// UserRepository определяет интерфейс для работы с хранилищем пользователей.
+// Запросы не ограничены организацией: изоляцию обеспечивает сервис.
 type UserRepository interface {
 	// CRUD операции
 	Create(ctx context.Context, user *User) error
@@ -453,6 +649,8 @@ type UserRepository interface {
 	// Операции с баном
 	Ban(ctx context.Context, userID string, banInfo *BanInfo) error
 	Unban(ctx context.Context, userID string) error
+	BanStatistics(ctx context.Context) ([]*BanCategoryStats, error)
+	UnbanExpired(ctx context.Context, now time.Time, limit int) (map[string]*BanInfo, error)
 
 	// Операции с подписками
 	UpdateSubscription(ctx context.Context, userID string, subscription *SubscriptionInfo) error
@@ -481,6 +679,10 @@ type AuditRepository interface {
 	// История банов
 	LogBanChange(ctx context.Context, userID string, action string, details map[string]interface{}) error
 	GetBanHistory(ctx context.Context, userID string) ([]map[string]interface{}, error)
+
+	// Страйки модерации
+	AddStrike(ctx context.Context, strike *Strike) error
+	ListActiveStrikes(ctx context.Context, userID string, now time.Time) ([]*Strike, error)
 }
 
 // UserFilter фильтр для поиска пользователей
@@ -493,38 +695,123 @@ type UserFilter struct {
 	IsBanned  *bool
 	SubStatus *SubscriptionStatus
 	SubLevel  *SubscriptionLevel
+
+	BanCategory BanCategory // Только активные баны этой категории
+
+	OrganizationID string // Только участники организации, пусто - все пользователи
+}
+
+// BanCategoryStats - число действующих банов одной категории
+type BanCategoryStats struct {
+	Category  BanCategory // Пусто - баны без категории
+	Active    int64
+	Temporary int64
+	Permanent int64
 }
 
 // UserService определяет бизнес-логику работы с пользователями
 type UserService interface {
 	// CRUD операции
-	Register(user *User) (*User, error)
-	GetUser(id string) (*User, error)
-	UpdateUser(user *User) (*User, error)
-	DeleteUser(id string) error
-	ListUsers(filter *UserFilter) ([]*User, int64, error)
+	Register(ctx context.Context, user *User, ip, deviceID string) (*User, error)
+	GetUser(ctx context.Context, id string) (*User, error)
+	UpdateUser(ctx context.Context, user *User) (*User, error)
+	DeleteUser(ctx context.Context, id string) error
+	ListUsers(ctx context.Context, filter *UserFilter) ([]*User, int64, error)
 
 	// Аутентификация и авторизация
-	Authenticate(email, password string) (*User, string, error) // Возвращает пользователя и JWT токен
-	ValidateToken(token string) (*User, error)
-	ChangePassword(userID, currentPassword, newPassword string) error
-	ResetPassword(email string) error
+	Authenticate(ctx context.Context, email, password, ip, deviceID string) (*User, *TokenPair, error) // Возвращает пользователя и пару токенов
+	RefreshToken(ctx context.Context, refreshToken, ip, deviceID string) (*User, *TokenPair, error)    // Ротация refresh токена
+	VerifyMFA(ctx context.Context, mfaToken, code string) (*User, *TokenPair, error)                   // Второй шаг входа с 2FA
+	ValidateToken(ctx context.Context, token string) (*User, error)
+	Logout(ctx context.Context, accessToken, refreshToken string) error
+	RevokeAllSessions(ctx context.Context, userID string) error
+	ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error
+	VerifyEmail(ctx context.Context, token string) (*User, error)
+	ResendVerification(ctx context.Context, email string) error
+	SendPhoneOTP(ctx context.Context, userID string) (time.Time, error) // Возвращает время истечения кода
+	VerifyPhoneOTP(ctx context.Context, userID, code string) (*User, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ConfirmPasswordReset(ctx context.Context, token, newPassword string) error
+	ClearLockout(ctx context.Context, email, ip string) error // Снимает блокировку после неудачных входов
+
+	// Двухфакторная аутентификация
+	EnrollTOTP(ctx context.Context, userID string) (secret, uri string, err error)
+	ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) // Возвращает коды восстановления
+	DisableTOTP(ctx context.Context, userID, code string) error
+
+	// WebAuthn (passkeys)
+	BeginWebAuthnRegistration(ctx context.Context, userID string) (sessionID string, options []byte, err error)
+	FinishWebAuthnRegistration(ctx context.Context, userID, sessionID, name string, response []byte) (*WebAuthnCredential, error)
+	BeginWebAuthnLogin(ctx context.Context, email string) (sessionID string, options []byte, err error)
+	FinishWebAuthnLogin(ctx context.Context, sessionID string, response []byte, ip, deviceID string) (*User, *TokenPair, error)
+	ListWebAuthnCredentials(ctx context.Context, userID string) ([]*WebAuthnCredential, error)
+	RenameWebAuthnCredential(ctx context.Context, userID, credentialID, name string) (*WebAuthnCredential, error)
+	DeleteWebAuthnCredential(ctx context.Context, userID, credentialID string) error
+
+	// API ключи
+	CreateAPIKey(ctx context.Context, userID, name string, scopes []Permission, expiresAt *time.Time) (*APIKey, string, error)
+	ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID string) error
+	AuthenticateAPIKey(ctx context.Context, key string) (*Principal, error)
+
+	// Вход от имени пользователя
+	ImpersonateUser(ctx context.Context, userID, reason string) (*User, string, time.Time, error)
+	StopImpersonation(ctx context.Context) error
+
+	// Сервисные аккаунты
+	IssueServiceToken(ctx context.Context, clientID, clientSecret, audience string) (*ServiceToken, error)
 
 	// Бан-система
-	BanUser(userID, reason, bannedBy string, duration *time.Duration) (*User, error)
-	UnbanUser(userID, unbannedBy string) (*User, error)
+	BanUser(ctx context.Context, userID, reason string, category BanCategory, duration *time.Duration) (*User, error)
+	UnbanUser(ctx context.Context, userID string) (*User, error)
+	GetBanStatistics(ctx context.Context) ([]*BanCategoryStats, error)
+	IssueWarning(ctx context.Context, userID, reason string) (*WarningResult, error)
+	ListStrikes(ctx context.Context, userID string) ([]*Strike, error) // Только действующие страйки
+
+	// Апелляции на баны
+	SubmitBanAppeal(ctx context.Context, appealToken, message string) (*BanAppeal, error) // Токен из ошибки входа забаненного
+	ListBanAppeals(ctx context.Context, filter *AppealFilter) ([]*BanAppeal, int64, error)
+	AssignBanAppeal(ctx context.Context, appealID, moderatorID string) (*BanAppeal, error) // Пустой moderatorID - себе
+	ApproveBanAppeal(ctx context.Context, appealID, resolution string) (*BanAppeal, error)
+	RejectBanAppeal(ctx context.Context, appealID, resolution string) (*BanAppeal, error)
+
+	// Жалобы и очередь модерации
+	ReportUser(ctx context.Context, userID string, category ReportCategory, description string, evidence map[string]string) (*UserReport, bool, error) // bool - повторная жалоба
+	ListModerationQueue(ctx context.Context, filter *ReportFilter) ([]*UserReport, int64, error)
+	ClaimReport(ctx context.Context, reportID string) (*UserReport, error) // С жалобами заявителей
+	ResolveReport(ctx context.Context, reportID string, resolution *ReportResolution) (*UserReport, error)
+
+	// Списки запрета IP, email доменов и устройств
+	CreateDenyListEntry(ctx context.Context, kind DenyListKind, value, reason string, expiresAt *time.Time) (*DenyListEntry, error)
+	GetDenyListEntry(ctx context.Context, id string) (*DenyListEntry, error)
+	ListDenyListEntries(ctx context.Context, filter *DenyListFilter) ([]*DenyListEntry, int64, error)
+	UpdateDenyListEntry(ctx context.Context, id, reason string, expiresAt *time.Time) (*DenyListEntry, error)
+	DeleteDenyListEntry(ctx context.Context, id string) error
 
 	// Подписки
-	UpdateSubscription(userID string, subscription *SubscriptionInfo) (*User, error)
-	CancelSubscription(userID, reason string, immediate bool) (*User, error)
-	CheckSubscriptionAccess(userID string, requiredLevel SubscriptionLevel, feature string) (bool, error)
+	UpdateSubscription(ctx context.Context, userID string, update *SubscriptionUpdate) (*User, error)
+	CancelSubscription(ctx context.Context, userID, reason string, immediate bool) (*User, error)
+	CheckSubscriptionAccess(ctx context.Context, userID string, requiredLevel SubscriptionLevel, feature string) (bool, error)
+
+	// Организации
+	CreateOrganization(ctx context.Context, name, slug string) (*Organization, error)
+	GetOrganization(ctx context.Context, orgID string) (*Organization, error)
+	ListOrganizations(ctx context.Context) ([]*Organization, error)
+	ListOrganizationMembers(ctx context.Context, orgID string) ([]*OrgMember, error)
+	UpdateOrganizationMember(ctx context.Context, orgID, userID string, role OrgRole) (*OrgMember, error)
+	RemoveOrganizationMember(ctx context.Context, orgID, userID string) error
+	InviteToOrganization(ctx context.Context, orgID, email string, role OrgRole) (*OrgInvitation, error)
+	ListMyInvitations(ctx context.Context) ([]*OrgInvitation, error)
+	AcceptInvitation(ctx context.Context, orgID, invitationID string) (*OrgInvitation, error)
+	DeclineInvitation(ctx context.Context, orgID, invitationID string) (*OrgInvitation, error)
+	SwitchOrganization(ctx context.Context, orgID, ip, deviceID string) (*User, *TokenPair, error)
 
 	// Валидация
-	ValidateEmail(email string) error
-	ValidatePassword(password string) error
+	ValidateEmail(ctx context.Context, email string) error
+	ValidatePassword(ctx context.Context, password string) error
 
 	// Health check
-	HealthCheck() (bool, error)
+	HealthCheck(ctx context.Context) (bool, error)
 }
 
 // ===== Утилитарные функции =====
@@ -548,12 +835,13 @@ func NewUser(email, password, name string) *User {
 }
 
 // NewBanInfo создает новую информацию о бане
-func NewBanInfo(reason, bannedBy string, duration *time.Duration) *BanInfo {
+func NewBanInfo(reason, bannedBy string, category BanCategory, duration *time.Duration) *BanInfo {
 	banInfo := &BanInfo{
 		IsBanned: true,
 		BannedAt: time.Now(),
 		Reason:   reason,
 		BannedBy: bannedBy,
+		Category: category,
 	}
 
 	if duration != nil {
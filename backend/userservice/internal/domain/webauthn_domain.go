@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// WebAuthnCredential - ключ доступа (passkey или аппаратный ключ) пользователя
+type WebAuthnCredential struct {
+	ID              string // Внутренний ID записи
+	UserID          string
+	CredentialID    []byte // ID, выданный аутентификатором
+	PublicKey       []byte // Открытый ключ в формате COSE
+	AttestationType string
+	Transports      []string // usb, nfc, ble, internal, hybrid
+	AAGUID          []byte   // Модель аутентификатора
+	SignCount       uint32   // Счетчик подписей, рост проверяется при каждом входе
+	UserVerified    bool
+	BackupEligible  bool // Ключ может синхронизироваться между устройствами
+	BackupState     bool
+	Name            string // Название, заданное пользователем
+	CreatedAt       time.Time
+	LastUsedAt      *time.Time
+}
+
+// WebAuthnSession - состояние незавершенной церемонии регистрации или входа
+type WebAuthnSession struct {
+	ID        string
+	UserID    string // Пусто для входа по passkey без указания email
+	Ceremony  WebAuthnCeremony
+	Data      []byte // Сериализованные данные сессии (challenge и т.п.)
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// WebAuthnCeremony - тип церемонии WebAuthn
+type WebAuthnCeremony string
+
+const (
+	WebAuthnCeremonyRegistration WebAuthnCeremony = "registration"
+	WebAuthnCeremonyLogin        WebAuthnCeremony = "login"
+)
+
+// NewWebAuthnSession создает сессию церемонии
+func NewWebAuthnSession(userID string, ceremony WebAuthnCeremony, data []byte, ttl time.Duration) *WebAuthnSession {
+	now := time.Now()
+
+	return &WebAuthnSession{
+		ID:        GenerateUUID(),
+		UserID:    userID,
+		Ceremony:  ceremony,
+		Data:      data,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+// WebAuthnRepository определяет хранилище ключей WebAuthn и сессий церемоний
+type WebAuthnRepository interface {
+	CreateCredential(ctx context.Context, cred *WebAuthnCredential) error
+	ListCredentials(ctx context.Context, userID string) ([]*WebAuthnCredential, error)
+	FindCredential(ctx context.Context, credentialID []byte) (*WebAuthnCredential, error)
+	UpdateCredentialUsage(ctx context.Context, id string, signCount uint32, backupState bool, usedAt time.Time) error
+	RenameCredential(ctx context.Context, userID, id, name string) (*WebAuthnCredential, error)
+	DeleteCredential(ctx context.Context, userID, id string) error
+
+	CreateSession(ctx context.Context, session *WebAuthnSession) error
+
+	// ConsumeSession атомарно удаляет и возвращает действующую сессию,
+	// чтобы один challenge нельзя было использовать дважды
+	ConsumeSession(ctx context.Context, id string, ceremony WebAuthnCeremony) (*WebAuthnSession, error)
+}
@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"userservice/internal/domain"
+)
+
+// LogMailer пишет письма в лог сервиса. Только для локальной разработки.
+type LogMailer struct {
+	from string
+}
+
+// NewLogMailer создает отправителя писем в лог
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+// Send выводит письмо в лог
+func (m *LogMailer) Send(ctx context.Context, msg *domain.EmailMessage) error {
+	log.Printf("Email from=%s to=%s subject=%q\n%s", m.from, msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// FileMailer сохраняет письма в каталог в формате .eml,
+// чтобы их можно было открыть почтовым клиентом
+type FileMailer struct {
+	from string
+	dir  string
+}
+
+// NewFileMailer создает отправителя писем в каталог
+func NewFileMailer(from, dir string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create outbox dir: %w", err)
+	}
+
+	return &FileMailer{from: from, dir: dir}, nil
+}
+
+// Send записывает письмо в файл
+func (m *FileMailer) Send(ctx context.Context, msg *domain.EmailMessage) error {
+	now := time.Now()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405.000000000"), domain.GenerateUUID())
+	if err := os.WriteFile(filepath.Join(m.dir, name), []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"userservice/internal/domain"
+)
+
+// LogSMSSender пишет SMS в лог сервиса. Заглушка для локальной разработки,
+// пока не подключен SMS провайдер.
+type LogSMSSender struct {
+	from string
+}
+
+// NewLogSMSSender создает отправителя SMS в лог
+func NewLogSMSSender(from string) *LogSMSSender {
+	return &LogSMSSender{from: from}
+}
+
+// Send выводит SMS в лог
+func (s *LogSMSSender) Send(ctx context.Context, msg *domain.SMSMessage) error {
+	log.Printf("SMS from=%s to=%s: %s", s.from, msg.To, msg.Body)
+	return nil
+}
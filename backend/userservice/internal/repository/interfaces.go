@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"userservice/internal/domain"
 )
 
@@ -22,6 +23,8 @@ type UserRepository interface {
 	// Операции с баном
 	Ban(ctx context.Context, userID string, banInfo *domain.BanInfo) error
 	Unban(ctx context.Context, userID string) error
+	BanStatistics(ctx context.Context) ([]*domain.BanCategoryStats, error)
+	UnbanExpired(ctx context.Context, now time.Time, limit int) (map[string]*domain.BanInfo, error)
 
 	// Операции с подписками
 	UpdateSubscription(ctx context.Context, userID string, subscription *domain.SubscriptionInfo) error
@@ -54,6 +57,144 @@ type AuditRepository interface {
 	LogBanChange(ctx context.Context, userID string, action string, details map[string]interface{}) error
 	GetBanHistory(ctx context.Context, userID string) ([]map[string]interface{}, error)
 
+	// Страйки модерации
+	AddStrike(ctx context.Context, strike *domain.Strike) error
+	ListActiveStrikes(ctx context.Context, userID string, now time.Time) ([]*domain.Strike, error)
+
 	// Health check
 	Ping(ctx context.Context) error
 }
+
+// RefreshTokenRepository - хранилище refresh токенов (в PostgreSQL)
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *domain.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	MarkRotated(ctx context.Context, id, replacedBy string) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
+
+// TokenRevocationStore - список отозванных access токенов (в Redis или в памяти)
+type TokenRevocationStore interface {
+	RevokeToken(ctx context.Context, jti string, ttl time.Duration) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	RevokeAllForUser(ctx context.Context, userID string, at time.Time, ttl time.Duration) error
+	RevokedBefore(ctx context.Context, userID string) (time.Time, error)
+}
+
+// LoginAttemptStore - счетчики неудачных входов и блокировки (в Redis или в памяти)
+type LoginAttemptStore interface {
+	IncrementFailures(ctx context.Context, key string, window time.Duration) (int, error)
+	ResetFailures(ctx context.Context, key string) error
+	IncrementLockouts(ctx context.Context, key string, ttl time.Duration) (int, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	LockedUntil(ctx context.Context, key string) (time.Time, error)
+	Clear(ctx context.Context, key string) error
+}
+
+// PasswordResetRepository - хранилище токенов сброса пароля (в PostgreSQL)
+type PasswordResetRepository interface {
+	Create(ctx context.Context, token *domain.PasswordResetToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error)
+	MarkUsed(ctx context.Context, id string) (bool, error)
+	InvalidateForUser(ctx context.Context, userID string) error
+}
+
+// PhoneOTPRepository - хранилище кодов подтверждения телефона (в PostgreSQL)
+type PhoneOTPRepository interface {
+	Create(ctx context.Context, otp *domain.PhoneOTP) error
+	FindLatest(ctx context.Context, userID string) (*domain.PhoneOTP, error)
+	IncrementAttempts(ctx context.Context, id string) (int, error)
+	MarkConsumed(ctx context.Context, id string) (bool, error)
+	InvalidateForUser(ctx context.Context, userID string) error
+}
+
+// MFARepository - хранилище настроек 2FA и кодов восстановления (в PostgreSQL)
+type MFARepository interface {
+	SaveTOTP(ctx context.Context, cred *domain.TOTPCredential) error
+	FindTOTP(ctx context.Context, userID string) (*domain.TOTPCredential, error)
+	ConfirmTOTP(ctx context.Context, userID string, step int64) error
+	DeleteTOTP(ctx context.Context, userID string) error
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+}
+
+// WebAuthnRepository - хранилище ключей WebAuthn и сессий церемоний (в PostgreSQL)
+type WebAuthnRepository interface {
+	CreateCredential(ctx context.Context, cred *domain.WebAuthnCredential) error
+	ListCredentials(ctx context.Context, userID string) ([]*domain.WebAuthnCredential, error)
+	FindCredential(ctx context.Context, credentialID []byte) (*domain.WebAuthnCredential, error)
+	UpdateCredentialUsage(ctx context.Context, id string, signCount uint32, backupState bool, usedAt time.Time) error
+	RenameCredential(ctx context.Context, userID, id, name string) (*domain.WebAuthnCredential, error)
+	DeleteCredential(ctx context.Context, userID, id string) error
+	CreateSession(ctx context.Context, session *domain.WebAuthnSession) error
+	ConsumeSession(ctx context.Context, id string, ceremony domain.WebAuthnCeremony) (*domain.WebAuthnSession, error)
+}
+
+// PasswordHistoryRepository - прежние хеши паролей (в PostgreSQL)
+type PasswordHistoryRepository interface {
+	Add(ctx context.Context, userID, passwordHash string, keep int) error
+	List(ctx context.Context, userID string, limit int) ([]string, error)
+}
+
+// OrganizationRepository - организации, участники и приглашения (в PostgreSQL)
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *domain.Organization, owner *domain.OrgMember) error
+	FindByID(ctx context.Context, id string) (*domain.Organization, error)
+	ListForUser(ctx context.Context, userID string) ([]*domain.Organization, error)
+	FindMember(ctx context.Context, orgID, userID string) (*domain.OrgMember, error)
+	ListMembers(ctx context.Context, orgID string) ([]*domain.OrgMember, error)
+	UpdateMemberRole(ctx context.Context, orgID, userID string, role domain.OrgRole) error
+	RemoveMember(ctx context.Context, orgID, userID string) error
+	CountOwners(ctx context.Context, orgID string) (int, error)
+	CreateInvitation(ctx context.Context, inv *domain.OrgInvitation) error
+	FindInvitation(ctx context.Context, orgID, id string) (*domain.OrgInvitation, error)
+	ListInvitationsForEmail(ctx context.Context, email string) ([]*domain.OrgInvitation, error)
+	RespondInvitation(ctx context.Context, inv *domain.OrgInvitation, userID string, status domain.InvitationStatus, at time.Time) (bool, error)
+}
+
+// APIKeyRepository - API ключи машинных клиентов (в PostgreSQL)
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	FindByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error)
+	ListByUser(ctx context.Context, userID string) ([]*domain.APIKey, error)
+	Revoke(ctx context.Context, userID, id string, at time.Time) error
+	UpdateLastUsed(ctx context.Context, id string, at time.Time) error
+}
+
+// BanAppealRepository - апелляции на баны (в PostgreSQL)
+type BanAppealRepository interface {
+	Create(ctx context.Context, appeal *domain.BanAppeal) error
+	FindByID(ctx context.Context, id string) (*domain.BanAppeal, error)
+	List(ctx context.Context, filter *domain.AppealFilter) ([]*domain.BanAppeal, int64, error)
+	Assign(ctx context.Context, id, moderatorID string, at time.Time) (bool, error)
+	Resolve(ctx context.Context, id string, status domain.AppealStatus, moderatorID, resolution string, at time.Time) (bool, error)
+}
+
+// ReportRepository - жалобы на пользователей и очередь модерации (в PostgreSQL)
+type ReportRepository interface {
+	Submit(ctx context.Context, report *domain.UserReport, entry *domain.ReportEntry) (*domain.UserReport, bool, error)
+	FindByID(ctx context.Context, id string) (*domain.UserReport, error)
+	ListEntries(ctx context.Context, reportID string) ([]*domain.ReportEntry, error)
+	ListQueue(ctx context.Context, filter *domain.ReportFilter) ([]*domain.UserReport, int64, error)
+	Claim(ctx context.Context, id, moderatorID string, at time.Time) (bool, error)
+	Resolve(ctx context.Context, report *domain.UserReport) (bool, error)
+	SetOutcome(ctx context.Context, report *domain.UserReport) error
+	Reopen(ctx context.Context, id, moderatorID string) error
+}
+
+// DenyListRepository - списки запрета IP, email доменов и устройств (в PostgreSQL)
+type DenyListRepository interface {
+	Create(ctx context.Context, entry *domain.DenyListEntry) error
+	FindByID(ctx context.Context, id string) (*domain.DenyListEntry, error)
+	List(ctx context.Context, filter *domain.DenyListFilter) ([]*domain.DenyListEntry, int64, error)
+	Update(ctx context.Context, entry *domain.DenyListEntry) error
+	Delete(ctx context.Context, id string) error
+	Match(ctx context.Context, check *domain.DenyCheck, now time.Time) (*domain.DenyListEntry, error)
+}
+
+// ServiceAccountRepository - сервисные аккаунты (из конфигурации)
+type ServiceAccountRepository interface {
+	FindByClientID(ctx context.Context, clientID string) (*domain.ServiceAccount, error)
+}
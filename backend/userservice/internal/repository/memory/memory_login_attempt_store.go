@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// cleanupInterval - как часто из хранилища удаляются просроченные записи.
+// Между чистками просроченная запись проверяется при обращении к ее ключу.
+const cleanupInterval = time.Minute
+
+// MemoryLoginAttemptStore - счетчики неудачных входов и блокировки в памяти процесса.
+// Используется, когда Redis недоступен; не разделяется между репликами.
+type MemoryLoginAttemptStore struct {
+	mu          sync.Mutex
+	failures    map[string]counterEntry
+	lockouts    map[string]counterEntry
+	locked      map[string]time.Time // key -> конец блокировки
+	lastCleanup time.Time
+}
+
+type counterEntry struct {
+	count     int
+	expiresAt time.Time
+}
+
+// NewMemoryLoginAttemptStore создает новое хранилище счетчиков входа
+func NewMemoryLoginAttemptStore() *MemoryLoginAttemptStore {
+	return &MemoryLoginAttemptStore{
+		failures: make(map[string]counterEntry),
+		lockouts: make(map[string]counterEntry),
+		locked:   make(map[string]time.Time),
+	}
+}
+
+// IncrementFailures увеличивает счетчик неудачных входов
+func (s *MemoryLoginAttemptStore) IncrementFailures(ctx context.Context, key string, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.maybeCleanup(now)
+	entry, ok := s.failures[key]
+	if !ok || now.After(entry.expiresAt) {
+		entry = counterEntry{expiresAt: now.Add(window)}
+	}
+	entry.count++
+	s.failures[key] = entry
+
+	return entry.count, nil
+}
+
+// ResetFailures сбрасывает счетчик неудачных входов
+func (s *MemoryLoginAttemptStore) ResetFailures(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, key)
+	return nil
+}
+
+// IncrementLockouts увеличивает счетчик блокировок подряд
+func (s *MemoryLoginAttemptStore) IncrementLockouts(ctx context.Context, key string, ttl time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.maybeCleanup(now)
+	entry, ok := s.lockouts[key]
+	if !ok || now.After(entry.expiresAt) {
+		entry = counterEntry{}
+	}
+	entry.count++
+	entry.expiresAt = now.Add(ttl)
+	s.lockouts[key] = entry
+
+	return entry.count, nil
+}
+
+// Lock блокирует вход до until
+func (s *MemoryLoginAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.locked[key] = until
+	return nil
+}
+
+// LockedUntil возвращает время окончания блокировки
+func (s *MemoryLoginAttemptStore) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	until, ok := s.locked[key]
+	if !ok || time.Now().After(until) {
+		return time.Time{}, nil
+	}
+
+	return until, nil
+}
+
+// Clear снимает блокировку и сбрасывает счетчики
+func (s *MemoryLoginAttemptStore) Clear(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, key)
+	delete(s.lockouts, key)
+	delete(s.locked, key)
+	return nil
+}
+
+// maybeCleanup удаляет просроченные записи не чаще cleanupInterval, чтобы
+// неудачный вход не обходил все счетчики. Вызывается под блокировкой.
+func (s *MemoryLoginAttemptStore) maybeCleanup(now time.Time) {
+	if now.Sub(s.lastCleanup) < cleanupInterval {
+		return
+	}
+	s.lastCleanup = now
+
+	for key, entry := range s.failures {
+		if now.After(entry.expiresAt) {
+			delete(s.failures, key)
+		}
+	}
+
+	for key, entry := range s.lockouts {
+		if now.After(entry.expiresAt) {
+			delete(s.lockouts, key)
+		}
+	}
+
+	for key, until := range s.locked {
+		if now.After(until) {
+			delete(s.locked, key)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryLoginAttemptStoreExpiresCounters(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryLoginAttemptStore()
+
+	if _, err := s.IncrementFailures(ctx, "expired", time.Nanosecond); err != nil {
+		t.Fatalf("IncrementFailures: %v", err)
+	}
+	if _, err := s.IncrementLockouts(ctx, "expired", time.Nanosecond); err != nil {
+		t.Fatalf("IncrementLockouts: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	// Чистка уже была при первом обращении, просроченный ключ проверяется сам
+	if got, _ := s.IncrementFailures(ctx, "expired", time.Minute); got != 1 {
+		t.Errorf("failures after window = %d, want 1", got)
+	}
+	if got, _ := s.IncrementLockouts(ctx, "expired", time.Minute); got != 1 {
+		t.Errorf("lockouts after ttl = %d, want 1", got)
+	}
+	if got, _ := s.IncrementFailures(ctx, "expired", time.Minute); got != 2 {
+		t.Errorf("failures within window = %d, want 2", got)
+	}
+
+	// Просроченные записи других ключей удаляются очередной чисткой
+	s.failures["stale"] = counterEntry{count: 5, expiresAt: time.Now().Add(-time.Second)}
+	s.lastCleanup = time.Now().Add(-cleanupInterval)
+	if _, err := s.IncrementFailures(ctx, "other", time.Minute); err != nil {
+		t.Fatalf("IncrementFailures: %v", err)
+	}
+	if _, ok := s.failures["stale"]; ok {
+		t.Error("stale counter is not removed by cleanup")
+	}
+}
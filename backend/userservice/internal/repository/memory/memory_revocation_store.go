@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryRevocationStore - список отозванных токенов в памяти процесса.
+// Используется, когда Redis недоступен; не разделяется между репликами.
+type MemoryRevocationStore struct {
+	mu            sync.RWMutex
+	tokens        map[string]time.Time // jti -> когда запись можно удалить
+	revokedBefore map[string]revokedBeforeEntry
+}
+
+type revokedBeforeEntry struct {
+	at        time.Time
+	expiresAt time.Time
+}
+
+// NewMemoryRevocationStore создает новое хранилище отозванных токенов
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{
+		tokens:        make(map[string]time.Time),
+		revokedBefore: make(map[string]revokedBeforeEntry),
+	}
+}
+
+// RevokeToken отзывает токен по jti
+func (s *MemoryRevocationStore) RevokeToken(ctx context.Context, jti string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanup()
+	s.tokens[jti] = time.Now().Add(ttl)
+	return nil
+}
+
+// IsTokenRevoked проверяет, отозван ли токен
+func (s *MemoryRevocationStore) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	expiresAt, ok := s.tokens[jti]
+	return ok && time.Now().Before(expiresAt), nil
+}
+
+// RevokeAllForUser отзывает все токены пользователя, выпущенные до at
+func (s *MemoryRevocationStore) RevokeAllForUser(ctx context.Context, userID string, at time.Time, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanup()
+	s.revokedBefore[userID] = revokedBeforeEntry{at: at, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// RevokedBefore возвращает момент последнего отзыва всех токенов пользователя
+func (s *MemoryRevocationStore) RevokedBefore(ctx context.Context, userID string) (time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.revokedBefore[userID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return time.Time{}, nil
+	}
+
+	return entry.at, nil
+}
+
+// cleanup удаляет просроченные записи. Вызывается под блокировкой на запись.
+func (s *MemoryRevocationStore) cleanup() {
+	now := time.Now()
+
+	for jti, expiresAt := range s.tokens {
+		if now.After(expiresAt) {
+			delete(s.tokens, jti)
+		}
+	}
+
+	for userID, entry := range s.revokedBefore {
+		if now.After(entry.expiresAt) {
+			delete(s.revokedBefore, userID)
+		}
+	}
+}
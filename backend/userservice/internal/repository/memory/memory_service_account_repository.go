@@ -0,0 +1,32 @@
+package memory
+
+import (
+	"context"
+	"userservice/internal/domain"
+)
+
+// MemoryServiceAccountRepository - сервисные аккаунты из конфигурации.
+// Список задается при старте и не меняется во время работы.
+type MemoryServiceAccountRepository struct {
+	accounts map[string]*domain.ServiceAccount
+}
+
+// NewMemoryServiceAccountRepository создает хранилище из списка аккаунтов
+func NewMemoryServiceAccountRepository(accounts []*domain.ServiceAccount) *MemoryServiceAccountRepository {
+	r := &MemoryServiceAccountRepository{
+		accounts: make(map[string]*domain.ServiceAccount, len(accounts)),
+	}
+	for _, a := range accounts {
+		r.accounts[a.ClientID] = a
+	}
+	return r
+}
+
+// FindByClientID находит сервисный аккаунт по client_id
+func (r *MemoryServiceAccountRepository) FindByClientID(ctx context.Context, clientID string) (*domain.ServiceAccount, error) {
+	a, ok := r.accounts[clientID]
+	if !ok {
+		return nil, domain.ErrServiceAccountNotFound
+	}
+	return a, nil
+}
@@ -41,6 +41,8 @@ type UserMetadataDocument struct {
 type UserActivityDocument struct {
 	ID           string         `bson:"_id,omitempty"`
 	UserID       string         `bson:"user_id"`
+	ActorID      string         `bson:"actor_id,omitempty"`
+	ActorKind    string         `bson:"actor_kind,omitempty"`
 	ActivityType string         `bson:"activity_type"`
 	IPAddress    string         `bson:"ip_address,omitempty"`
 	UserAgent    string         `bson:"user_agent,omitempty"`
@@ -71,10 +73,21 @@ type BanHistoryDocument struct {
 	Reason    string                 `bson:"reason,omitempty"`
 	Duration  *time.Duration         `bson:"duration,omitempty"`
 	BannedBy  string                 `bson:"banned_by"`
+	ActorID   string                 `bson:"actor_id,omitempty"`
 	Details   map[string]interface{} `bson:"details,omitempty"`
 	CreatedAt time.Time              `bson:"created_at"`
 }
 
+// StrikeDocument - документ страйка модерации
+type StrikeDocument struct {
+	ID        string    `bson:"_id"`
+	UserID    string    `bson:"user_id"`
+	Reason    string    `bson:"reason"`
+	IssuedBy  string    `bson:"issued_by"`
+	IssuedAt  time.Time `bson:"issued_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
 // SaveMetadata сохраняет метаданные пользователя
 func (r *MongoUserRepository) SaveMetadata(ctx context.Context, userID string, metadata map[string]string) error {
 	collection := r.db.Collection("user_metadata")
@@ -161,6 +174,8 @@ func (r *MongoUserRepository) LogActivity(ctx context.Context, activity *domain.
 	doc := &UserActivityDocument{
 		ID:           activity.ID,
 		UserID:       activity.UserID,
+		ActorID:      activity.ActorID,
+		ActorKind:    string(activity.ActorKind),
 		ActivityType: string(activity.ActivityType),
 		IPAddress:    activity.IPAddress,
 		UserAgent:    activity.UserAgent,
@@ -209,6 +224,8 @@ func (r *MongoUserRepository) GetUserActivities(ctx context.Context, userID stri
 		activity := &domain.UserActivity{
 			ID:           doc.ID,
 			UserID:       doc.UserID,
+			ActorID:      doc.ActorID,
+			ActorKind:    domain.PrincipalKind(doc.ActorKind),
 			ActivityType: domain.ActivityType(doc.ActivityType),
 			IPAddress:    doc.IPAddress,
 			UserAgent:    doc.UserAgent,
@@ -296,6 +313,7 @@ func (r *MongoUserRepository) LogBanChange(ctx context.Context, userID string, a
 		Action:    action,
 		Reason:    getString(details, "reason"),
 		BannedBy:  getString(details, "banned_by"),
+		ActorID:   getString(details, "actor_id"),
 		Details:   details,
 		CreatedAt: time.Now(),
 	}
@@ -337,6 +355,7 @@ func (r *MongoUserRepository) GetBanHistory(ctx context.Context, userID string)
 			"action":     doc.Action,
 			"reason":     doc.Reason,
 			"banned_by":  doc.BannedBy,
+			"actor_id":   doc.ActorID,
 			"duration":   doc.Duration,
 			"details":    doc.Details,
 			"created_at": doc.CreatedAt,
@@ -348,6 +367,63 @@ func (r *MongoUserRepository) GetBanHistory(ctx context.Context, userID string)
 	return history, nil
 }
 
+// AddStrike сохраняет страйк модерации
+func (r *MongoUserRepository) AddStrike(ctx context.Context, strike *domain.Strike) error {
+	collection := r.db.Collection("user_strikes")
+
+	doc := &StrikeDocument{
+		ID:        strike.ID,
+		UserID:    strike.UserID,
+		Reason:    strike.Reason,
+		IssuedBy:  strike.IssuedBy,
+		IssuedAt:  strike.IssuedAt,
+		ExpiresAt: strike.ExpiresAt,
+	}
+
+	_, err := collection.InsertOne(ctx, doc)
+	if err != nil {
+		return fmt.Errorf("failed to add strike: %w", err)
+	}
+
+	return nil
+}
+
+// ListActiveStrikes получает несгоревшие к моменту now страйки пользователя
+func (r *MongoUserRepository) ListActiveStrikes(ctx context.Context, userID string, now time.Time) ([]*domain.Strike, error) {
+	collection := r.db.Collection("user_strikes")
+
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "expires_at", Value: bson.D{{Key: "$gt", Value: now}}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "issued_at", Value: -1}})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find strikes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var strikes []*domain.Strike
+	for cursor.Next(ctx) {
+		var doc StrikeDocument
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+
+		strikes = append(strikes, &domain.Strike{
+			ID:        doc.ID,
+			UserID:    doc.UserID,
+			Reason:    doc.Reason,
+			IssuedBy:  doc.IssuedBy,
+			IssuedAt:  doc.IssuedAt,
+			ExpiresAt: doc.ExpiresAt,
+		})
+	}
+
+	return strikes, nil
+}
+
 // Ping проверяет соединение с MongoDB
 func (r *MongoUserRepository) Ping(ctx context.Context) error {
 	return r.client.Ping(ctx, nil)
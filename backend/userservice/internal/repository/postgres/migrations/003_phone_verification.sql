@@ -0,0 +1,16 @@
+-- Подтверждение телефона
+ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMPTZ;
+
+-- Одноразовые SMS коды (хранятся только хеши)
+CREATE TABLE IF NOT EXISTS phone_otp_codes (
+    id          UUID PRIMARY KEY,
+    user_id     UUID NOT NULL,
+    phone       VARCHAR(16) NOT NULL,
+    code_hash   VARCHAR(64) NOT NULL,
+    attempts    INTEGER NOT NULL DEFAULT 0,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    consumed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_phone_otp_codes_user_id ON phone_otp_codes (user_id, created_at DESC);
@@ -0,0 +1,19 @@
+-- TOTP секреты (зашифрованы ключом сервиса)
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id          UUID PRIMARY KEY,
+    secret_encrypted TEXT NOT NULL,
+    confirmed_at     TIMESTAMPTZ,
+    last_used_step   BIGINT NOT NULL DEFAULT 0,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Одноразовые коды восстановления (хранятся только хеши)
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id         UUID PRIMARY KEY,
+    user_id    UUID NOT NULL,
+    code_hash  VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    used_at    TIMESTAMPTZ,
+    UNIQUE (user_id, code_hash)
+);
@@ -0,0 +1,31 @@
+-- Ключи WebAuthn (passkeys и аппаратные ключи)
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id               UUID PRIMARY KEY,
+    user_id          UUID NOT NULL,
+    credential_id    BYTEA NOT NULL UNIQUE,
+    public_key       BYTEA NOT NULL,
+    attestation_type VARCHAR(32) NOT NULL DEFAULT '',
+    transports       TEXT[] NOT NULL DEFAULT '{}',
+    aaguid           BYTEA,
+    sign_count       BIGINT NOT NULL DEFAULT 0,
+    user_verified    BOOLEAN NOT NULL DEFAULT FALSE,
+    backup_eligible  BOOLEAN NOT NULL DEFAULT FALSE,
+    backup_state     BOOLEAN NOT NULL DEFAULT FALSE,
+    name             VARCHAR(255) NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at     TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthn_credentials_user_id ON webauthn_credentials (user_id);
+
+-- Незавершенные церемонии регистрации и входа
+CREATE TABLE IF NOT EXISTS webauthn_sessions (
+    id         UUID PRIMARY KEY,
+    user_id    UUID,
+    ceremony   VARCHAR(16) NOT NULL,
+    data       BYTEA NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthn_sessions_expires_at ON webauthn_sessions (expires_at);
@@ -0,0 +1,9 @@
+-- Прежние хеши паролей для запрета повторного использования
+CREATE TABLE IF NOT EXISTS password_history (
+    id            UUID PRIMARY KEY,
+    user_id       UUID NOT NULL,
+    password_hash TEXT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_history_user_id ON password_history (user_id, created_at DESC);
@@ -0,0 +1,38 @@
+-- Организации (тенанты)
+CREATE TABLE IF NOT EXISTS organizations (
+    id         UUID PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    slug       VARCHAR(64) NOT NULL UNIQUE,
+    owner_id   UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Участники организаций с ролью внутри организации
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id UUID NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    user_id         UUID NOT NULL,
+    role            VARCHAR(16) NOT NULL,
+    joined_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members (user_id);
+
+-- Приглашения по email
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id              UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    email           VARCHAR(255) NOT NULL,
+    role            VARCHAR(16) NOT NULL,
+    invited_by      UUID NOT NULL,
+    status          VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    expires_at      TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    responded_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_email ON organization_invitations (email, status);
+
+-- Организация, в контексте которой выдано семейство refresh токенов
+ALTER TABLE IF EXISTS refresh_tokens ADD COLUMN IF NOT EXISTS tenant_id UUID;
@@ -0,0 +1,15 @@
+-- API ключи (personal access tokens) для машинных клиентов
+CREATE TABLE IF NOT EXISTS api_keys (
+    id           UUID PRIMARY KEY,
+    user_id      UUID NOT NULL,
+    name         VARCHAR(255) NOT NULL DEFAULT '',
+    prefix       VARCHAR(32) NOT NULL UNIQUE,
+    key_hash     VARCHAR(64) NOT NULL,
+    scopes       TEXT[] NOT NULL DEFAULT '{}',
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys (user_id);
@@ -0,0 +1,9 @@
+-- Срок временного бана отдельной колонкой, чтобы истекшие баны снимались по индексу
+ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS banned_until TIMESTAMPTZ;
+
+UPDATE users SET
+    banned_until = (ban_info->>'BannedUntil')::timestamptz,
+    status = 'BANNED_TEMPORARILY'
+WHERE is_banned AND ban_info->>'BannedUntil' IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_banned_until ON users (banned_until) WHERE is_banned;
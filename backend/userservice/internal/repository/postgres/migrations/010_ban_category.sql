@@ -0,0 +1,7 @@
+-- Категория бана для фильтрации и статистики модерации
+ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS ban_category VARCHAR(20);
+
+UPDATE users SET ban_category = ban_info->>'Category'
+WHERE is_banned AND COALESCE(ban_info->>'Category', '') != '';
+
+CREATE INDEX IF NOT EXISTS idx_users_ban_category ON users (ban_category) WHERE is_banned;
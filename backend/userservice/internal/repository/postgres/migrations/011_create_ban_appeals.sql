@@ -0,0 +1,18 @@
+-- Апелляции на баны, не больше одной на каждый бан
+CREATE TABLE IF NOT EXISTS ban_appeals (
+    id          UUID PRIMARY KEY,
+    user_id     UUID NOT NULL,
+    banned_at   TIMESTAMPTZ NOT NULL,
+    message     TEXT NOT NULL,
+    status      VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    assigned_to UUID,
+    resolved_by UUID,
+    resolution  TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    UNIQUE (user_id, banned_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ban_appeals_queue ON ban_appeals (status, created_at);
+CREATE INDEX IF NOT EXISTS idx_ban_appeals_assigned_to ON ban_appeals (assigned_to) WHERE assigned_to IS NOT NULL;
@@ -0,0 +1,34 @@
+-- Жалобы на пользователей: одна открытая жалоба на пользователя в каждой категории
+CREATE TABLE IF NOT EXISTS user_reports (
+    id               UUID PRIMARY KEY,
+    reported_user_id UUID NOT NULL,
+    category         VARCHAR(16) NOT NULL,
+    status           VARCHAR(16) NOT NULL DEFAULT 'OPEN',
+    report_count     INT NOT NULL DEFAULT 0,
+    priority         INT NOT NULL DEFAULT 0,
+    claimed_by       UUID,
+    claimed_at       TIMESTAMPTZ,
+    action           VARCHAR(16),
+    resolution       TEXT NOT NULL DEFAULT '',
+    resolved_by      UUID,
+    resolved_at      TIMESTAMPTZ,
+    banned_at        TIMESTAMPTZ,
+    strike_id        VARCHAR(64),
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_reports_open
+    ON user_reports (reported_user_id, category) WHERE status IN ('OPEN', 'CLAIMED');
+CREATE INDEX IF NOT EXISTS idx_user_reports_queue
+    ON user_reports (priority DESC, created_at) WHERE status IN ('OPEN', 'CLAIMED');
+
+-- Жалобы отдельных заявителей, повторная жалоба того же заявителя не учитывается
+CREATE TABLE IF NOT EXISTS user_report_entries (
+    report_id   UUID NOT NULL REFERENCES user_reports (id) ON DELETE CASCADE,
+    reporter_id UUID NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    evidence    JSONB,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (report_id, reporter_id)
+);
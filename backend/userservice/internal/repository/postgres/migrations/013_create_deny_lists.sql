@@ -0,0 +1,17 @@
+-- Списки запрета регистрации и входа: IP диапазоны, email домены и устройства
+CREATE TABLE IF NOT EXISTS deny_list_entries (
+    id          UUID PRIMARY KEY,
+    kind        VARCHAR(16) NOT NULL,
+    value       VARCHAR(255) NOT NULL,
+    network     CIDR,  -- Заполняется только для kind = 'IP'
+    reason      TEXT NOT NULL DEFAULT '',
+    created_by  VARCHAR(128) NOT NULL DEFAULT '',  -- ID пользователя или сервисного аккаунта
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ,
+    UNIQUE (kind, value)
+);
+
+-- Поиск диапазона, содержащего адрес (network >>= ip)
+CREATE INDEX IF NOT EXISTS idx_deny_list_entries_network ON deny_list_entries USING gist (network inet_ops)
+    WHERE network IS NOT NULL;
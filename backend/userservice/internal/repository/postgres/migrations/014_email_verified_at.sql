@@ -0,0 +1,4 @@
+-- Время подтверждения email. Статус не подходит: без обязательного
+-- подтверждения новые аккаунты сразу активны. Существующие адреса не
+-- помечаются, их владельцы подтверждают адрес через ResendVerification.
+ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
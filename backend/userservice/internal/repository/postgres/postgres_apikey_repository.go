@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgresAPIKeyRepository - хранилище API ключей в PostgreSQL
+type PostgresAPIKeyRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresAPIKeyRepository создает новый репозиторий API ключей
+func NewPostgresAPIKeyRepository(db *sqlx.DB) *PostgresAPIKeyRepository {
+	return &PostgresAPIKeyRepository{db: db}
+}
+
+// APIKeyDBModel - модель API ключа в базе данных
+type APIKeyDBModel struct {
+	ID         string         `db:"id"`
+	UserID     string         `db:"user_id"`
+	Name       string         `db:"name"`
+	Prefix     string         `db:"prefix"`
+	KeyHash    string         `db:"key_hash"`
+	Scopes     pq.StringArray `db:"scopes"`
+	ExpiresAt  sql.NullTime   `db:"expires_at"`
+	LastUsedAt sql.NullTime   `db:"last_used_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+	RevokedAt  sql.NullTime   `db:"revoked_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *APIKeyDBModel) ToDomain() *domain.APIKey {
+	key := &domain.APIKey{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Name:      m.Name,
+		Prefix:    m.Prefix,
+		KeyHash:   m.KeyHash,
+		CreatedAt: m.CreatedAt,
+	}
+
+	for _, scope := range m.Scopes {
+		key.Scopes = append(key.Scopes, domain.Permission(scope))
+	}
+
+	if m.ExpiresAt.Valid {
+		key.ExpiresAt = &m.ExpiresAt.Time
+	}
+
+	if m.LastUsedAt.Valid {
+		key.LastUsedAt = &m.LastUsedAt.Time
+	}
+
+	if m.RevokedAt.Valid {
+		key.RevokedAt = &m.RevokedAt.Time
+	}
+
+	return key
+}
+
+// Create сохраняет новый API ключ
+func (r *PostgresAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	scopes := make(pq.StringArray, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	var expiresAt sql.NullTime
+	if key.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *key.ExpiresAt, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		key.ID,
+		key.UserID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		scopes,
+		expiresAt,
+		key.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+// FindByPrefix находит API ключ по открытой части
+func (r *PostgresAPIKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	var dbKey APIKeyDBModel
+
+	query := `SELECT * FROM api_keys WHERE prefix = $1`
+	err := r.db.GetContext(ctx, &dbKey, query, prefix)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+
+	return dbKey.ToDomain(), nil
+}
+
+// ListByUser возвращает неотозванные ключи пользователя
+func (r *PostgresAPIKeyRepository) ListByUser(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	var dbKeys []APIKeyDBModel
+
+	query := `SELECT * FROM api_keys WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at`
+	if err := r.db.SelectContext(ctx, &dbKeys, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	keys := make([]*domain.APIKey, 0, len(dbKeys))
+	for i := range dbKeys {
+		keys = append(keys, dbKeys[i].ToDomain())
+	}
+
+	return keys, nil
+}
+
+// Revoke отзывает ключ пользователя
+func (r *PostgresAPIKeyRepository) Revoke(ctx context.Context, userID, id string, at time.Time) error {
+	query := `UPDATE api_keys SET revoked_at = $1 WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, at, id, userID)
+	if err != nil {
+		if isInvalidInput(err) {
+			return domain.ErrAPIKeyNotFound
+		}
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// UpdateLastUsed сохраняет время последнего использования ключа
+func (r *PostgresAPIKeyRepository) UpdateLastUsed(ctx context.Context, id string, at time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = $1 WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, at, id); err != nil {
+		return fmt.Errorf("failed to update api key usage: %w", err)
+	}
+
+	return nil
+}
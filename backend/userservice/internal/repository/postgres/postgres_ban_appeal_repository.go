@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgresBanAppealRepository - хранилище апелляций на баны в PostgreSQL
+type PostgresBanAppealRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresBanAppealRepository создает новый репозиторий апелляций
+func NewPostgresBanAppealRepository(db *sqlx.DB) *PostgresBanAppealRepository {
+	return &PostgresBanAppealRepository{db: db}
+}
+
+// BanAppealDBModel - модель апелляции в базе данных
+type BanAppealDBModel struct {
+	ID         string         `db:"id"`
+	UserID     string         `db:"user_id"`
+	BannedAt   time.Time      `db:"banned_at"`
+	Message    string         `db:"message"`
+	Status     string         `db:"status"`
+	AssignedTo sql.NullString `db:"assigned_to"`
+	ResolvedBy sql.NullString `db:"resolved_by"`
+	Resolution string         `db:"resolution"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+	ResolvedAt sql.NullTime   `db:"resolved_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *BanAppealDBModel) ToDomain() *domain.BanAppeal {
+	appeal := &domain.BanAppeal{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		BannedAt:   m.BannedAt,
+		Message:    m.Message,
+		Status:     domain.AppealStatus(m.Status),
+		AssignedTo: m.AssignedTo.String,
+		ResolvedBy: m.ResolvedBy.String,
+		Resolution: m.Resolution,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+
+	if m.ResolvedAt.Valid {
+		appeal.ResolvedAt = &m.ResolvedAt.Time
+	}
+
+	return appeal
+}
+
+// Create сохраняет апелляцию
+func (r *PostgresBanAppealRepository) Create(ctx context.Context, appeal *domain.BanAppeal) error {
+	query := `
+		INSERT INTO ban_appeals (id, user_id, banned_at, message, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		appeal.ID,
+		appeal.UserID,
+		appeal.BannedAt,
+		appeal.Message,
+		string(appeal.Status),
+		appeal.CreatedAt,
+		appeal.UpdatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return domain.ErrAppealAlreadySubmitted
+		}
+		return fmt.Errorf("failed to create ban appeal: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID находит апелляцию по ID
+func (r *PostgresBanAppealRepository) FindByID(ctx context.Context, id string) (*domain.BanAppeal, error) {
+	var dbAppeal BanAppealDBModel
+
+	query := `SELECT * FROM ban_appeals WHERE id = $1`
+	err := r.db.GetContext(ctx, &dbAppeal, query, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows || isInvalidInput(err) {
+			return nil, domain.ErrAppealNotFound
+		}
+		return nil, fmt.Errorf("failed to find ban appeal: %w", err)
+	}
+
+	return dbAppeal.ToDomain(), nil
+}
+
+// List возвращает очередь апелляций, старые первыми
+func (r *PostgresBanAppealRepository) List(ctx context.Context, filter *domain.AppealFilter) ([]*domain.BanAppeal, int64, error) {
+	var conditions []string
+	var args []interface{}
+	argPos := 1
+
+	if filter.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argPos))
+		args = append(args, string(filter.Status))
+		argPos++
+	} else {
+		conditions = append(conditions, fmt.Sprintf("status IN ($%d, $%d)", argPos, argPos+1))
+		args = append(args, string(domain.AppealStatusPending), string(domain.AppealStatusInReview))
+		argPos += 2
+	}
+
+	if filter.AssignedTo != "" {
+		conditions = append(conditions, fmt.Sprintf("assigned_to = $%d", argPos))
+		args = append(args, filter.AssignedTo)
+		argPos++
+	}
+
+	where := strings.Join(conditions, " AND ")
+
+	var total int64
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM ban_appeals WHERE "+where, args...); err != nil {
+		if isInvalidInput(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, fmt.Errorf("failed to count ban appeals: %w", err)
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = 20
+	}
+	if filter.PageSize > 100 {
+		filter.PageSize = 100
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	args = append(args, filter.PageSize, offset)
+
+	query := fmt.Sprintf(`
+		SELECT * FROM ban_appeals
+		WHERE %s
+		ORDER BY created_at
+		LIMIT $%d OFFSET $%d`,
+		where, argPos, argPos+1)
+
+	var dbAppeals []BanAppealDBModel
+	if err := r.db.SelectContext(ctx, &dbAppeals, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list ban appeals: %w", err)
+	}
+
+	appeals := make([]*domain.BanAppeal, 0, len(dbAppeals))
+	for i := range dbAppeals {
+		appeals = append(appeals, dbAppeals[i].ToDomain())
+	}
+
+	return appeals, total, nil
+}
+
+// Assign назначает открытую апелляцию модератору
+func (r *PostgresBanAppealRepository) Assign(ctx context.Context, id, moderatorID string, at time.Time) (bool, error) {
+	query := `
+		UPDATE ban_appeals SET assigned_to = $1, status = $2, updated_at = $3
+		WHERE id = $4 AND status IN ($5, $6)
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		moderatorID,
+		string(domain.AppealStatusInReview),
+		at,
+		id,
+		string(domain.AppealStatusPending),
+		string(domain.AppealStatusInReview),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to assign ban appeal: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// Resolve закрывает открытую апелляцию
+func (r *PostgresBanAppealRepository) Resolve(ctx context.Context, id string, status domain.AppealStatus, moderatorID, resolution string, at time.Time) (bool, error) {
+	query := `
+		UPDATE ban_appeals SET
+			status = $1,
+			resolved_by = $2,
+			resolution = $3,
+			resolved_at = $4,
+			updated_at = $4
+		WHERE id = $5 AND status IN ($6, $7)
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		string(status),
+		moderatorID,
+		resolution,
+		at,
+		id,
+		string(domain.AppealStatusPending),
+		string(domain.AppealStatusInReview),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve ban appeal: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgresDenyListRepository - хранилище списков запрета в PostgreSQL
+type PostgresDenyListRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresDenyListRepository создает новый репозиторий списков запрета
+func NewPostgresDenyListRepository(db *sqlx.DB) *PostgresDenyListRepository {
+	return &PostgresDenyListRepository{db: db}
+}
+
+// DenyListEntryDBModel - модель записи списка запрета в базе данных
+type DenyListEntryDBModel struct {
+	ID        string         `db:"id"`
+	Kind      string         `db:"kind"`
+	Value     string         `db:"value"`
+	Network   sql.NullString `db:"network"`
+	Reason    string         `db:"reason"`
+	CreatedBy string         `db:"created_by"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+	ExpiresAt sql.NullTime   `db:"expires_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *DenyListEntryDBModel) ToDomain() *domain.DenyListEntry {
+	entry := &domain.DenyListEntry{
+		ID:        m.ID,
+		Kind:      domain.DenyListKind(m.Kind),
+		Value:     m.Value,
+		Reason:    m.Reason,
+		CreatedBy: m.CreatedBy,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+
+	if m.ExpiresAt.Valid {
+		entry.ExpiresAt = &m.ExpiresAt.Time
+	}
+
+	return entry
+}
+
+// Create сохраняет запись списка запрета
+func (r *PostgresDenyListRepository) Create(ctx context.Context, entry *domain.DenyListEntry) error {
+	query := `
+		INSERT INTO deny_list_entries (id, kind, value, network, reason, created_by, created_at, updated_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	var network sql.NullString
+	if entry.Kind == domain.DenyListKindIP {
+		network = sql.NullString{String: entry.Value, Valid: true}
+	}
+
+	var expiresAt sql.NullTime
+	if entry.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *entry.ExpiresAt, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		entry.ID,
+		string(entry.Kind),
+		entry.Value,
+		network,
+		entry.Reason,
+		entry.CreatedBy,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+		expiresAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return domain.ErrDenyListEntryExists
+		}
+		return fmt.Errorf("failed to create deny list entry: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID находит запись списка запрета по ID
+func (r *PostgresDenyListRepository) FindByID(ctx context.Context, id string) (*domain.DenyListEntry, error) {
+	var dbEntry DenyListEntryDBModel
+
+	query := `SELECT * FROM deny_list_entries WHERE id = $1`
+	err := r.db.GetContext(ctx, &dbEntry, query, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows || isInvalidInput(err) {
+			return nil, domain.ErrDenyListEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to find deny list entry: %w", err)
+	}
+
+	return dbEntry.ToDomain(), nil
+}
+
+// List возвращает записи списка запрета, новые первыми
+func (r *PostgresDenyListRepository) List(ctx context.Context, filter *domain.DenyListFilter) ([]*domain.DenyListEntry, int64, error) {
+	var conditions []string
+	var args []interface{}
+	argPos := 1
+
+	if filter.Kind != "" {
+		conditions = append(conditions, fmt.Sprintf("kind = $%d", argPos))
+		args = append(args, string(filter.Kind))
+		argPos++
+	}
+
+	if !filter.IncludeExpired {
+		conditions = append(conditions, "(expires_at IS NULL OR expires_at > NOW())")
+	}
+
+	where := "TRUE"
+	if len(conditions) > 0 {
+		where = strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM deny_list_entries WHERE "+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count deny list entries: %w", err)
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = 20
+	}
+	if filter.PageSize > 100 {
+		filter.PageSize = 100
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	args = append(args, filter.PageSize, offset)
+
+	query := fmt.Sprintf(`
+		SELECT * FROM deny_list_entries
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`,
+		where, argPos, argPos+1)
+
+	var dbEntries []DenyListEntryDBModel
+	if err := r.db.SelectContext(ctx, &dbEntries, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list deny list entries: %w", err)
+	}
+
+	entries := make([]*domain.DenyListEntry, 0, len(dbEntries))
+	for i := range dbEntries {
+		entries = append(entries, dbEntries[i].ToDomain())
+	}
+
+	return entries, total, nil
+}
+
+// Update меняет причину и срок действия записи
+func (r *PostgresDenyListRepository) Update(ctx context.Context, entry *domain.DenyListEntry) error {
+	query := `
+		UPDATE deny_list_entries SET reason = $1, expires_at = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	var expiresAt sql.NullTime
+	if entry.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *entry.ExpiresAt, Valid: true}
+	}
+
+	result, err := r.db.ExecContext(ctx, query, entry.Reason, expiresAt, entry.UpdatedAt, entry.ID)
+	if err != nil {
+		if isInvalidInput(err) {
+			return domain.ErrDenyListEntryNotFound
+		}
+		return fmt.Errorf("failed to update deny list entry: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrDenyListEntryNotFound
+	}
+
+	return nil
+}
+
+// Delete удаляет запись списка запрета
+func (r *PostgresDenyListRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM deny_list_entries WHERE id = $1`, id)
+	if err != nil {
+		if isInvalidInput(err) {
+			return domain.ErrDenyListEntryNotFound
+		}
+		return fmt.Errorf("failed to delete deny list entry: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrDenyListEntryNotFound
+	}
+
+	return nil
+}
+
+// Match ищет действующий запрет для адреса, домена (с родительскими) и устройства
+func (r *PostgresDenyListRepository) Match(ctx context.Context, check *domain.DenyCheck, now time.Time) (*domain.DenyListEntry, error) {
+	query := `
+		SELECT * FROM deny_list_entries
+		WHERE (expires_at IS NULL OR expires_at > $1)
+		  AND (
+		    network >>= $2::inet
+		    OR (kind = $3 AND value = ANY($4))
+		    OR (kind = $5 AND value = $6)
+		  )
+		ORDER BY created_at
+		LIMIT 1
+	`
+
+	// Некорректный адрес не сравниваем, иначе запрос упадет на приведении к inet
+	var ip sql.NullString
+	if net.ParseIP(check.IP) != nil {
+		ip = sql.NullString{String: check.IP, Valid: true}
+	}
+
+	var deviceID sql.NullString
+	if check.DeviceID != "" {
+		deviceID = sql.NullString{String: check.DeviceID, Valid: true}
+	}
+
+	var dbEntry DenyListEntryDBModel
+	err := r.db.GetContext(ctx, &dbEntry, query,
+		now,
+		ip,
+		string(domain.DenyListKindEmailDomain),
+		pq.Array(domain.EmailDomainCandidates(check.EmailDomain)),
+		string(domain.DenyListKindDevice),
+		deviceID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to match deny list: %w", err)
+	}
+
+	return dbEntry.ToDomain(), nil
+}
@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresMFARepository - хранилище настроек 2FA в PostgreSQL
+type PostgresMFARepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresMFARepository создает новый репозиторий настроек 2FA
+func NewPostgresMFARepository(db *sqlx.DB) *PostgresMFARepository {
+	return &PostgresMFARepository{db: db}
+}
+
+// TOTPCredentialDBModel - модель TOTP секрета в базе данных
+type TOTPCredentialDBModel struct {
+	UserID          string       `db:"user_id"`
+	SecretEncrypted string       `db:"secret_encrypted"`
+	ConfirmedAt     sql.NullTime `db:"confirmed_at"`
+	LastUsedStep    int64        `db:"last_used_step"`
+	CreatedAt       time.Time    `db:"created_at"`
+	UpdatedAt       time.Time    `db:"updated_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *TOTPCredentialDBModel) ToDomain() *domain.TOTPCredential {
+	cred := &domain.TOTPCredential{
+		UserID:          m.UserID,
+		SecretEncrypted: m.SecretEncrypted,
+		LastUsedStep:    m.LastUsedStep,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+
+	if m.ConfirmedAt.Valid {
+		cred.ConfirmedAt = &m.ConfirmedAt.Time
+	}
+
+	return cred
+}
+
+// SaveTOTP сохраняет новый секрет. Подтвержденный секрет не перезаписывается.
+func (r *PostgresMFARepository) SaveTOTP(ctx context.Context, cred *domain.TOTPCredential) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret_encrypted, last_used_step, created_at, updated_at)
+		VALUES ($1, $2, 0, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret_encrypted = EXCLUDED.secret_encrypted,
+			last_used_step = 0,
+			updated_at = EXCLUDED.updated_at
+		WHERE user_totp.confirmed_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, cred.UserID, cred.SecretEncrypted, cred.CreatedAt, cred.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save totp secret: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrMFAAlreadyEnabled
+	}
+
+	return nil
+}
+
+// FindTOTP находит TOTP секрет пользователя
+func (r *PostgresMFARepository) FindTOTP(ctx context.Context, userID string) (*domain.TOTPCredential, error) {
+	var dbCred TOTPCredentialDBModel
+
+	query := `SELECT * FROM user_totp WHERE user_id = $1`
+	err := r.db.GetContext(ctx, &dbCred, query, userID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrMFANotEnrolled
+		}
+		return nil, fmt.Errorf("failed to find totp secret: %w", err)
+	}
+
+	return dbCred.ToDomain(), nil
+}
+
+// ConfirmTOTP завершает настройку 2FA и запоминает шаг первого кода
+func (r *PostgresMFARepository) ConfirmTOTP(ctx context.Context, userID string, step int64) error {
+	query := `
+		UPDATE user_totp SET confirmed_at = $1, last_used_step = $2, updated_at = $1
+		WHERE user_id = $3 AND confirmed_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), step, userID)
+	if err != nil {
+		return fmt.Errorf("failed to confirm totp: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrMFAAlreadyEnabled
+	}
+
+	return nil
+}
+
+// DeleteTOTP удаляет секрет и коды восстановления пользователя
+func (r *PostgresMFARepository) DeleteTOTP(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete totp secret: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// UseStep запоминает шаг принятого кода, если он новее последнего
+func (r *PostgresMFARepository) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	query := `
+		UPDATE user_totp SET last_used_step = $1, updated_at = $2
+		WHERE user_id = $3 AND last_used_step < $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, step, time.Now(), userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to update totp step: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// ReplaceRecoveryCodes заменяет коды восстановления пользователя новыми
+func (r *PostgresMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	now := time.Now()
+	for _, hash := range codeHashes {
+		query := `INSERT INTO mfa_recovery_codes (id, user_id, code_hash, created_at) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.ExecContext(ctx, query, domain.GenerateUUID(), userID, hash, now); err != nil {
+			return fmt.Errorf("failed to create recovery code: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UseRecoveryCode помечает код восстановления использованным
+func (r *PostgresMFARepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	query := `
+		UPDATE mfa_recovery_codes SET used_at = $1
+		WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
@@ -0,0 +1,349 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgresOrganizationRepository - хранилище организаций в PostgreSQL
+type PostgresOrganizationRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresOrganizationRepository создает новый репозиторий организаций
+func NewPostgresOrganizationRepository(db *sqlx.DB) *PostgresOrganizationRepository {
+	return &PostgresOrganizationRepository{db: db}
+}
+
+// OrganizationDBModel - модель организации в базе данных
+type OrganizationDBModel struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	Slug      string    `db:"slug"`
+	OwnerID   string    `db:"owner_id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *OrganizationDBModel) ToDomain() *domain.Organization {
+	return &domain.Organization{
+		ID:        m.ID,
+		Name:      m.Name,
+		Slug:      m.Slug,
+		OwnerID:   m.OwnerID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// OrgMemberDBModel - модель участника организации в базе данных
+type OrgMemberDBModel struct {
+	OrganizationID string    `db:"organization_id"`
+	UserID         string    `db:"user_id"`
+	Role           string    `db:"role"`
+	JoinedAt       time.Time `db:"joined_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *OrgMemberDBModel) ToDomain() *domain.OrgMember {
+	return &domain.OrgMember{
+		OrganizationID: m.OrganizationID,
+		UserID:         m.UserID,
+		Role:           domain.OrgRole(m.Role),
+		JoinedAt:       m.JoinedAt,
+	}
+}
+
+// OrgInvitationDBModel - модель приглашения в базе данных
+type OrgInvitationDBModel struct {
+	ID             string       `db:"id"`
+	OrganizationID string       `db:"organization_id"`
+	Email          string       `db:"email"`
+	Role           string       `db:"role"`
+	InvitedBy      string       `db:"invited_by"`
+	Status         string       `db:"status"`
+	ExpiresAt      time.Time    `db:"expires_at"`
+	CreatedAt      time.Time    `db:"created_at"`
+	RespondedAt    sql.NullTime `db:"responded_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *OrgInvitationDBModel) ToDomain() *domain.OrgInvitation {
+	inv := &domain.OrgInvitation{
+		ID:             m.ID,
+		OrganizationID: m.OrganizationID,
+		Email:          m.Email,
+		Role:           domain.OrgRole(m.Role),
+		InvitedBy:      m.InvitedBy,
+		Status:         domain.InvitationStatus(m.Status),
+		ExpiresAt:      m.ExpiresAt,
+		CreatedAt:      m.CreatedAt,
+	}
+
+	if m.RespondedAt.Valid {
+		inv.RespondedAt = &m.RespondedAt.Time
+	}
+
+	return inv
+}
+
+// Create сохраняет организацию и ее владельца
+func (r *PostgresOrganizationRepository) Create(ctx context.Context, org *domain.Organization, owner *domain.OrgMember) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO organizations (id, name, slug, owner_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err = tx.ExecContext(ctx, query, org.ID, org.Name, org.Slug, org.OwnerID, org.CreatedAt, org.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return domain.ErrOrganizationSlugTaken
+		}
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	query = `INSERT INTO organization_members (organization_id, user_id, role, joined_at) VALUES ($1, $2, $3, $4)`
+	if _, err := tx.ExecContext(ctx, query, owner.OrganizationID, owner.UserID, string(owner.Role), owner.JoinedAt); err != nil {
+		return fmt.Errorf("failed to add organization owner: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// FindByID находит организацию по ID
+func (r *PostgresOrganizationRepository) FindByID(ctx context.Context, id string) (*domain.Organization, error) {
+	var dbOrg OrganizationDBModel
+
+	query := `SELECT * FROM organizations WHERE id = $1`
+	err := r.db.GetContext(ctx, &dbOrg, query, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows || isInvalidInput(err) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to find organization: %w", err)
+	}
+
+	return dbOrg.ToDomain(), nil
+}
+
+// ListForUser возвращает организации, в которых состоит пользователь
+func (r *PostgresOrganizationRepository) ListForUser(ctx context.Context, userID string) ([]*domain.Organization, error) {
+	var dbOrgs []OrganizationDBModel
+
+	query := `
+		SELECT o.* FROM organizations o
+		JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name
+	`
+	if err := r.db.SelectContext(ctx, &dbOrgs, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	orgs := make([]*domain.Organization, 0, len(dbOrgs))
+	for i := range dbOrgs {
+		orgs = append(orgs, dbOrgs[i].ToDomain())
+	}
+
+	return orgs, nil
+}
+
+// FindMember находит участника организации
+func (r *PostgresOrganizationRepository) FindMember(ctx context.Context, orgID, userID string) (*domain.OrgMember, error) {
+	var dbMember OrgMemberDBModel
+
+	query := `SELECT * FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+	err := r.db.GetContext(ctx, &dbMember, query, orgID, userID)
+
+	if err != nil {
+		if err == sql.ErrNoRows || isInvalidInput(err) {
+			return nil, domain.ErrNotOrganizationMember
+		}
+		return nil, fmt.Errorf("failed to find organization member: %w", err)
+	}
+
+	return dbMember.ToDomain(), nil
+}
+
+// ListMembers возвращает участников организации
+func (r *PostgresOrganizationRepository) ListMembers(ctx context.Context, orgID string) ([]*domain.OrgMember, error) {
+	var dbMembers []OrgMemberDBModel
+
+	query := `SELECT * FROM organization_members WHERE organization_id = $1 ORDER BY joined_at`
+	if err := r.db.SelectContext(ctx, &dbMembers, query, orgID); err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+
+	members := make([]*domain.OrgMember, 0, len(dbMembers))
+	for i := range dbMembers {
+		members = append(members, dbMembers[i].ToDomain())
+	}
+
+	return members, nil
+}
+
+// UpdateMemberRole меняет роль участника организации
+func (r *PostgresOrganizationRepository) UpdateMemberRole(ctx context.Context, orgID, userID string, role domain.OrgRole) error {
+	query := `UPDATE organization_members SET role = $1 WHERE organization_id = $2 AND user_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, string(role), orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update organization member: %w", err)
+	}
+
+	return requireAffected(result, domain.ErrNotOrganizationMember)
+}
+
+// RemoveMember исключает участника из организации
+func (r *PostgresOrganizationRepository) RemoveMember(ctx context.Context, orgID, userID string) error {
+	query := `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove organization member: %w", err)
+	}
+
+	return requireAffected(result, domain.ErrNotOrganizationMember)
+}
+
+// CountOwners возвращает число владельцев организации
+func (r *PostgresOrganizationRepository) CountOwners(ctx context.Context, orgID string) (int, error) {
+	var count int
+
+	query := `SELECT COUNT(*) FROM organization_members WHERE organization_id = $1 AND role = $2`
+	if err := r.db.GetContext(ctx, &count, query, orgID, string(domain.OrgRoleOwner)); err != nil {
+		return 0, fmt.Errorf("failed to count organization owners: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateInvitation сохраняет приглашение
+func (r *PostgresOrganizationRepository) CreateInvitation(ctx context.Context, inv *domain.OrgInvitation) error {
+	query := `
+		INSERT INTO organization_invitations (
+			id, organization_id, email, role, invited_by, status, expires_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		inv.ID,
+		inv.OrganizationID,
+		inv.Email,
+		string(inv.Role),
+		inv.InvitedBy,
+		string(inv.Status),
+		inv.ExpiresAt,
+		inv.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	return nil
+}
+
+// FindInvitation находит приглашение организации по ID
+func (r *PostgresOrganizationRepository) FindInvitation(ctx context.Context, orgID, id string) (*domain.OrgInvitation, error) {
+	var dbInv OrgInvitationDBModel
+
+	query := `SELECT * FROM organization_invitations WHERE id = $1 AND organization_id = $2`
+	err := r.db.GetContext(ctx, &dbInv, query, id, orgID)
+
+	if err != nil {
+		if err == sql.ErrNoRows || isInvalidInput(err) {
+			return nil, domain.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
+	}
+
+	return dbInv.ToDomain(), nil
+}
+
+// ListInvitationsForEmail возвращает действующие приглашения на email
+func (r *PostgresOrganizationRepository) ListInvitationsForEmail(ctx context.Context, email string) ([]*domain.OrgInvitation, error) {
+	var dbInvs []OrgInvitationDBModel
+
+	query := `
+		SELECT * FROM organization_invitations
+		WHERE email = LOWER($1) AND status = $2 AND expires_at > $3
+		ORDER BY created_at DESC
+	`
+	err := r.db.SelectContext(ctx, &dbInvs, query, email, string(domain.InvitationStatusPending), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	invs := make([]*domain.OrgInvitation, 0, len(dbInvs))
+	for i := range dbInvs {
+		invs = append(invs, dbInvs[i].ToDomain())
+	}
+
+	return invs, nil
+}
+
+// RespondInvitation закрывает ожидающее приглашение и при принятии добавляет участника
+func (r *PostgresOrganizationRepository) RespondInvitation(ctx context.Context, inv *domain.OrgInvitation, userID string, status domain.InvitationStatus, at time.Time) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE organization_invitations SET status = $1, responded_at = $2
+		WHERE id = $3 AND organization_id = $4 AND status = $5
+	`
+	result, err := tx.ExecContext(ctx, query, string(status), at, inv.ID, inv.OrganizationID, string(domain.InvitationStatusPending))
+	if err != nil {
+		return false, fmt.Errorf("failed to respond invitation: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	if status == domain.InvitationStatusAccepted {
+		query = `
+			INSERT INTO organization_members (organization_id, user_id, role, joined_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (organization_id, user_id) DO NOTHING
+		`
+		if _, err := tx.ExecContext(ctx, query, inv.OrganizationID, userID, string(inv.Role), at); err != nil {
+			return false, fmt.Errorf("failed to add organization member: %w", err)
+		}
+	}
+
+	return true, tx.Commit()
+}
+
+// requireAffected возвращает notFound, если запрос не изменил ни одной строки
+func requireAffected(result sql.Result, notFound error) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return notFound
+	}
+
+	return nil
+}
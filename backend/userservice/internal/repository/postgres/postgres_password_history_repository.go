@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresPasswordHistoryRepository - хранилище прежних хешей паролей в PostgreSQL
+type PostgresPasswordHistoryRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresPasswordHistoryRepository создает новый репозиторий истории паролей
+func NewPostgresPasswordHistoryRepository(db *sqlx.DB) *PostgresPasswordHistoryRepository {
+	return &PostgresPasswordHistoryRepository{db: db}
+}
+
+// Add сохраняет хеш и удаляет записи старше keep последних
+func (r *PostgresPasswordHistoryRepository) Add(ctx context.Context, userID, passwordHash string, keep int) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO password_history (id, user_id, password_hash, created_at) VALUES ($1, $2, $3, $4)`
+	if _, err := tx.ExecContext(ctx, query, domain.GenerateUUID(), userID, passwordHash, time.Now()); err != nil {
+		return fmt.Errorf("failed to add password history: %w", err)
+	}
+
+	trim := `
+		DELETE FROM password_history
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM password_history
+			WHERE user_id = $1
+			ORDER BY created_at DESC
+			LIMIT $2
+		)
+	`
+	if _, err := tx.ExecContext(ctx, trim, userID, keep); err != nil {
+		return fmt.Errorf("failed to trim password history: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// List возвращает последние хеши паролей пользователя, новые первыми
+func (r *PostgresPasswordHistoryRepository) List(ctx context.Context, userID string, limit int) ([]string, error) {
+	query := `
+		SELECT password_hash FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	var hashes []string
+	if err := r.db.SelectContext(ctx, &hashes, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list password history: %w", err)
+	}
+
+	return hashes, nil
+}
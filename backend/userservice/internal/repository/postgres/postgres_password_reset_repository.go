@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresPasswordResetRepository - хранилище токенов сброса пароля в PostgreSQL
+type PostgresPasswordResetRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresPasswordResetRepository создает новый репозиторий токенов сброса пароля
+func NewPostgresPasswordResetRepository(db *sqlx.DB) *PostgresPasswordResetRepository {
+	return &PostgresPasswordResetRepository{db: db}
+}
+
+// PasswordResetTokenDBModel - модель токена сброса пароля в базе данных
+type PasswordResetTokenDBModel struct {
+	ID        string       `db:"id"`
+	UserID    string       `db:"user_id"`
+	TokenHash string       `db:"token_hash"`
+	ExpiresAt time.Time    `db:"expires_at"`
+	CreatedAt time.Time    `db:"created_at"`
+	UsedAt    sql.NullTime `db:"used_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *PasswordResetTokenDBModel) ToDomain() *domain.PasswordResetToken {
+	token := &domain.PasswordResetToken{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		TokenHash: m.TokenHash,
+		ExpiresAt: m.ExpiresAt,
+		CreatedAt: m.CreatedAt,
+	}
+
+	if m.UsedAt.Valid {
+		token.UsedAt = &m.UsedAt.Time
+	}
+
+	return token
+}
+
+// Create сохраняет новый токен сброса пароля
+func (r *PostgresPasswordResetRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	query := `
+		INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID,
+		token.UserID,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return nil
+}
+
+// FindByHash находит токен сброса пароля по хешу
+func (r *PostgresPasswordResetRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	var dbToken PasswordResetTokenDBModel
+
+	query := `SELECT * FROM password_reset_tokens WHERE token_hash = $1`
+	err := r.db.GetContext(ctx, &dbToken, query, tokenHash)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrResetTokenInvalid
+		}
+		return nil, fmt.Errorf("failed to find password reset token: %w", err)
+	}
+
+	return dbToken.ToDomain(), nil
+}
+
+// MarkUsed помечает токен использованным, если он еще не был использован
+func (r *PostgresPasswordResetRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	query := `UPDATE password_reset_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark password reset token used: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// InvalidateForUser помечает использованными все действующие токены пользователя
+func (r *PostgresPasswordResetRepository) InvalidateForUser(ctx context.Context, userID string) error {
+	query := `UPDATE password_reset_tokens SET used_at = $1 WHERE user_id = $2 AND used_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, time.Now(), userID); err != nil {
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresPhoneOTPRepository - хранилище кодов подтверждения телефона в PostgreSQL
+type PostgresPhoneOTPRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresPhoneOTPRepository создает новый репозиторий кодов подтверждения телефона
+func NewPostgresPhoneOTPRepository(db *sqlx.DB) *PostgresPhoneOTPRepository {
+	return &PostgresPhoneOTPRepository{db: db}
+}
+
+// PhoneOTPDBModel - модель кода подтверждения в базе данных
+type PhoneOTPDBModel struct {
+	ID         string       `db:"id"`
+	UserID     string       `db:"user_id"`
+	Phone      string       `db:"phone"`
+	CodeHash   string       `db:"code_hash"`
+	Attempts   int          `db:"attempts"`
+	ExpiresAt  time.Time    `db:"expires_at"`
+	CreatedAt  time.Time    `db:"created_at"`
+	ConsumedAt sql.NullTime `db:"consumed_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *PhoneOTPDBModel) ToDomain() *domain.PhoneOTP {
+	otp := &domain.PhoneOTP{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Phone:     m.Phone,
+		CodeHash:  m.CodeHash,
+		Attempts:  m.Attempts,
+		ExpiresAt: m.ExpiresAt,
+		CreatedAt: m.CreatedAt,
+	}
+
+	if m.ConsumedAt.Valid {
+		otp.ConsumedAt = &m.ConsumedAt.Time
+	}
+
+	return otp
+}
+
+// Create сохраняет новый код
+func (r *PostgresPhoneOTPRepository) Create(ctx context.Context, otp *domain.PhoneOTP) error {
+	query := `
+		INSERT INTO phone_otp_codes (id, user_id, phone, code_hash, attempts, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		otp.ID,
+		otp.UserID,
+		otp.Phone,
+		otp.CodeHash,
+		otp.Attempts,
+		otp.ExpiresAt,
+		otp.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create phone otp: %w", err)
+	}
+
+	return nil
+}
+
+// FindLatest находит последний неиспользованный код пользователя
+func (r *PostgresPhoneOTPRepository) FindLatest(ctx context.Context, userID string) (*domain.PhoneOTP, error) {
+	var dbOTP PhoneOTPDBModel
+
+	query := `
+		SELECT * FROM phone_otp_codes
+		WHERE user_id = $1 AND consumed_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	err := r.db.GetContext(ctx, &dbOTP, query, userID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrOTPInvalid
+		}
+		return nil, fmt.Errorf("failed to find phone otp: %w", err)
+	}
+
+	return dbOTP.ToDomain(), nil
+}
+
+// IncrementAttempts увеличивает счетчик неудачных попыток
+func (r *PostgresPhoneOTPRepository) IncrementAttempts(ctx context.Context, id string) (int, error) {
+	var attempts int
+
+	query := `UPDATE phone_otp_codes SET attempts = attempts + 1 WHERE id = $1 RETURNING attempts`
+	if err := r.db.GetContext(ctx, &attempts, query, id); err != nil {
+		return 0, fmt.Errorf("failed to increment phone otp attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// MarkConsumed помечает код использованным, если он еще не был использован
+func (r *PostgresPhoneOTPRepository) MarkConsumed(ctx context.Context, id string) (bool, error) {
+	query := `UPDATE phone_otp_codes SET consumed_at = $1 WHERE id = $2 AND consumed_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark phone otp consumed: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// InvalidateForUser помечает использованными все действующие коды пользователя
+func (r *PostgresPhoneOTPRepository) InvalidateForUser(ctx context.Context, userID string) error {
+	query := `UPDATE phone_otp_codes SET consumed_at = $1 WHERE user_id = $2 AND consumed_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, time.Now(), userID); err != nil {
+		return fmt.Errorf("failed to invalidate phone otp codes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,392 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresReportRepository - хранилище жалоб на пользователей в PostgreSQL
+type PostgresReportRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresReportRepository создает новый репозиторий жалоб
+func NewPostgresReportRepository(db *sqlx.DB) *PostgresReportRepository {
+	return &PostgresReportRepository{db: db}
+}
+
+// UserReportDBModel - модель жалобы в базе данных
+type UserReportDBModel struct {
+	ID             string         `db:"id"`
+	ReportedUserID string         `db:"reported_user_id"`
+	Category       string         `db:"category"`
+	Status         string         `db:"status"`
+	ReportCount    int            `db:"report_count"`
+	Priority       int            `db:"priority"`
+	ClaimedBy      sql.NullString `db:"claimed_by"`
+	ClaimedAt      sql.NullTime   `db:"claimed_at"`
+	Action         sql.NullString `db:"action"`
+	Resolution     string         `db:"resolution"`
+	ResolvedBy     sql.NullString `db:"resolved_by"`
+	ResolvedAt     sql.NullTime   `db:"resolved_at"`
+	BannedAt       sql.NullTime   `db:"banned_at"`
+	StrikeID       sql.NullString `db:"strike_id"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *UserReportDBModel) ToDomain() *domain.UserReport {
+	report := &domain.UserReport{
+		ID:             m.ID,
+		ReportedUserID: m.ReportedUserID,
+		Category:       domain.ReportCategory(m.Category),
+		Status:         domain.ReportStatus(m.Status),
+		ReportCount:    m.ReportCount,
+		Priority:       m.Priority,
+		ClaimedBy:      m.ClaimedBy.String,
+		Action:         domain.ReportAction(m.Action.String),
+		Resolution:     m.Resolution,
+		ResolvedBy:     m.ResolvedBy.String,
+		StrikeID:       m.StrikeID.String,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+
+	if m.ClaimedAt.Valid {
+		report.ClaimedAt = &m.ClaimedAt.Time
+	}
+
+	if m.ResolvedAt.Valid {
+		report.ResolvedAt = &m.ResolvedAt.Time
+	}
+
+	if m.BannedAt.Valid {
+		report.BannedAt = &m.BannedAt.Time
+	}
+
+	return report
+}
+
+// ReportEntryDBModel - модель жалобы заявителя в базе данных
+type ReportEntryDBModel struct {
+	ReportID    string         `db:"report_id"`
+	ReporterID  string         `db:"reporter_id"`
+	Description string         `db:"description"`
+	Evidence    sql.NullString `db:"evidence"` // JSON в базе
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *ReportEntryDBModel) ToDomain() *domain.ReportEntry {
+	entry := &domain.ReportEntry{
+		ReportID:    m.ReportID,
+		ReporterID:  m.ReporterID,
+		Description: m.Description,
+		CreatedAt:   m.CreatedAt,
+	}
+
+	if m.Evidence.Valid && m.Evidence.String != "" {
+		if err := json.Unmarshal([]byte(m.Evidence.String), &entry.Evidence); err != nil {
+			fmt.Printf("Warning: failed to parse report evidence: %v\n", err)
+		}
+	}
+
+	return entry
+}
+
+// Submit добавляет жалобу заявителя в открытую жалобу или создает новую
+func (r *PostgresReportRepository) Submit(ctx context.Context, report *domain.UserReport, entry *domain.ReportEntry) (*domain.UserReport, bool, error) {
+	var evidence sql.NullString
+	if len(entry.Evidence) > 0 {
+		evidenceJSON, err := json.Marshal(entry.Evidence)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to marshal report evidence: %w", err)
+		}
+		evidence = sql.NullString{String: string(evidenceJSON), Valid: true}
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Открытая жалоба на пользователя в категории одна, ее создает первый заявитель
+	query := `
+		INSERT INTO user_reports (id, reported_user_id, category, status, report_count, priority, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, 0, $5, $6, $7)
+		ON CONFLICT (reported_user_id, category) WHERE status IN ('OPEN', 'CLAIMED') DO NOTHING
+	`
+	_, err = tx.ExecContext(ctx, query,
+		report.ID,
+		report.ReportedUserID,
+		string(report.Category),
+		string(report.Status),
+		report.Priority,
+		report.CreatedAt,
+		report.UpdatedAt,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	var dbReport UserReportDBModel
+	query = `
+		SELECT * FROM user_reports
+		WHERE reported_user_id = $1 AND category = $2 AND status IN ('OPEN', 'CLAIMED')
+		FOR UPDATE
+	`
+	if err := tx.GetContext(ctx, &dbReport, query, report.ReportedUserID, string(report.Category)); err != nil {
+		return nil, false, fmt.Errorf("failed to find open report: %w", err)
+	}
+
+	query = `
+		INSERT INTO user_report_entries (report_id, reporter_id, description, evidence, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (report_id, reporter_id) DO NOTHING
+	`
+	result, err := tx.ExecContext(ctx, query, dbReport.ID, entry.ReporterID, entry.Description, evidence, entry.CreatedAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to add report entry: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return dbReport.ToDomain(), true, tx.Commit()
+	}
+
+	query = `
+		UPDATE user_reports SET
+			report_count = report_count + 1,
+			priority = $1 + report_count + 1,
+			updated_at = $2
+		WHERE id = $3
+		RETURNING *
+	`
+	if err := tx.GetContext(ctx, &dbReport, query, report.Category.Weight(), entry.CreatedAt, dbReport.ID); err != nil {
+		return nil, false, fmt.Errorf("failed to update report: %w", err)
+	}
+
+	return dbReport.ToDomain(), false, tx.Commit()
+}
+
+// FindByID находит жалобу по ID
+func (r *PostgresReportRepository) FindByID(ctx context.Context, id string) (*domain.UserReport, error) {
+	var dbReport UserReportDBModel
+
+	query := `SELECT * FROM user_reports WHERE id = $1`
+	err := r.db.GetContext(ctx, &dbReport, query, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows || isInvalidInput(err) {
+			return nil, domain.ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to find report: %w", err)
+	}
+
+	return dbReport.ToDomain(), nil
+}
+
+// ListEntries возвращает жалобы заявителей в порядке поступления
+func (r *PostgresReportRepository) ListEntries(ctx context.Context, reportID string) ([]*domain.ReportEntry, error) {
+	var dbEntries []ReportEntryDBModel
+
+	query := `SELECT * FROM user_report_entries WHERE report_id = $1 ORDER BY created_at`
+	if err := r.db.SelectContext(ctx, &dbEntries, query, reportID); err != nil {
+		return nil, fmt.Errorf("failed to list report entries: %w", err)
+	}
+
+	entries := make([]*domain.ReportEntry, 0, len(dbEntries))
+	for i := range dbEntries {
+		entries = append(entries, dbEntries[i].ToDomain())
+	}
+
+	return entries, nil
+}
+
+// ListQueue возвращает очередь модерации
+func (r *PostgresReportRepository) ListQueue(ctx context.Context, filter *domain.ReportFilter) ([]*domain.UserReport, int64, error) {
+	var conditions []string
+	var args []interface{}
+	argPos := 1
+
+	if filter.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argPos))
+		args = append(args, string(filter.Status))
+		argPos++
+	} else {
+		conditions = append(conditions, "status IN ('OPEN', 'CLAIMED')")
+	}
+
+	if filter.Category != "" {
+		conditions = append(conditions, fmt.Sprintf("category = $%d", argPos))
+		args = append(args, string(filter.Category))
+		argPos++
+	}
+
+	if filter.ClaimedBy != "" {
+		conditions = append(conditions, fmt.Sprintf("claimed_by = $%d", argPos))
+		args = append(args, filter.ClaimedBy)
+		argPos++
+	}
+
+	where := strings.Join(conditions, " AND ")
+
+	var total int64
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM user_reports WHERE "+where, args...); err != nil {
+		if isInvalidInput(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = 20
+	}
+	if filter.PageSize > 100 {
+		filter.PageSize = 100
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	args = append(args, filter.PageSize, offset)
+
+	query := fmt.Sprintf(`
+		SELECT * FROM user_reports
+		WHERE %s
+		ORDER BY priority DESC, created_at
+		LIMIT $%d OFFSET $%d`,
+		where, argPos, argPos+1)
+
+	var dbReports []UserReportDBModel
+	if err := r.db.SelectContext(ctx, &dbReports, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	reports := make([]*domain.UserReport, 0, len(dbReports))
+	for i := range dbReports {
+		reports = append(reports, dbReports[i].ToDomain())
+	}
+
+	return reports, total, nil
+}
+
+// Claim берет открытую жалобу в работу
+func (r *PostgresReportRepository) Claim(ctx context.Context, id, moderatorID string, at time.Time) (bool, error) {
+	query := `
+		UPDATE user_reports SET status = $1, claimed_by = $2, claimed_at = $3, updated_at = $3
+		WHERE id = $4 AND (status = $5 OR (status = $1 AND claimed_by = $2))
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		string(domain.ReportStatusClaimed),
+		moderatorID,
+		at,
+		id,
+		string(domain.ReportStatusOpen),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim report: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// Resolve сохраняет решение по жалобе, взятой модератором report.ResolvedBy
+func (r *PostgresReportRepository) Resolve(ctx context.Context, report *domain.UserReport) (bool, error) {
+	query := `
+		UPDATE user_reports SET
+			status = $1,
+			action = $2,
+			resolution = $3,
+			resolved_by = $4,
+			resolved_at = $5,
+			updated_at = $5
+		WHERE id = $6 AND status = $7 AND claimed_by = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		string(domain.ReportStatusResolved),
+		string(report.Action),
+		report.Resolution,
+		report.ResolvedBy,
+		report.ResolvedAt,
+		report.ID,
+		string(domain.ReportStatusClaimed),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve report: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// SetOutcome сохраняет бан или страйк, выданный по закрытой жалобе
+func (r *PostgresReportRepository) SetOutcome(ctx context.Context, report *domain.UserReport) error {
+	var bannedAt sql.NullTime
+	if report.BannedAt != nil {
+		bannedAt = sql.NullTime{Time: *report.BannedAt, Valid: true}
+	}
+	var strikeID sql.NullString
+	if report.StrikeID != "" {
+		strikeID = sql.NullString{String: report.StrikeID, Valid: true}
+	}
+
+	query := `UPDATE user_reports SET banned_at = $1, strike_id = $2 WHERE id = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, bannedAt, strikeID, report.ID); err != nil {
+		return fmt.Errorf("failed to set report outcome: %w", err)
+	}
+
+	return nil
+}
+
+// Reopen возвращает закрытую модератором жалобу в работу к нему
+func (r *PostgresReportRepository) Reopen(ctx context.Context, id, moderatorID string) error {
+	query := `
+		UPDATE user_reports SET
+			status = $1,
+			action = NULL,
+			resolution = '',
+			resolved_by = NULL,
+			resolved_at = NULL,
+			updated_at = $2
+		WHERE id = $3 AND status = $4 AND resolved_by = $5
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		string(domain.ReportStatusClaimed),
+		time.Now(),
+		id,
+		string(domain.ReportStatusResolved),
+		moderatorID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reopen report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresTokenRepository - хранилище refresh токенов в PostgreSQL
+type PostgresTokenRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresTokenRepository создает новый репозиторий refresh токенов
+func NewPostgresTokenRepository(db *sqlx.DB) *PostgresTokenRepository {
+	return &PostgresTokenRepository{db: db}
+}
+
+// RefreshTokenDBModel - модель refresh токена в базе данных
+type RefreshTokenDBModel struct {
+	ID         string         `db:"id"`
+	UserID     string         `db:"user_id"`
+	FamilyID   string         `db:"family_id"`
+	TenantID   sql.NullString `db:"tenant_id"`
+	TokenHash  string         `db:"token_hash"`
+	ExpiresAt  time.Time      `db:"expires_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+	RotatedAt  sql.NullTime   `db:"rotated_at"`
+	RevokedAt  sql.NullTime   `db:"revoked_at"`
+	ReplacedBy sql.NullString `db:"replaced_by"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *RefreshTokenDBModel) ToDomain() *domain.RefreshToken {
+	token := &domain.RefreshToken{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		FamilyID:   m.FamilyID,
+		TenantID:   m.TenantID.String,
+		TokenHash:  m.TokenHash,
+		ExpiresAt:  m.ExpiresAt,
+		CreatedAt:  m.CreatedAt,
+		ReplacedBy: m.ReplacedBy.String,
+	}
+
+	if m.RotatedAt.Valid {
+		token.RotatedAt = &m.RotatedAt.Time
+	}
+
+	if m.RevokedAt.Valid {
+		token.RevokedAt = &m.RevokedAt.Time
+	}
+
+	return token
+}
+
+// Create сохраняет новый refresh токен
+func (r *PostgresTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, family_id, tenant_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID,
+		token.UserID,
+		token.FamilyID,
+		sql.NullString{String: token.TenantID, Valid: token.TenantID != ""},
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// FindByHash находит refresh токен по хешу
+func (r *PostgresTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	var dbToken RefreshTokenDBModel
+
+	query := `SELECT * FROM refresh_tokens WHERE token_hash = $1`
+	err := r.db.GetContext(ctx, &dbToken, query, tokenHash)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrRefreshTokenInvalid
+		}
+		return nil, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	return dbToken.ToDomain(), nil
+}
+
+// MarkRotated помечает токен использованным, если он еще не был использован или отозван
+func (r *PostgresTokenRepository) MarkRotated(ctx context.Context, id, replacedBy string) (bool, error) {
+	query := `
+		UPDATE refresh_tokens SET
+			rotated_at = $1,
+			replaced_by = $2
+		WHERE id = $3 AND rotated_at IS NULL AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), replacedBy, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// RevokeFamily отзывает все токены семейства
+func (r *PostgresTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	query := `UPDATE refresh_tokens SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, time.Now(), familyID); err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllForUser отзывает все refresh токены пользователя
+func (r *PostgresTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	query := `UPDATE refresh_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, time.Now(), userID); err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+
+	return nil
+}
@@ -31,7 +31,9 @@ type UserDBModel struct {
 	Name               string         `db:"name"`
 	Password           string         `db:"password"`
 	Email              string         `db:"email"`
+	EmailVerifiedAt    sql.NullTime   `db:"email_verified_at"`
 	Phone              string         `db:"phone"`
+	PhoneVerifiedAt    sql.NullTime   `db:"phone_verified_at"`
 	Status             string         `db:"status"`
 	Role               string         `db:"role"`
 	CreatedAt          time.Time      `db:"created_at"`
@@ -40,6 +42,8 @@ type UserDBModel struct {
 	BanInfo            sql.NullString `db:"ban_info"`     // JSON в базе
 	Subscription       sql.NullString `db:"subscription"` // JSON в базе
 	IsBanned           bool           `db:"is_banned"`
+	BannedUntil        sql.NullTime   `db:"banned_until"`
+	BanCategory        sql.NullString `db:"ban_category"`
 	SubscriptionStatus string         `db:"subscription_status"`
 	SubscriptionLevel  string         `db:"subscription_level"`
 	SubscriptionEnd    sql.NullTime   `db:"subscription_end"`
@@ -64,6 +68,14 @@ func (dbUser *UserDBModel) ToDomain() (*domain.User, error) {
 		user.LastLoginAt = &dbUser.LastLoginAt.Time
 	}
 
+	if dbUser.EmailVerifiedAt.Valid {
+		user.EmailVerifiedAt = &dbUser.EmailVerifiedAt.Time
+	}
+
+	if dbUser.PhoneVerifiedAt.Valid {
+		user.PhoneVerifiedAt = &dbUser.PhoneVerifiedAt.Time
+	}
+
 	// Парсим BanInfo из JSON
 	if dbUser.BanInfo.Valid && dbUser.BanInfo.String != "" {
 		var banInfo domain.BanInfo
@@ -96,11 +108,25 @@ func (dbUser *UserDBModel) FromDomain(user *domain.User) error {
 	dbUser.CreatedAt = user.CreatedAt
 	dbUser.UpdatedAt = user.UpdatedAt
 	dbUser.IsBanned = user.BanInfo != nil && user.BanInfo.IsBanned
+	if dbUser.IsBanned && user.BanInfo.BannedUntil != nil {
+		dbUser.BannedUntil = sql.NullTime{Time: *user.BanInfo.BannedUntil, Valid: true}
+	}
+	if dbUser.IsBanned && user.BanInfo.Category != domain.BanCategoryUnspecified {
+		dbUser.BanCategory = sql.NullString{String: string(user.BanInfo.Category), Valid: true}
+	}
 
 	if user.LastLoginAt != nil {
 		dbUser.LastLoginAt = sql.NullTime{Time: *user.LastLoginAt, Valid: true}
 	}
 
+	if user.EmailVerifiedAt != nil {
+		dbUser.EmailVerifiedAt = sql.NullTime{Time: *user.EmailVerifiedAt, Valid: true}
+	}
+
+	if user.PhoneVerifiedAt != nil {
+		dbUser.PhoneVerifiedAt = sql.NullTime{Time: *user.PhoneVerifiedAt, Valid: true}
+	}
+
 	// Сериализуем BanInfo в JSON
 	if user.BanInfo != nil {
 		banInfoJSON, err := json.Marshal(user.BanInfo)
@@ -142,13 +168,13 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User)
 
 	query := `
 		INSERT INTO users (
-			id, service_email, name, password, email, phone, status, role,
+			id, service_email, name, password, email, email_verified_at, phone, phone_verified_at, status, role,
 			created_at, updated_at, last_login_at, ban_info, subscription,
-			is_banned, subscription_status, subscription_level, subscription_end
+			is_banned, banned_until, ban_category, subscription_status, subscription_level, subscription_end
 		) VALUES (
-			:id, :service_email, :name, :password, :email, :phone, :status, :role,
+			:id, :service_email, :name, :password, :email, :email_verified_at, :phone, :phone_verified_at, :status, :role,
 			:created_at, :updated_at, :last_login_at, :ban_info, :subscription,
-			:is_banned, :subscription_status, :subscription_level, :subscription_end
+			:is_banned, :banned_until, :ban_category, :subscription_status, :subscription_level, :subscription_end
 		)
 	`
 
@@ -220,7 +246,9 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *domain.User)
 			name = :name,
 			password = :password,
 			email = :email,
+			email_verified_at = :email_verified_at,
 			phone = :phone,
+			phone_verified_at = :phone_verified_at,
 			status = :status,
 			role = :role,
 			updated_at = :updated_at,
@@ -228,6 +256,8 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *domain.User)
 			ban_info = :ban_info,
 			subscription = :subscription,
 			is_banned = :is_banned,
+			banned_until = :banned_until,
+			ban_category = :ban_category,
 			subscription_status = :subscription_status,
 			subscription_level = :subscription_level,
 			subscription_end = :subscription_end
@@ -320,6 +350,12 @@ func (r *PostgresUserRepository) List(ctx context.Context, filter *domain.UserFi
 		argPos++
 	}
 
+	if filter.BanCategory != domain.BanCategoryUnspecified {
+		conditions = append(conditions, fmt.Sprintf("is_banned AND ban_category = $%d", argPos))
+		args = append(args, string(filter.BanCategory))
+		argPos++
+	}
+
 	if filter.SubStatus != nil && *filter.SubStatus != "" {
 		conditions = append(conditions, fmt.Sprintf("subscription_status = $%d", argPos))
 		args = append(args, string(*filter.SubStatus))
@@ -332,6 +368,14 @@ func (r *PostgresUserRepository) List(ctx context.Context, filter *domain.UserFi
 		argPos++
 	}
 
+	if filter.OrganizationID != "" {
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM organization_members m WHERE m.user_id = users.id AND m.organization_id = $%d)",
+			argPos))
+		args = append(args, filter.OrganizationID)
+		argPos++
+	}
+
 	// Подсчет общего количества
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM users WHERE %s", strings.Join(conditions, " AND "))
 	var total int64
@@ -423,23 +467,31 @@ func (r *PostgresUserRepository) Ban(ctx context.Context, userID string, banInfo
 		return fmt.Errorf("failed to marshal ban info: %w", err)
 	}
 
+	var bannedUntil sql.NullTime
+	if banInfo.BannedUntil != nil {
+		bannedUntil = sql.NullTime{Time: *banInfo.BannedUntil, Valid: true}
+	}
+	var category sql.NullString
+	if banInfo.Category != domain.BanCategoryUnspecified {
+		category = sql.NullString{String: string(banInfo.Category), Valid: true}
+	}
+
 	query := `
 		UPDATE users SET 
 			ban_info = $1,
 			is_banned = true,
-			status = CASE 
-				WHEN $2::jsonb->>'banned_until' IS NOT NULL THEN $3
-				ELSE $4
-			END,
+			banned_until = $2,
+			ban_category = $3,
+			status = $4,
 			updated_at = $5
 		WHERE id = $6
 	`
 
 	_, err = r.db.ExecContext(ctx, query,
 		string(banInfoJSON),
-		string(banInfoJSON),
-		domain.UserStatusBannedTemporarily,
-		domain.UserStatusBannedPermanently,
+		bannedUntil,
+		category,
+		banInfo.Status(),
 		time.Now(),
 		userID,
 	)
@@ -451,13 +503,18 @@ func (r *PostgresUserRepository) Ban(ctx context.Context, userID string, banInfo
 	return nil
 }
 
-// Unban разбанивает пользователя
+// Unban разбанивает пользователя и возвращает ему статус до бана
+// (ACTIVE для банов, в которых он не сохранен). Удаленный пользователь
+// остается удаленным.
 func (r *PostgresUserRepository) Unban(ctx context.Context, userID string) error {
 	query := `
 		UPDATE users SET 
 			ban_info = NULL,
 			is_banned = false,
-			status = $1,
+			banned_until = NULL,
+			ban_category = NULL,
+			status = CASE WHEN status = $4 THEN status
+				ELSE COALESCE(NULLIF(ban_info->>'PreviousStatus', ''), $1) END,
 			updated_at = $2
 		WHERE id = $3
 	`
@@ -466,6 +523,7 @@ func (r *PostgresUserRepository) Unban(ctx context.Context, userID string) error
 		domain.UserStatusActive,
 		time.Now(),
 		userID,
+		domain.UserStatusDeleted,
 	)
 
 	if err != nil {
@@ -475,6 +533,93 @@ func (r *PostgresUserRepository) Unban(ctx context.Context, userID string) error
 	return nil
 }
 
+// BanStatistics считает действующие баны по категориям
+func (r *PostgresUserRepository) BanStatistics(ctx context.Context) ([]*domain.BanCategoryStats, error) {
+	query := `
+		SELECT
+			COALESCE(ban_category, '') AS category,
+			COUNT(*) AS active,
+			COUNT(banned_until) AS temporary
+		FROM users
+		WHERE is_banned AND status != $1
+		GROUP BY 1
+		ORDER BY 1
+	`
+
+	var rows []struct {
+		Category  string `db:"category"`
+		Active    int64  `db:"active"`
+		Temporary int64  `db:"temporary"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, domain.UserStatusDeleted); err != nil {
+		return nil, fmt.Errorf("failed to count bans: %w", err)
+	}
+
+	stats := make([]*domain.BanCategoryStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, &domain.BanCategoryStats{
+			Category:  domain.BanCategory(row.Category),
+			Active:    row.Active,
+			Temporary: row.Temporary,
+			Permanent: row.Active - row.Temporary,
+		})
+	}
+
+	return stats, nil
+}
+
+// UnbanExpired снимает до limit временных банов, истекших к моменту now,
+// и возвращает пользователям статус до бана, как Unban (удаленные остаются удаленными).
+// Строки блокируются с SKIP LOCKED, поэтому при запуске на нескольких репликах
+// каждый бан снимается ровно один раз. Возвращает снятые баны по ID пользователя.
+func (r *PostgresUserRepository) UnbanExpired(ctx context.Context, now time.Time, limit int) (map[string]*domain.BanInfo, error) {
+	query := `
+		WITH expired AS (
+			SELECT id, ban_info FROM users
+			WHERE is_banned AND banned_until <= $1
+			ORDER BY banned_until
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE users u SET
+			ban_info = NULL,
+			is_banned = false,
+			banned_until = NULL,
+			ban_category = NULL,
+			status = CASE WHEN u.status = $4 THEN u.status
+				ELSE COALESCE(NULLIF(expired.ban_info->>'PreviousStatus', ''), $3) END,
+			updated_at = $1
+		FROM expired
+		WHERE u.id = expired.id
+		RETURNING u.id, expired.ban_info
+	`
+
+	rows, err := r.db.QueryxContext(ctx, query, now, limit, domain.UserStatusActive, domain.UserStatusDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unban expired users: %w", err)
+	}
+	defer rows.Close()
+
+	unbanned := make(map[string]*domain.BanInfo)
+	for rows.Next() {
+		var id string
+		var banInfoJSON sql.NullString
+		if err := rows.Scan(&id, &banInfoJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan unbanned user: %w", err)
+		}
+
+		banInfo := &domain.BanInfo{}
+		if banInfoJSON.Valid {
+			if err := json.Unmarshal([]byte(banInfoJSON.String), banInfo); err != nil {
+				fmt.Printf("Warning: failed to parse ban info of %s: %v\n", id, err)
+			}
+		}
+		unbanned[id] = banInfo
+	}
+
+	return unbanned, rows.Err()
+}
+
 // UpdateSubscription обновляет подписку пользователя
 func (r *PostgresUserRepository) UpdateSubscription(ctx context.Context, userID string, subscription *domain.SubscriptionInfo) error {
 	subscriptionJSON, err := json.Marshal(subscription)
@@ -529,7 +674,9 @@ func (r *PostgresUserRepository) CancelSubscription(ctx context.Context, userID
 	}
 
 	// Обновляем подписку
-	user.Subscription.Cancel(reason, immediate)
+	if err := user.Subscription.Cancel(reason, immediate); err != nil {
+		return err
+	}
 
 	// Сохраняем изменения
 	return r.UpdateSubscription(ctx, userID, user.Subscription)
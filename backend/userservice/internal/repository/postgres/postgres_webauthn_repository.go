@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgresWebAuthnRepository - хранилище ключей WebAuthn в PostgreSQL
+type PostgresWebAuthnRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresWebAuthnRepository создает новый репозиторий ключей WebAuthn
+func NewPostgresWebAuthnRepository(db *sqlx.DB) *PostgresWebAuthnRepository {
+	return &PostgresWebAuthnRepository{db: db}
+}
+
+// WebAuthnCredentialDBModel - модель ключа WebAuthn в базе данных
+type WebAuthnCredentialDBModel struct {
+	ID              string         `db:"id"`
+	UserID          string         `db:"user_id"`
+	CredentialID    []byte         `db:"credential_id"`
+	PublicKey       []byte         `db:"public_key"`
+	AttestationType string         `db:"attestation_type"`
+	Transports      pq.StringArray `db:"transports"`
+	AAGUID          []byte         `db:"aaguid"`
+	SignCount       int64          `db:"sign_count"`
+	UserVerified    bool           `db:"user_verified"`
+	BackupEligible  bool           `db:"backup_eligible"`
+	BackupState     bool           `db:"backup_state"`
+	Name            string         `db:"name"`
+	CreatedAt       time.Time      `db:"created_at"`
+	LastUsedAt      sql.NullTime   `db:"last_used_at"`
+}
+
+// ToDomain преобразует DB модель в доменную
+func (m *WebAuthnCredentialDBModel) ToDomain() *domain.WebAuthnCredential {
+	cred := &domain.WebAuthnCredential{
+		ID:              m.ID,
+		UserID:          m.UserID,
+		CredentialID:    m.CredentialID,
+		PublicKey:       m.PublicKey,
+		AttestationType: m.AttestationType,
+		Transports:      m.Transports,
+		AAGUID:          m.AAGUID,
+		SignCount:       uint32(m.SignCount),
+		UserVerified:    m.UserVerified,
+		BackupEligible:  m.BackupEligible,
+		BackupState:     m.BackupState,
+		Name:            m.Name,
+		CreatedAt:       m.CreatedAt,
+	}
+
+	if m.LastUsedAt.Valid {
+		cred.LastUsedAt = &m.LastUsedAt.Time
+	}
+
+	return cred
+}
+
+// WebAuthnSessionDBModel - модель сессии церемонии в базе данных
+type WebAuthnSessionDBModel struct {
+	ID        string         `db:"id"`
+	UserID    sql.NullString `db:"user_id"`
+	Ceremony  string         `db:"ceremony"`
+	Data      []byte         `db:"data"`
+	ExpiresAt time.Time      `db:"expires_at"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+// CreateCredential сохраняет новый ключ
+func (r *PostgresWebAuthnRepository) CreateCredential(ctx context.Context, cred *domain.WebAuthnCredential) error {
+	query := `
+		INSERT INTO webauthn_credentials (
+			id, user_id, credential_id, public_key, attestation_type, transports, aaguid,
+			sign_count, user_verified, backup_eligible, backup_state, name, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		cred.ID,
+		cred.UserID,
+		cred.CredentialID,
+		cred.PublicKey,
+		cred.AttestationType,
+		pq.StringArray(cred.Transports),
+		cred.AAGUID,
+		int64(cred.SignCount),
+		cred.UserVerified,
+		cred.BackupEligible,
+		cred.BackupState,
+		cred.Name,
+		cred.CreatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return domain.ErrWebAuthnCredentialExists
+		}
+		return fmt.Errorf("failed to create webauthn credential: %w", err)
+	}
+
+	return nil
+}
+
+// ListCredentials возвращает ключи пользователя
+func (r *PostgresWebAuthnRepository) ListCredentials(ctx context.Context, userID string) ([]*domain.WebAuthnCredential, error) {
+	var dbCreds []WebAuthnCredentialDBModel
+
+	query := `SELECT * FROM webauthn_credentials WHERE user_id = $1 ORDER BY created_at`
+	if err := r.db.SelectContext(ctx, &dbCreds, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list webauthn credentials: %w", err)
+	}
+
+	creds := make([]*domain.WebAuthnCredential, 0, len(dbCreds))
+	for i := range dbCreds {
+		creds = append(creds, dbCreds[i].ToDomain())
+	}
+
+	return creds, nil
+}
+
+// FindCredential находит ключ по ID, выданному аутентификатором
+func (r *PostgresWebAuthnRepository) FindCredential(ctx context.Context, credentialID []byte) (*domain.WebAuthnCredential, error) {
+	var dbCred WebAuthnCredentialDBModel
+
+	query := `SELECT * FROM webauthn_credentials WHERE credential_id = $1`
+	err := r.db.GetContext(ctx, &dbCred, query, credentialID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrWebAuthnCredentialNotFound
+		}
+		return nil, fmt.Errorf("failed to find webauthn credential: %w", err)
+	}
+
+	return dbCred.ToDomain(), nil
+}
+
+// UpdateCredentialUsage сохраняет счетчик подписей после успешного входа
+func (r *PostgresWebAuthnRepository) UpdateCredentialUsage(ctx context.Context, id string, signCount uint32, backupState bool, usedAt time.Time) error {
+	query := `
+		UPDATE webauthn_credentials SET sign_count = $1, backup_state = $2, last_used_at = $3
+		WHERE id = $4
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, int64(signCount), backupState, usedAt, id); err != nil {
+		return fmt.Errorf("failed to update webauthn credential: %w", err)
+	}
+
+	return nil
+}
+
+// RenameCredential меняет название ключа пользователя
+func (r *PostgresWebAuthnRepository) RenameCredential(ctx context.Context, userID, id, name string) (*domain.WebAuthnCredential, error) {
+	var dbCred WebAuthnCredentialDBModel
+
+	query := `UPDATE webauthn_credentials SET name = $1 WHERE id = $2 AND user_id = $3 RETURNING *`
+	err := r.db.GetContext(ctx, &dbCred, query, name, id, userID)
+
+	if err != nil {
+		if err == sql.ErrNoRows || isInvalidInput(err) {
+			return nil, domain.ErrWebAuthnCredentialNotFound
+		}
+		return nil, fmt.Errorf("failed to rename webauthn credential: %w", err)
+	}
+
+	return dbCred.ToDomain(), nil
+}
+
+// DeleteCredential удаляет ключ пользователя
+func (r *PostgresWebAuthnRepository) DeleteCredential(ctx context.Context, userID, id string) error {
+	query := `DELETE FROM webauthn_credentials WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		if isInvalidInput(err) {
+			return domain.ErrWebAuthnCredentialNotFound
+		}
+		return fmt.Errorf("failed to delete webauthn credential: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrWebAuthnCredentialNotFound
+	}
+
+	return nil
+}
+
+// CreateSession сохраняет сессию церемонии
+func (r *PostgresWebAuthnRepository) CreateSession(ctx context.Context, session *domain.WebAuthnSession) error {
+	query := `
+		INSERT INTO webauthn_sessions (id, user_id, ceremony, data, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	userID := sql.NullString{String: session.UserID, Valid: session.UserID != ""}
+	_, err := r.db.ExecContext(ctx, query,
+		session.ID,
+		userID,
+		string(session.Ceremony),
+		session.Data,
+		session.ExpiresAt,
+		session.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create webauthn session: %w", err)
+	}
+
+	// Попутно удаляем истекшие сессии
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM webauthn_sessions WHERE expires_at < $1`, time.Now()); err != nil {
+		fmt.Printf("Warning: failed to delete expired webauthn sessions: %v\n", err)
+	}
+
+	return nil
+}
+
+// ConsumeSession удаляет и возвращает действующую сессию
+func (r *PostgresWebAuthnRepository) ConsumeSession(ctx context.Context, id string, ceremony domain.WebAuthnCeremony) (*domain.WebAuthnSession, error) {
+	var dbSession WebAuthnSessionDBModel
+
+	query := `
+		DELETE FROM webauthn_sessions
+		WHERE id = $1 AND ceremony = $2 AND expires_at > $3
+		RETURNING *
+	`
+	err := r.db.GetContext(ctx, &dbSession, query, id, string(ceremony), time.Now())
+
+	if err != nil {
+		if err == sql.ErrNoRows || isInvalidInput(err) {
+			return nil, domain.ErrWebAuthnSessionInvalid
+		}
+		return nil, fmt.Errorf("failed to consume webauthn session: %w", err)
+	}
+
+	return &domain.WebAuthnSession{
+		ID:        dbSession.ID,
+		UserID:    dbSession.UserID.String,
+		Ceremony:  domain.WebAuthnCeremony(dbSession.Ceremony),
+		Data:      dbSession.Data,
+		ExpiresAt: dbSession.ExpiresAt,
+		CreatedAt: dbSession.CreatedAt,
+	}, nil
+}
+
+// isInvalidInput проверяет, что ID от клиента не является корректным UUID
+func isInvalidInput(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == "22P02"
+}
@@ -0,0 +1,121 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	loginFailuresPrefix = "lockout:failures:"
+	loginLockoutsPrefix = "lockout:count:"
+	loginLockedPrefix   = "lockout:until:"
+)
+
+// RedisLoginAttemptStore - счетчики неудачных входов и блокировки в Redis.
+// Общие для всех реплик, поэтому лимиты нельзя обойти, попадая на разные инстансы.
+type RedisLoginAttemptStore struct {
+	client *redis.Client
+}
+
+// NewRedisLoginAttemptStore создает новое хранилище счетчиков входа
+func NewRedisLoginAttemptStore(client *redis.Client) *RedisLoginAttemptStore {
+	return &RedisLoginAttemptStore{client: client}
+}
+
+// IncrementFailures увеличивает счетчик неудачных входов
+func (s *RedisLoginAttemptStore) IncrementFailures(ctx context.Context, key string, window time.Duration) (int, error) {
+	n, err := s.incrementWithTTL(ctx, loginFailuresPrefix+key, window)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment login failures: %w", err)
+	}
+
+	return n, nil
+}
+
+// ResetFailures сбрасывает счетчик неудачных входов
+func (s *RedisLoginAttemptStore) ResetFailures(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, loginFailuresPrefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+
+	return nil
+}
+
+// IncrementLockouts увеличивает счетчик блокировок подряд
+func (s *RedisLoginAttemptStore) IncrementLockouts(ctx context.Context, key string, ttl time.Duration) (int, error) {
+	key = loginLockoutsPrefix + key
+
+	n, err := s.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment lockouts: %w", err)
+	}
+
+	// Каждая новая блокировка продлевает память о предыдущих
+	if err := s.client.Expire(ctx, key, ttl).Err(); err != nil {
+		return 0, fmt.Errorf("failed to set lockouts ttl: %w", err)
+	}
+
+	return int(n), nil
+}
+
+// Lock блокирует вход до until
+func (s *RedisLoginAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+
+	if err := s.client.Set(ctx, loginLockedPrefix+key, until.Unix(), ttl).Err(); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+
+	return nil
+}
+
+// LockedUntil возвращает время окончания блокировки
+func (s *RedisLoginAttemptStore) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	val, err := s.client.Get(ctx, loginLockedPrefix+key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to get login lock: %w", err)
+	}
+
+	unix, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid login lock value: %w", err)
+	}
+
+	return time.Unix(unix, 0), nil
+}
+
+// Clear снимает блокировку и сбрасывает счетчики
+func (s *RedisLoginAttemptStore) Clear(ctx context.Context, key string) error {
+	err := s.client.Del(ctx, loginFailuresPrefix+key, loginLockoutsPrefix+key, loginLockedPrefix+key).Err()
+	if err != nil {
+		return fmt.Errorf("failed to clear lockout: %w", err)
+	}
+
+	return nil
+}
+
+// incrementWithTTL увеличивает счетчик; срок жизни задается при создании ключа
+func (s *RedisLoginAttemptStore) incrementWithTTL(ctx context.Context, key string, ttl time.Duration) (int, error) {
+	var incr *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireNX(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(incr.Val()), nil
+}
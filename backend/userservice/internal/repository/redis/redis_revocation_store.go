@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	revokedTokenPrefix  = "revoked:jti:"
+	revokedBeforePrefix = "revoked:user:"
+)
+
+// RedisRevocationStore - список отозванных токенов в Redis.
+// Ключи живут не дольше самих токенов, поэтому список не растет бесконечно.
+type RedisRevocationStore struct {
+	client *redis.Client
+}
+
+// NewRedisRevocationStore создает новое хранилище отозванных токенов
+func NewRedisRevocationStore(client *redis.Client) *RedisRevocationStore {
+	return &RedisRevocationStore{client: client}
+}
+
+// RevokeToken отзывает токен по jti
+func (s *RedisRevocationStore) RevokeToken(ctx context.Context, jti string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	if err := s.client.Set(ctx, revokedTokenPrefix+jti, 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	return nil
+}
+
+// IsTokenRevoked проверяет, отозван ли токен
+func (s *RedisRevocationStore) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	n, err := s.client.Exists(ctx, revokedTokenPrefix+jti).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+
+	return n > 0, nil
+}
+
+// RevokeAllForUser отзывает все токены пользователя, выпущенные до at
+func (s *RedisRevocationStore) RevokeAllForUser(ctx context.Context, userID string, at time.Time, ttl time.Duration) error {
+	err := s.client.Set(ctx, revokedBeforePrefix+userID, at.Unix(), ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+
+	return nil
+}
+
+// RevokedBefore возвращает момент последнего отзыва всех токенов пользователя
+func (s *RedisRevocationStore) RevokedBefore(ctx context.Context, userID string) (time.Time, error) {
+	val, err := s.client.Get(ctx, revokedBeforePrefix+userID).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to get user revocation: %w", err)
+	}
+
+	unix, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid user revocation value: %w", err)
+	}
+
+	return time.Unix(unix, 0), nil
+}
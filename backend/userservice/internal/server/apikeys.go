@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"userservice/internal/domain"
+	"userservice/pkg/jwt"
+)
+
+// CreateAPIKey выпускает API ключ пользователя. Полный ключ возвращается только здесь.
+// Права ключа ограничиваются scopes, но не могут превышать права роли владельца.
+func (s *UserService) CreateAPIKey(ctx context.Context, userID, name string, scopes []domain.Permission, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	p, err := s.requireSelf(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	// Ключом нельзя выпустить новый ключ, иначе его ограничения легко обойти
+	if p.IsAPIKey() {
+		return nil, "", domain.ErrSessionTokenRequired
+	}
+	if err := denyImpersonation(ctx); err != nil {
+		return nil, "", err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", domain.NewRequiredFieldError("name")
+	}
+
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, "", domain.NewInvalidFormatError("scopes", "известное право, например users.read")
+		}
+		if !p.Role.HasPermission(scope) {
+			return nil, "", domain.NewPermissionDeniedError(scope, p.Role)
+		}
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", domain.NewInvalidFormatError("expires_at", "дата в будущем")
+	}
+	if maxTTL := s.config.APIKeys.MaxTTL; maxTTL > 0 {
+		limit := time.Now().Add(maxTTL)
+		if expiresAt == nil || expiresAt.After(limit) {
+			expiresAt = &limit
+		}
+	}
+
+	existing, err := s.apiKeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	active := 0
+	for _, k := range existing {
+		if k.IsActive() {
+			active++
+		}
+	}
+	if max := s.config.APIKeys.MaxPerUser; max > 0 && active >= max {
+		return nil, "", domain.ErrAPIKeyLimitReached
+	}
+
+	prefix, secret, err := generateAPIKey()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	plain := domain.APIKeyPrefix + prefix + "_" + secret
+
+	key := domain.NewAPIKey(userID, name, prefix, jwt.HashToken(plain), scopes, expiresAt)
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeAPIKey, "", "", "")
+	activity.AddDetail("action", "created")
+	activity.AddDetail("api_key_id", key.ID)
+	s.logActivity(ctx, activity)
+
+	return key, plain, nil
+}
+
+// ListAPIKeys возвращает действующие и истекшие, но не отозванные ключи пользователя
+func (s *UserService) ListAPIKeys(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	if _, err := s.requireSelfOr(ctx, userID, domain.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	return s.apiKeyRepo.ListByUser(ctx, userID)
+}
+
+// RevokeAPIKey отзывает ключ. Чужие ключи отзывает администратор с правом sessions.revoke.
+func (s *UserService) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	if err := denyImpersonation(ctx); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.requireManage(ctx, user, domain.PermissionSessionsRevoke); err != nil {
+		return err
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, userID, keyID, time.Now()); err != nil {
+		return err
+	}
+
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeAPIKey, "", "", "")
+	activity.AddDetail("action", "revoked")
+	activity.AddDetail("api_key_id", keyID)
+	s.logActivity(ctx, activity)
+
+	return nil
+}
+
+// AuthenticateAPIKey проверяет API ключ и возвращает его владельца как вызывающего.
+// Роль берется из текущего профиля, поэтому понижение роли сразу действует и на ключи.
+func (s *UserService) AuthenticateAPIKey(ctx context.Context, plain string) (*domain.Principal, error) {
+	prefix, ok := domain.ParseAPIKey(plain)
+	if !ok {
+		return nil, domain.ErrInvalidToken
+	}
+
+	key, err := s.apiKeyRepo.FindByPrefix(ctx, prefix)
+	if err != nil {
+		if err == domain.ErrAPIKeyNotFound {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(jwt.HashToken(plain)), []byte(key.KeyHash)) != 1 || !key.IsActive() {
+		return nil, domain.ErrInvalidToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, key.UserID)
+	if err != nil {
+		var domainErr *domain.DomainError
+		if errors.As(err, &domainErr) && domainErr.Code == domain.ErrCodeUserNotFound {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if err := s.checkLoginAllowed(user); err != nil {
+		return nil, err
+	}
+
+	if err := s.apiKeyRepo.UpdateLastUsed(ctx, key.ID, time.Now()); err != nil {
+		fmt.Printf("Warning: failed to update api key usage: %v\n", err)
+	}
+
+	return &domain.Principal{
+		UserID:   user.ID,
+		Email:    user.Email,
+		Role:     user.Role,
+		IssuedAt: key.CreatedAt,
+		APIKeyID: key.ID,
+		Scopes:   key.Scopes,
+	}, nil
+}
+
+// generateAPIKey генерирует открытую часть ключа и секрет.
+// Открытая часть без "_", чтобы ключ однозначно разбирался.
+func generateAPIKey() (prefix, secret string, err error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	prefix = strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf))
+
+	secret, err = jwt.GenerateOpaqueToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	return prefix, secret, nil
+}
@@ -0,0 +1,227 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"userservice/internal/domain"
+	"userservice/pkg/jwt"
+)
+
+// bannedError выдает забаненному пользователю токен подачи апелляции.
+// Если токен выпустить не удалось, вход просто отклоняется.
+func (s *UserService) bannedError(user *domain.User) error {
+	token, err := s.jwtManager.GenerateBoundPurposeToken(user.ID, user.Email, jwt.PurposeBanAppeal, banRef(user.BanInfo), s.config.Appeals.TokenTTL)
+	if err != nil {
+		fmt.Printf("Warning: failed to generate appeal token: %v\n", err)
+		return domain.ErrUserBanned
+	}
+	return &domain.BannedError{AppealToken: token}
+}
+
+// banRef идентифицирует бан для привязки токена апелляции: время его выдачи
+func banRef(ban *domain.BanInfo) string {
+	if ban == nil {
+		return ""
+	}
+	return ban.BannedAt.UTC().Format(time.RFC3339Nano)
+}
+
+// SubmitBanAppeal подает апелляцию на текущий бан по токену из ошибки входа.
+// На каждый бан принимается только одна апелляция.
+func (s *UserService) SubmitBanAppeal(ctx context.Context, appealToken, message string) (*domain.BanAppeal, error) {
+	claims, err := s.jwtManager.ValidatePurposeToken(appealToken, jwt.PurposeBanAppeal)
+	if err != nil {
+		return nil, domain.ErrAppealTokenInvalid
+	}
+
+	if err := s.checkRevoked(ctx, claims); err != nil {
+		if err == domain.ErrTokenRevoked {
+			return nil, domain.ErrAppealTokenInvalid
+		}
+		return nil, err
+	}
+
+	if message == "" {
+		return nil, domain.NewRequiredFieldError("message")
+	}
+	if max := s.config.Appeals.MaxMessageLength; max > 0 && utf8.RuneCountInString(message) > max {
+		return nil, domain.NewInvalidLengthError("message", 0, max, utf8.RuneCountInString(message))
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, domain.ErrAppealTokenInvalid
+	}
+
+	// Бан мог истечь или быть снят, пока жил токен
+	if !user.IsBanned() {
+		return nil, domain.ErrBanNotFound
+	}
+
+	// Токен, выданный при прошлом бане, не подходит для обжалования нового
+	if claims.Ref != banRef(user.BanInfo) {
+		return nil, domain.ErrAppealTokenInvalid
+	}
+
+	appeal := domain.NewBanAppeal(user.ID, user.BanInfo.BannedAt, message)
+	if err := s.appealRepo.Create(ctx, appeal); err != nil {
+		return nil, err
+	}
+
+	s.logAppealChange(ctx, appeal, "appeal_submitted", user.ID)
+
+	return appeal, nil
+}
+
+// ListBanAppeals возвращает очередь апелляций для модераторов
+func (s *UserService) ListBanAppeals(ctx context.Context, filter *domain.AppealFilter) ([]*domain.BanAppeal, int64, error) {
+	if _, err := s.requirePermission(ctx, domain.PermissionUsersBan); err != nil {
+		return nil, 0, err
+	}
+
+	return s.appealRepo.List(ctx, filter)
+}
+
+// AssignBanAppeal назначает открытую апелляцию модератору и переводит ее в IN_REVIEW.
+// Назначить можно только пользователя с правом бана.
+func (s *UserService) AssignBanAppeal(ctx context.Context, appealID, moderatorID string) (*domain.BanAppeal, error) {
+	actor, err := s.requirePermission(ctx, domain.PermissionUsersBan)
+	if err != nil {
+		return nil, err
+	}
+
+	if moderatorID == "" {
+		moderatorID = actor.UserID
+	} else if !actor.IsSelf(moderatorID) {
+		moderator, err := s.userRepo.FindByID(ctx, moderatorID)
+		if err != nil {
+			return nil, err
+		}
+		if !moderator.Role.HasPermission(domain.PermissionUsersBan) {
+			return nil, domain.NewPermissionDeniedError(domain.PermissionUsersBan, moderator.Role)
+		}
+	}
+
+	appeal, err := s.appealRepo.FindByID(ctx, appealID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ok, err := s.appealRepo.Assign(ctx, appealID, moderatorID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrAppealClosed
+	}
+
+	appeal.AssignedTo = moderatorID
+	appeal.Status = domain.AppealStatusInReview
+	appeal.UpdatedAt = now
+
+	s.logAppealChange(ctx, appeal, "appeal_assigned", actor.UserID)
+
+	return appeal, nil
+}
+
+// ApproveBanAppeal удовлетворяет апелляцию и снимает обжалованный бан через UnbanUser
+func (s *UserService) ApproveBanAppeal(ctx context.Context, appealID, resolution string) (*domain.BanAppeal, error) {
+	return s.resolveBanAppeal(ctx, appealID, domain.AppealStatusApproved, resolution)
+}
+
+// RejectBanAppeal отклоняет апелляцию, бан остается
+func (s *UserService) RejectBanAppeal(ctx context.Context, appealID, resolution string) (*domain.BanAppeal, error) {
+	return s.resolveBanAppeal(ctx, appealID, domain.AppealStatusRejected, resolution)
+}
+
+// resolveBanAppeal закрывает апелляцию. Решение по апелляции может принять
+// только тот, кто вправе снять бан с ее автора.
+func (s *UserService) resolveBanAppeal(ctx context.Context, appealID string, status domain.AppealStatus, resolution string) (*domain.BanAppeal, error) {
+	actor, err := s.requirePermission(ctx, domain.PermissionUsersBan)
+	if err != nil {
+		return nil, err
+	}
+
+	appeal, err := s.appealRepo.FindByID(ctx, appealID)
+	if err != nil {
+		return nil, err
+	}
+	if !appeal.Status.IsOpen() {
+		return nil, domain.ErrAppealClosed
+	}
+
+	user, err := s.userRepo.FindByID(ctx, appeal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Role.CanManage(user.Role) {
+		return nil, domain.NewPermissionDeniedError(domain.PermissionUsersBan, actor.Role)
+	}
+
+	// Сначала снимаем бан: если разбан не удался, апелляция остается открытой
+	// и решение можно повторить. Новый бан, выданный после апелляции, одобрение
+	// не снимает. Истекший или уже снятый (в том числе прошлой попыткой) бан - не ошибка.
+	if status == domain.AppealStatusApproved && appeal.IsFor(user.BanInfo) {
+		if _, err := s.UnbanUser(ctx, user.ID); err != nil && err != domain.ErrBanNotFound {
+			return nil, err
+		}
+	}
+
+	// Закрытие условное, поэтому из параллельных решений проходит одно
+	now := time.Now()
+	ok, err := s.appealRepo.Resolve(ctx, appealID, status, actor.UserID, resolution, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrAppealClosed
+	}
+
+	appeal.Status = status
+	appeal.ResolvedBy = actor.UserID
+	appeal.Resolution = resolution
+	appeal.ResolvedAt = &now
+	appeal.UpdatedAt = now
+
+	if status == domain.AppealStatusApproved {
+		s.logAppealChange(ctx, appeal, "appeal_approved", actor.UserID)
+	} else {
+		s.logAppealChange(ctx, appeal, "appeal_rejected", actor.UserID)
+	}
+
+	return appeal, nil
+}
+
+// logAppealChange пишет переход апелляции в историю банов пользователя и в активность
+func (s *UserService) logAppealChange(ctx context.Context, appeal *domain.BanAppeal, action, actor string) {
+	details := map[string]interface{}{
+		"appeal_id": appeal.ID,
+		"status":    appeal.Status,
+		"banned_at": appeal.BannedAt,
+		"actor_id":  actor,
+		"user_id":   appeal.UserID,
+	}
+	if appeal.AssignedTo != "" {
+		details["assigned_to"] = appeal.AssignedTo
+	}
+	if appeal.Resolution != "" {
+		details["resolution"] = appeal.Resolution
+	}
+	if err := s.auditRepo.LogBanChange(ctx, appeal.UserID, action, details); err != nil {
+		fmt.Printf("Warning: failed to log ban change: %v\n", err)
+	}
+
+	activity := domain.NewUserActivity(appeal.UserID, domain.ActivityTypeBanAppeal, "", "", "")
+	activity.AddDetail("action", action)
+	activity.AddDetail("appeal_id", appeal.ID)
+	// Апелляцию подает сам забаненный, без access токена
+	if _, ok := domain.PrincipalFromContext(ctx); !ok {
+		activity.ActorID = actor
+		activity.ActorKind = domain.PrincipalKindUser
+	}
+	s.logActivity(ctx, activity)
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"userservice/internal/config"
+	"userservice/internal/domain"
+)
+
+type fakeAppealRepo struct {
+	domain.BanAppealRepository
+	appeals []*domain.BanAppeal
+}
+
+func (r *fakeAppealRepo) Create(ctx context.Context, appeal *domain.BanAppeal) error {
+	r.appeals = append(r.appeals, appeal)
+	return nil
+}
+
+func TestSubmitBanAppealBoundToBan(t *testing.T) {
+	firstBan := time.Date(2024, 3, 1, 10, 0, 0, 123456789, time.UTC)
+
+	tests := []struct {
+		name string
+		// bannedAt - время бана на момент подачи апелляции, нулевое - бан снят
+		bannedAt time.Time
+		wantErr  error
+	}{
+		{name: "same ban", bannedAt: firstBan},
+		{name: "new ban", bannedAt: firstBan.Add(time.Hour), wantErr: domain.ErrAppealTokenInvalid},
+		{name: "ban lifted", wantErr: domain.ErrBanNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newRefreshEnv(t)
+			appeals := &fakeAppealRepo{}
+			env.service.appealRepo = appeals
+			env.service.revoked = &fakeRevocationStore{revokedBefore: make(map[string]time.Time)}
+			env.service.config = &config.Config{Appeals: config.AppealsConfig{TokenTTL: time.Hour}}
+
+			env.user.BanInfo = &domain.BanInfo{IsBanned: true, BannedAt: firstBan}
+			var bannedErr *domain.BannedError
+			if err := env.service.bannedError(env.user); !errors.As(err, &bannedErr) {
+				t.Fatalf("bannedError = %v, want *domain.BannedError", err)
+			}
+
+			env.user.BanInfo = nil
+			if !tt.bannedAt.IsZero() {
+				env.user.BanInfo = &domain.BanInfo{IsBanned: true, BannedAt: tt.bannedAt}
+			}
+
+			_, err := env.service.SubmitBanAppeal(ctx, bannedErr.AppealToken, "please")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SubmitBanAppeal error = %v, want %v", err, tt.wantErr)
+			}
+			if submitted := len(appeals.appeals) == 1; submitted != (tt.wantErr == nil) {
+				t.Errorf("appeal submitted = %v, want %v", submitted, tt.wantErr == nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"userservice/internal/domain"
+)
+
+// systemActor - исполнитель действий, которые сервис выполняет сам
+const systemActor = "system"
+
+// principal возвращает вызывающего из контекста
+func principal(ctx context.Context) (*domain.Principal, error) {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrTokenNotProvided
+	}
+	return p, nil
+}
+
+// requirePermission проверяет, что у вызывающего есть право perm
+func (s *UserService) requirePermission(ctx context.Context, perm domain.Permission) (*domain.Principal, error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Can(perm) {
+		return nil, domain.NewPermissionDeniedError(perm, p.Role)
+	}
+	return p, nil
+}
+
+// requireUserPermission проверяет право perm на операцию над пользователем userID
+// с учетом организации из токена (см. requireTenantScope)
+func (s *UserService) requireUserPermission(ctx context.Context, userID string, perm domain.Permission) (*domain.Principal, error) {
+	p, err := s.requirePermission(ctx, perm)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireTenantScope(ctx, p, userID); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// requireTenantScope ограничивает операции над чужими аккаунтами с токеном
+// организации ее участниками, как и ListUsers. Остальные пользователи
+// для такого токена не существуют.
+//
+// Изоляция организаций намеренно держится только на уровне сервиса:
+// пользователь не принадлежит организации, а состоит в ней (и может состоять
+// в нескольких), поэтому запросы UserRepository организацию не учитывают.
+// Любой метод, работающий с чужим аккаунтом, обязан пройти через
+// requireUserPermission, requireSelfOr или requireManage.
+func (s *UserService) requireTenantScope(ctx context.Context, p *domain.Principal, userID string) error {
+	if p.TenantID == "" || p.IsSelf(userID) {
+		return nil
+	}
+	if !s.sharesTenant(ctx, userID) {
+		return domain.NewUserNotFoundError(userID)
+	}
+	return nil
+}
+
+// requireSelf проверяет, что операция выполняется над собственным аккаунтом
+// и вызывающему разрешено его менять (см. requireSelfWrite)
+func (s *UserService) requireSelf(ctx context.Context, userID string) (*domain.Principal, error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !p.IsSelf(userID) {
+		return nil, domain.NewPermissionDeniedError("", p.Role)
+	}
+	if err := requireSelfWrite(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// requireSelfOr пропускает операцию над своим аккаунтом или при наличии права perm.
+// С токеном организации чужие аккаунты доступны, только если это ее участники.
+func (s *UserService) requireSelfOr(ctx context.Context, userID string, perm domain.Permission) (*domain.Principal, error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !p.IsSelf(userID) && !p.Can(perm) {
+		return nil, domain.NewPermissionDeniedError(perm, p.Role)
+	}
+	if err := s.requireTenantScope(ctx, p, userID); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// requireManage проверяет право perm и то, что роль вызывающего выше роли цели.
+// Над своим аккаунтом операция разрешена без права perm, но с self.write.
+func (s *UserService) requireManage(ctx context.Context, target *domain.User, perm domain.Permission) (*domain.Principal, error) {
+	p, err := s.requireSelfOr(ctx, target.ID, perm)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsSelf(target.ID) {
+		if err := requireSelfWrite(p); err != nil {
+			return nil, err
+		}
+	} else if !p.Role.CanManage(target.Role) {
+		return nil, domain.NewPermissionDeniedError(perm, p.Role)
+	}
+	return p, nil
+}
+
+// requireSelfWrite не дает API ключу без права self.write менять аккаунт владельца.
+// Иначе ключ с узкими scopes мог бы удалить аккаунт, сменить email и пароль
+// или завершить сессии. Сессионный токен и ключ без scopes это право имеют.
+func requireSelfWrite(p *domain.Principal) error {
+	if !p.Can(domain.PermissionSelfWrite) {
+		return domain.NewPermissionDeniedError(domain.PermissionSelfWrite, p.Role)
+	}
+	return nil
+}
+
+// userPrincipal возвращает вызывающего-человека. Сервисным аккаунтам
+// недоступны операции от своего имени (организации, ключи и т.п.).
+func userPrincipal(ctx context.Context) (*domain.Principal, error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsService() {
+		return nil, domain.NewPermissionDeniedError("", p.Role)
+	}
+	return p, nil
+}
+
+// denyImpersonation запрещает операцию администратору, вошедшему от имени пользователя.
+// Так закрыты смена учетных данных, второго фактора и удаление аккаунта.
+func denyImpersonation(ctx context.Context) error {
+	if p, ok := domain.PrincipalFromContext(ctx); ok && p.IsImpersonated() {
+		return domain.ErrImpersonationForbidden
+	}
+	return nil
+}
+
+// actorID возвращает ID вызывающего или "system", если запрос без токена
+func actorID(ctx context.Context) string {
+	if p, ok := domain.PrincipalFromContext(ctx); ok {
+		return p.ActorID()
+	}
+	return systemActor
+}
+
+// logActivity записывает активность с ID и видом вызывающего.
+// Ошибка записи аудита не прерывает операцию.
+func (s *UserService) logActivity(ctx context.Context, activity *domain.UserActivity) {
+	if p, ok := domain.PrincipalFromContext(ctx); ok && activity.ActorID == "" {
+		activity.ActorID = p.ActorID()
+		activity.ActorKind = p.ActorKind()
+		if p.IsImpersonated() {
+			activity.AddDetail("impersonated_user_id", p.UserID)
+		}
+	}
+
+	if err := s.auditRepo.LogActivity(ctx, activity); err != nil {
+		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	}
+}
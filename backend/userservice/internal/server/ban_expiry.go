@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"userservice/internal/domain"
+)
+
+// ExpireBans снимает все временные баны, срок которых истек, и возвращает
+// их количество. Баны забираются пачками по batchSize.
+func (s *UserService) ExpireBans(ctx context.Context, batchSize int) (int, error) {
+	total := 0
+	for {
+		now := time.Now()
+		unbanned, err := s.userRepo.UnbanExpired(ctx, now, batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to unban expired users: %w", err)
+		}
+
+		for userID, banInfo := range unbanned {
+			s.logBanExpired(ctx, userID, banInfo, now)
+		}
+
+		total += len(unbanned)
+		if len(unbanned) == 0 || len(unbanned) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// logBanExpired пишет в аудит автоматическое снятие бана
+func (s *UserService) logBanExpired(ctx context.Context, userID string, banInfo *domain.BanInfo, now time.Time) {
+	details := map[string]interface{}{
+		"unbanned_by": systemActor,
+		"actor_id":    systemActor,
+		"reason":      "ban expired",
+		"unbanned_at": now,
+		"user_id":     userID,
+	}
+	if banInfo != nil {
+		details["restored_status"] = banInfo.RestoredStatus()
+		if banInfo.BannedUntil != nil {
+			details["banned_until"] = *banInfo.BannedUntil
+		}
+	}
+	if err := s.auditRepo.LogBanChange(ctx, userID, "unban", details); err != nil {
+		fmt.Printf("Warning: failed to log ban change: %v\n", err)
+	}
+
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeUnban, "", "", "")
+	activity.ActorID = systemActor
+	activity.ActorKind = domain.PrincipalKindSystem
+	activity.AddDetail("unbanned_by", systemActor)
+	activity.AddDetail("reason", "ban expired")
+	s.logActivity(ctx, activity)
+}
+
+// StartBanExpiry раз в interval снимает истекшие временные баны.
+// Строки забираются с SKIP LOCKED, поэтому воркер можно запускать на всех репликах.
+func (s *UserService) StartBanExpiry(ctx context.Context, interval time.Duration, batchSize int) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := s.ExpireBans(ctx, batchSize)
+				if err != nil {
+					log.Printf("Failed to expire bans: %v", err)
+				}
+				if n > 0 {
+					log.Printf("Lifted %d expired bans", n)
+				}
+			}
+		}
+	}()
+}
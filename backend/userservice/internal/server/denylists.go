@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"userservice/internal/domain"
+)
+
+// checkDenyLists проверяет запрос по спискам запрета. Как и при проверке
+// блокировок, недоступное хранилище не мешает входу.
+func (s *UserService) checkDenyLists(ctx context.Context, stage, email string, check *domain.DenyCheck) error {
+	entry, err := s.denyListRepo.Match(ctx, check, time.Now())
+	if err != nil {
+		fmt.Printf("Warning: failed to check deny lists: %v\n", err)
+		return nil
+	}
+	if entry == nil {
+		return nil
+	}
+
+	activity := domain.NewUserActivity("", domain.ActivityTypeDenyList, check.IP, "", check.DeviceID)
+	activity.AddDetail("action", "access_denied")
+	activity.AddDetail("stage", stage)
+	activity.AddDetail("email", email)
+	activity.AddDetail("entry_id", entry.ID)
+	activity.AddDetail("kind", entry.Kind)
+	s.logActivity(ctx, activity)
+
+	return domain.ErrDeniedByDenyList
+}
+
+// checkDisposableEmail отклоняет регистрацию на домены одноразовой почты
+func (s *UserService) checkDisposableEmail(ctx context.Context, email string, check *domain.DenyCheck) error {
+	if !s.disposableDomains.Contains(check.EmailDomain) {
+		return nil
+	}
+
+	activity := domain.NewUserActivity("", domain.ActivityTypeDenyList, check.IP, "", check.DeviceID)
+	activity.AddDetail("action", "access_denied")
+	activity.AddDetail("stage", "register")
+	activity.AddDetail("email", email)
+	activity.AddDetail("kind", "DISPOSABLE_EMAIL")
+	s.logActivity(ctx, activity)
+
+	return domain.ErrDisposableEmailDomain
+}
+
+// CreateDenyListEntry добавляет запрет на IP диапазон, email домен или устройство
+func (s *UserService) CreateDenyListEntry(ctx context.Context, kind domain.DenyListKind, value, reason string, expiresAt *time.Time) (*domain.DenyListEntry, error) {
+	actor, err := s.requirePermission(ctx, domain.PermissionDenyListManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if !kind.IsValid() {
+		return nil, domain.ErrInvalidDenyListKind
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, domain.NewInvalidFormatError("expires_at", "время в будущем")
+	}
+
+	entry, err := domain.NewDenyListEntry(kind, value, reason, actor.ActorID(), expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.denyListRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	s.logDenyListChange(ctx, entry, "entry_created")
+
+	return entry, nil
+}
+
+// GetDenyListEntry возвращает запись списка запрета
+func (s *UserService) GetDenyListEntry(ctx context.Context, id string) (*domain.DenyListEntry, error) {
+	if _, err := s.requirePermission(ctx, domain.PermissionDenyListManage); err != nil {
+		return nil, err
+	}
+
+	return s.denyListRepo.FindByID(ctx, id)
+}
+
+// ListDenyListEntries возвращает записи списков запрета, по умолчанию только действующие
+func (s *UserService) ListDenyListEntries(ctx context.Context, filter *domain.DenyListFilter) ([]*domain.DenyListEntry, int64, error) {
+	if _, err := s.requirePermission(ctx, domain.PermissionDenyListManage); err != nil {
+		return nil, 0, err
+	}
+
+	return s.denyListRepo.List(ctx, filter)
+}
+
+// UpdateDenyListEntry меняет причину и срок запрета. expiresAt == nil - бессрочно,
+// срок в прошлом снимает запрет с сохранением записи. Значение записи
+// не меняется: для другого значения создается новая запись.
+func (s *UserService) UpdateDenyListEntry(ctx context.Context, id, reason string, expiresAt *time.Time) (*domain.DenyListEntry, error) {
+	if _, err := s.requirePermission(ctx, domain.PermissionDenyListManage); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.denyListRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Reason = reason
+	entry.ExpiresAt = expiresAt
+	entry.UpdatedAt = time.Now()
+
+	if err := s.denyListRepo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	s.logDenyListChange(ctx, entry, "entry_updated")
+
+	return entry, nil
+}
+
+// DeleteDenyListEntry снимает запрет
+func (s *UserService) DeleteDenyListEntry(ctx context.Context, id string) error {
+	if _, err := s.requirePermission(ctx, domain.PermissionDenyListManage); err != nil {
+		return err
+	}
+
+	entry, err := s.denyListRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.denyListRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logDenyListChange(ctx, entry, "entry_deleted")
+
+	return nil
+}
+
+// logDenyListChange пишет изменение списков запрета в активность
+func (s *UserService) logDenyListChange(ctx context.Context, entry *domain.DenyListEntry, action string) {
+	activity := domain.NewUserActivity("", domain.ActivityTypeDenyList, "", "", "")
+	activity.AddDetail("action", action)
+	activity.AddDetail("entry_id", entry.ID)
+	activity.AddDetail("kind", entry.Kind)
+	activity.AddDetail("value", entry.Value)
+	if entry.ExpiresAt != nil {
+		activity.AddDetail("expires_at", *entry.ExpiresAt)
+	}
+	s.logActivity(ctx, activity)
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+	"userservice/internal/domain"
+)
+
+// ImpersonateUser выпускает короткоживущий access токен пользователя userID
+// для администратора поддержки. Refresh токен не выдается, реальный исполнитель
+// записывается в токен и во все записи аудита.
+func (s *UserService) ImpersonateUser(ctx context.Context, userID, reason string) (*domain.User, string, time.Time, error) {
+	actor, err := s.requireUserPermission(ctx, userID, domain.PermissionUsersImpersonate)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	if actor.IsAPIKey() {
+		return nil, "", time.Time{}, domain.ErrSessionTokenRequired
+	}
+	if err := denyImpersonation(ctx); err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, "", time.Time{}, domain.NewRequiredFieldError("reason")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	// Вход от имени другого администратора поддержки дал бы его права
+	if actor.IsSelf(user.ID) || user.Role.HasPermission(domain.PermissionUsersImpersonate) {
+		return nil, "", time.Time{}, domain.ErrImpersonationNotAllowed
+	}
+
+	ttl := s.config.JWT.ImpersonationExpiry
+	token, err := s.jwtManager.GenerateImpersonationToken(user.ID, user.Email, string(user.Role), actor.UserID, ttl)
+	if err != nil {
+		return nil, "", time.Time{}, domain.ErrTokenGeneration
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypeImpersonation, "", "", "")
+	activity.AddDetail("action", "start")
+	activity.AddDetail("reason", reason)
+	activity.AddDetail("expires_at", expiresAt)
+	s.logActivity(ctx, activity)
+
+	user.Password = ""
+	return user, token, expiresAt, nil
+}
+
+// StopImpersonation завершает вход от имени пользователя: отзывает текущий токен
+func (s *UserService) StopImpersonation(ctx context.Context) error {
+	p, err := principal(ctx)
+	if err != nil {
+		return err
+	}
+	if !p.IsImpersonated() {
+		return domain.ErrNotImpersonating
+	}
+
+	// Токен живет не дольше impersonation_expiry, столько и храним отзыв
+	if err := s.revoked.RevokeToken(ctx, p.TokenID, s.config.JWT.ImpersonationExpiry); err != nil {
+		return err
+	}
+
+	activity := domain.NewUserActivity(p.UserID, domain.ActivityTypeImpersonation, "", "", "")
+	activity.AddDetail("action", "end")
+	s.logActivity(ctx, activity)
+
+	return nil
+}
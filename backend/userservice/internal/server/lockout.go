@@ -0,0 +1,173 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"userservice/internal/domain"
+	"userservice/pkg/jwt"
+)
+
+// checkLockout проверяет, не заблокирован ли вход для аккаунта или IP.
+// Ошибки хранилища не мешают входу: лучше пропустить проверку, чем не пустить никого.
+func (s *UserService) checkLockout(ctx context.Context, email, ip string) error {
+	keys := []string{domain.AccountLockoutKey(email)}
+	if ip != "" {
+		keys = append(keys, domain.IPLockoutKey(ip))
+	}
+
+	for _, key := range keys {
+		until, err := s.attempts.LockedUntil(ctx, key)
+		if err != nil {
+			fmt.Printf("Warning: failed to check lockout: %v\n", err)
+			continue
+		}
+		if time.Now().Before(until) {
+			return domain.ErrAccountLocked
+		}
+	}
+
+	return nil
+}
+
+// recordLoginFailure учитывает неудачный вход и блокирует аккаунт или IP
+// при превышении лимита. user равен nil, если email не найден.
+func (s *UserService) recordLoginFailure(ctx context.Context, user *domain.User, email, ip, reason string) {
+	cfg := s.config.Lockout
+
+	accountLocked := s.registerFailure(ctx, domain.AccountLockoutKey(email), cfg.MaxAccountFailures)
+	ipLocked := false
+	if ip != "" {
+		ipLocked = s.registerFailure(ctx, domain.IPLockoutKey(ip), cfg.MaxIPFailures)
+	}
+
+	// Логируем неудачную попытку, в том числе для несуществующих email
+	userID := ""
+	if user != nil {
+		userID = user.ID
+	}
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeLogin, ip, "", "")
+	activity.AddDetail("result", "failed")
+	activity.AddDetail("reason", reason)
+	activity.AddDetail("email", email)
+	if accountLocked {
+		activity.AddDetail("account_locked", true)
+	}
+	if ipLocked {
+		activity.AddDetail("ip_locked", true)
+	}
+	s.logActivity(ctx, activity)
+}
+
+// checkMFALockout проверяет, не заблокирован ли ввод второго фактора.
+// Как и checkLockout, при ошибке хранилища пропускает проверку.
+func (s *UserService) checkMFALockout(ctx context.Context, userID string) error {
+	until, err := s.attempts.LockedUntil(ctx, domain.MFALockoutKey(userID))
+	if err != nil {
+		fmt.Printf("Warning: failed to check lockout: %v\n", err)
+		return nil
+	}
+	if time.Now().Before(until) {
+		return domain.ErrAccountLocked
+	}
+
+	return nil
+}
+
+// recordMFAFailure учитывает неверный код второго фактора. Пароль к этому
+// моменту уже проверен, поэтому счетчик ведется по пользователю, а при
+// блокировке токен входа отзывается: продолжить можно только с новым паролем.
+func (s *UserService) recordMFAFailure(ctx context.Context, user *domain.User, claims *jwt.Claims) {
+	locked := s.registerFailure(ctx, domain.MFALockoutKey(user.ID), s.config.Lockout.MaxMFAFailures)
+	if locked {
+		if err := s.revoked.RevokeToken(ctx, claims.ID, time.Until(claims.ExpiresAt.Time)); err != nil {
+			fmt.Printf("Warning: failed to revoke mfa token: %v\n", err)
+		}
+	}
+
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypeLogin, "", "", "")
+	activity.AddDetail("result", "failed")
+	activity.AddDetail("reason", "invalid_mfa_code")
+	if locked {
+		activity.AddDetail("mfa_locked", true)
+	}
+	s.logActivity(ctx, activity)
+}
+
+// registerFailure увеличивает счетчик ключа и блокирует его, если лимит превышен.
+// Каждая следующая блокировка подряд вдвое дольше предыдущей.
+func (s *UserService) registerFailure(ctx context.Context, key string, maxFailures int) bool {
+	cfg := s.config.Lockout
+	if maxFailures <= 0 {
+		return false
+	}
+
+	failures, err := s.attempts.IncrementFailures(ctx, key, cfg.FailureWindow)
+	if err != nil {
+		fmt.Printf("Warning: failed to record login failure: %v\n", err)
+		return false
+	}
+	if failures < maxFailures {
+		return false
+	}
+
+	level, err := s.attempts.IncrementLockouts(ctx, key, cfg.ResetAfter)
+	if err != nil {
+		fmt.Printf("Warning: failed to record lockout: %v\n", err)
+		level = 1
+	}
+
+	until := time.Now().Add(domain.LockoutDuration(cfg.BaseDuration, cfg.MaxDuration, level))
+	if err := s.attempts.Lock(ctx, key, until); err != nil {
+		fmt.Printf("Warning: failed to lock login: %v\n", err)
+		return false
+	}
+
+	// После блокировки попытки считаются заново
+	if err := s.attempts.ResetFailures(ctx, key); err != nil {
+		fmt.Printf("Warning: failed to reset login failures: %v\n", err)
+	}
+
+	return true
+}
+
+// ClearLockout снимает блокировку входа с аккаунта и/или IP адреса
+func (s *UserService) ClearLockout(ctx context.Context, email, ip string) error {
+	if _, err := s.requirePermission(ctx, domain.PermissionLockoutClear); err != nil {
+		return err
+	}
+
+	if email == "" && ip == "" {
+		return domain.NewRequiredFieldError("email")
+	}
+
+	if email != "" {
+		if err := s.attempts.Clear(ctx, domain.AccountLockoutKey(email)); err != nil {
+			return err
+		}
+	}
+	if ip != "" {
+		if err := s.attempts.Clear(ctx, domain.IPLockoutKey(ip)); err != nil {
+			return err
+		}
+	}
+
+	// Логируем активность
+	userID := ""
+	if email != "" {
+		if user, err := s.userRepo.FindByEmail(ctx, email); err == nil {
+			userID = user.ID
+			if err := s.attempts.Clear(ctx, domain.MFALockoutKey(user.ID)); err != nil {
+				return err
+			}
+		}
+	}
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeLogin, ip, "", "")
+	activity.AddDetail("action", "lockout_cleared")
+	if email != "" {
+		activity.AddDetail("email", email)
+	}
+	s.logActivity(ctx, activity)
+
+	return nil
+}
@@ -0,0 +1,402 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+	"userservice/internal/domain"
+)
+
+var orgSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}$`)
+
+// CreateOrganization создает организацию, вызывающий становится ее владельцем
+func (s *UserService) CreateOrganization(ctx context.Context, name, slug string) (*domain.Organization, error) {
+	p, err := userPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireSelfWrite(p); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, domain.NewRequiredFieldError("name")
+	}
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if !orgSlugPattern.MatchString(slug) {
+		return nil, domain.NewInvalidFormatError("slug", "латинские буквы, цифры и дефис, от 2 до 63 символов")
+	}
+
+	org := domain.NewOrganization(name, slug, p.UserID)
+	owner := &domain.OrgMember{
+		OrganizationID: org.ID,
+		UserID:         p.UserID,
+		Role:           domain.OrgRoleOwner,
+		JoinedAt:       org.CreatedAt,
+	}
+
+	if err := s.orgRepo.Create(ctx, org, owner); err != nil {
+		return nil, err
+	}
+
+	s.logOrgActivity(ctx, p.UserID, org.ID, "create")
+
+	return org, nil
+}
+
+// GetOrganization возвращает организацию участнику
+func (s *UserService) GetOrganization(ctx context.Context, orgID string) (*domain.Organization, error) {
+	if _, _, err := s.requireOrgMember(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.FindByID(ctx, orgID)
+}
+
+// ListOrganizations возвращает организации вызывающего
+func (s *UserService) ListOrganizations(ctx context.Context) ([]*domain.Organization, error) {
+	p, err := userPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.ListForUser(ctx, p.UserID)
+}
+
+// ListOrganizationMembers возвращает участников организации с их ролями
+func (s *UserService) ListOrganizationMembers(ctx context.Context, orgID string) ([]*domain.OrgMember, error) {
+	if _, _, err := s.requireOrgMember(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.ListMembers(ctx, orgID)
+}
+
+// UpdateOrganizationMember меняет роль участника.
+// Администратор управляет только обычными участниками, владельцев назначает владелец.
+func (s *UserService) UpdateOrganizationMember(ctx context.Context, orgID, userID string, role domain.OrgRole) (*domain.OrgMember, error) {
+	if !role.IsValid() {
+		return nil, domain.ErrInvalidOrgRole
+	}
+
+	p, actor, err := s.requireOrgMember(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireSelfWrite(p); err != nil {
+		return nil, err
+	}
+
+	target, err := s.orgRepo.FindMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !actor.Role.CanManage(target.Role) || !actor.Role.CanAssign(role) {
+		return nil, domain.NewPermissionDeniedError("", p.Role)
+	}
+
+	if target.Role == domain.OrgRoleOwner && role != domain.OrgRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.orgRepo.UpdateMemberRole(ctx, orgID, userID, role); err != nil {
+		return nil, err
+	}
+	target.Role = role
+
+	s.logOrgActivity(ctx, userID, orgID, "role_changed", "role", string(role))
+
+	return target, nil
+}
+
+// RemoveOrganizationMember исключает участника. Свой ID означает выход из организации.
+func (s *UserService) RemoveOrganizationMember(ctx context.Context, orgID, userID string) error {
+	p, actor, err := s.requireOrgMember(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if err := requireSelfWrite(p); err != nil {
+		return err
+	}
+
+	target := actor
+	if !p.IsSelf(userID) {
+		target, err = s.orgRepo.FindMember(ctx, orgID, userID)
+		if err != nil {
+			return err
+		}
+		if !actor.Role.CanManage(target.Role) {
+			return domain.NewPermissionDeniedError("", p.Role)
+		}
+	}
+
+	if target.Role == domain.OrgRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.orgRepo.RemoveMember(ctx, orgID, userID); err != nil {
+		return err
+	}
+
+	s.logOrgActivity(ctx, userID, orgID, "member_removed")
+
+	return nil
+}
+
+// InviteToOrganization приглашает пользователя по email и отправляет ему письмо
+func (s *UserService) InviteToOrganization(ctx context.Context, orgID, email string, role domain.OrgRole) (*domain.OrgInvitation, error) {
+	if role == "" {
+		role = domain.OrgRoleMember
+	}
+	if !role.IsValid() {
+		return nil, domain.ErrInvalidOrgRole
+	}
+
+	email = strings.TrimSpace(email)
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, domain.NewInvalidFormatError("email", "адрес электронной почты")
+	}
+
+	p, actor, err := s.requireOrgMember(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireSelfWrite(p); err != nil {
+		return nil, err
+	}
+	if !actor.Role.CanAssign(role) {
+		return nil, domain.NewPermissionDeniedError("", p.Role)
+	}
+
+	// Уже состоящих в организации повторно не приглашаем
+	if user, err := s.userRepo.FindByEmail(ctx, email); err == nil {
+		if _, err := s.orgRepo.FindMember(ctx, orgID, user.ID); err == nil {
+			return nil, domain.ErrAlreadyOrganizationMember
+		}
+	}
+
+	org, err := s.orgRepo.FindByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := s.config.Organizations.InvitationTTL
+	inv := domain.NewOrgInvitation(orgID, email, role, p.UserID, ttl)
+	if err := s.orgRepo.CreateInvitation(ctx, inv); err != nil {
+		return nil, err
+	}
+
+	msg := &domain.EmailMessage{
+		To:      inv.Email,
+		Subject: fmt.Sprintf("Приглашение в организацию %s", org.Name),
+		Body: fmt.Sprintf(
+			"Вас пригласили в организацию %s. Войдите в аккаунт с этим адресом, чтобы принять или отклонить приглашение:\n%s\n\nПриглашение действует %s.\n",
+			org.Name, s.config.Organizations.InvitationURL, ttl,
+		),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		fmt.Printf("Warning: failed to send invitation: %v\n", err)
+	}
+
+	s.logOrgActivity(ctx, p.UserID, orgID, "invite", "email", inv.Email, "role", string(role))
+
+	return inv, nil
+}
+
+// ListMyInvitations возвращает действующие приглашения на email вызывающего
+func (s *UserService) ListMyInvitations(ctx context.Context) ([]*domain.OrgInvitation, error) {
+	p, err := userPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := s.verifiedEmail(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.ListInvitationsForEmail(ctx, email)
+}
+
+// AcceptInvitation принимает приглашение и добавляет вызывающего в организацию
+func (s *UserService) AcceptInvitation(ctx context.Context, orgID, invitationID string) (*domain.OrgInvitation, error) {
+	return s.respondInvitation(ctx, orgID, invitationID, domain.InvitationStatusAccepted)
+}
+
+// DeclineInvitation отклоняет приглашение
+func (s *UserService) DeclineInvitation(ctx context.Context, orgID, invitationID string) (*domain.OrgInvitation, error) {
+	return s.respondInvitation(ctx, orgID, invitationID, domain.InvitationStatusDeclined)
+}
+
+// respondInvitation закрывает приглашение, адресованное email вызывающего
+func (s *UserService) respondInvitation(ctx context.Context, orgID, invitationID string, status domain.InvitationStatus) (*domain.OrgInvitation, error) {
+	p, err := userPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireSelfWrite(p); err != nil {
+		return nil, err
+	}
+
+	email, err := s.verifiedEmail(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := s.orgRepo.FindInvitation(ctx, orgID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Чужие приглашения не раскрываем
+	if !inv.IsFor(email) || inv.Status != domain.InvitationStatusPending {
+		return nil, domain.ErrInvitationNotFound
+	}
+	if inv.IsExpired() {
+		return nil, domain.ErrInvitationExpired
+	}
+
+	now := time.Now()
+	ok, err := s.orgRepo.RespondInvitation(ctx, inv, p.UserID, status, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrInvitationNotFound
+	}
+	inv.Status = status
+	inv.RespondedAt = &now
+
+	s.logOrgActivity(ctx, p.UserID, orgID, strings.ToLower(string(status)), "invitation_id", inv.ID)
+
+	return inv, nil
+}
+
+// SwitchOrganization выдает новую пару токенов в контексте организации orgID.
+// Пустой orgID выдает токены вне организации.
+func (s *UserService) SwitchOrganization(ctx context.Context, orgID, ip, deviceID string) (*domain.User, *domain.TokenPair, error) {
+	p, err := userPrincipal(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Иначе API ключ с ограниченными правами можно обменять на полноценные токены
+	if p.IsAPIKey() {
+		return nil, nil, domain.ErrSessionTokenRequired
+	}
+	// Токен имперсонации не продлевается через refresh
+	if err := denyImpersonation(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	if orgID != "" {
+		if _, err := s.orgRepo.FindMember(ctx, orgID, p.UserID); err != nil {
+			return nil, nil, domain.ErrOrganizationNotFound
+		}
+	}
+
+	user, err := s.userRepo.FindByID(ctx, p.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkLoginAllowed(user); err != nil {
+		return nil, nil, err
+	}
+	check := &domain.DenyCheck{IP: ip, EmailDomain: domain.EmailDomain(user.Email), DeviceID: deviceID}
+	if err := s.checkDenyLists(ctx, "switch_organization", user.Email, check); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.issueTokens(ctx, user, "", orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.logOrgActivity(ctx, p.UserID, orgID, "switch")
+
+	user.Password = ""
+	return user, tokens, nil
+}
+
+// verifiedEmail возвращает текущий email вызывающего из базы, если он подтвержден.
+// Email в токене мог устареть, а неподтвержденный адрес не доказывает,
+// что приглашение адресовано именно этому пользователю. Статус не годится:
+// без обязательного подтверждения аккаунт активен с любым адресом.
+func (s *UserService) verifiedEmail(ctx context.Context, p *domain.Principal) (string, error) {
+	user, err := s.userRepo.FindByID(ctx, p.UserID)
+	if err != nil {
+		return "", err
+	}
+	if !user.IsEmailVerified() {
+		return "", domain.ErrEmailNotVerified
+	}
+	return user.Email, nil
+}
+
+// requireOrgMember проверяет, что вызывающий состоит в организации.
+// Не участникам организация не раскрывается.
+func (s *UserService) requireOrgMember(ctx context.Context, orgID string) (*domain.Principal, *domain.OrgMember, error) {
+	p, err := userPrincipal(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	member, err := s.orgRepo.FindMember(ctx, orgID, p.UserID)
+	if err != nil {
+		if err == domain.ErrNotOrganizationMember {
+			return nil, nil, domain.ErrOrganizationNotFound
+		}
+		return nil, nil, err
+	}
+
+	return p, member, nil
+}
+
+// ensureAnotherOwner не дает оставить организацию без владельца
+func (s *UserService) ensureAnotherOwner(ctx context.Context, orgID string) error {
+	owners, err := s.orgRepo.CountOwners(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return domain.ErrLastOrganizationOwner
+	}
+	return nil
+}
+
+// sharesTenant проверяет, что вызывающий и пользователь userID состоят
+// в организации из токена вызывающего
+func (s *UserService) sharesTenant(ctx context.Context, userID string) bool {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok || p.TenantID == "" {
+		return false
+	}
+
+	for _, id := range []string{p.UserID, userID} {
+		if _, err := s.orgRepo.FindMember(ctx, p.TenantID, id); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// logOrgActivity пишет действие с организацией в журнал активности пользователя userID.
+// details - пары ключ-значение.
+func (s *UserService) logOrgActivity(ctx context.Context, userID, orgID, action string, details ...string) {
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeOrganization, "", "", "")
+	activity.AddDetail("action", action)
+	activity.AddDetail("organization_id", orgID)
+	for i := 0; i+1 < len(details); i += 2 {
+		activity.AddDetail(details[i], details[i+1])
+	}
+	s.logActivity(ctx, activity)
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"userservice/internal/domain"
+)
+
+// validateNewPassword проверяет новый пароль по политике, базе утекших паролей
+// и истории паролей пользователя. У нового пользователя (без ID) история не проверяется.
+func (s *UserService) validateNewPassword(ctx context.Context, user *domain.User, password string) error {
+	violations := s.policy.Check(password, user.Email, user.Name)
+
+	if v := s.checkBreached(ctx, password); v != nil {
+		violations = append(violations, *v)
+	}
+
+	if user.ID != "" {
+		reused, err := s.isPasswordReused(ctx, user, password)
+		if err != nil {
+			return err
+		}
+		if reused {
+			violations = append(violations, domain.PasswordViolation{
+				Code:    domain.PasswordViolationReused,
+				Message: fmt.Sprintf("Пароль не должен совпадать с %d последними паролями", s.config.PasswordPolicy.HistorySize+1),
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return domain.NewPasswordPolicyError(violations)
+	}
+	return nil
+}
+
+// checkBreached проверяет пароль по базе утекших паролей.
+// Ошибка чтения базы не мешает смене пароля.
+func (s *UserService) checkBreached(ctx context.Context, password string) *domain.PasswordViolation {
+	if s.breached == nil {
+		return nil
+	}
+
+	breached, err := s.breached.IsBreached(ctx, password)
+	if err != nil {
+		fmt.Printf("Warning: failed to check breached passwords: %v\n", err)
+		return nil
+	}
+	if !breached {
+		return nil
+	}
+
+	return &domain.PasswordViolation{
+		Code:    domain.PasswordViolationBreached,
+		Message: "Пароль встречается в известных утечках, выберите другой",
+	}
+}
+
+// isPasswordReused сравнивает пароль с текущим и последними history_size прежними
+func (s *UserService) isPasswordReused(ctx context.Context, user *domain.User, password string) (bool, error) {
+	size := s.config.PasswordPolicy.HistorySize
+	if size <= 0 {
+		return false, nil
+	}
+
+	hashes, err := s.passwordHistory.List(ctx, user.ID, size)
+	if err != nil {
+		return false, err
+	}
+	if user.Password != "" {
+		hashes = append([]string{user.Password}, hashes...)
+	}
+
+	for _, hash := range hashes {
+		if ok, _ := s.passwords.Verify(password, hash); ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// rememberPassword сохраняет заменяемый хеш пароля в историю
+func (s *UserService) rememberPassword(ctx context.Context, userID, oldHash string) {
+	size := s.config.PasswordPolicy.HistorySize
+	if size <= 0 || oldHash == "" {
+		return
+	}
+
+	if err := s.passwordHistory.Add(ctx, userID, oldHash, size); err != nil {
+		fmt.Printf("Warning: failed to save password history: %v\n", err)
+	}
+}
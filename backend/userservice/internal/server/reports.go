@@ -0,0 +1,232 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"userservice/internal/domain"
+)
+
+// ReportUser принимает жалобу на пользователя. Повторная жалоба того же
+// заявителя не увеличивает приоритет; bool в ответе - признак повтора.
+func (s *UserService) ReportUser(ctx context.Context, userID string, category domain.ReportCategory, description string, evidence map[string]string) (*domain.UserReport, bool, error) {
+	reporter, err := userPrincipal(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if reporter.IsSelf(userID) {
+		return nil, false, domain.ErrSelfReportNotAllowed
+	}
+
+	if !category.IsValid() {
+		return nil, false, domain.ErrInvalidReportCategory
+	}
+	if max := s.config.Reports.MaxDescriptionLength; max > 0 && utf8.RuneCountInString(description) > max {
+		return nil, false, domain.NewInvalidLengthError("description", 0, max, utf8.RuneCountInString(description))
+	}
+	if max := s.config.Reports.MaxEvidenceItems; max > 0 && len(evidence) > max {
+		return nil, false, domain.NewInvalidLengthError("evidence", 0, max, len(evidence))
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, false, err
+	}
+
+	report := domain.NewUserReport(userID, category)
+	entry := &domain.ReportEntry{
+		ReportID:    report.ID,
+		ReporterID:  reporter.UserID,
+		Description: description,
+		Evidence:    evidence,
+		CreatedAt:   report.CreatedAt,
+	}
+
+	saved, duplicate, err := s.reportRepo.Submit(ctx, report, entry)
+	if err != nil {
+		return nil, false, err
+	}
+
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeReport, "", "", "")
+	activity.AddDetail("action", "report_submitted")
+	activity.AddDetail("report_id", saved.ID)
+	activity.AddDetail("category", category)
+	activity.AddDetail("duplicate", duplicate)
+	s.logActivity(ctx, activity)
+
+	return saved, duplicate, nil
+}
+
+// ListModerationQueue возвращает жалобы по убыванию приоритета
+func (s *UserService) ListModerationQueue(ctx context.Context, filter *domain.ReportFilter) ([]*domain.UserReport, int64, error) {
+	if _, err := s.requirePermission(ctx, domain.PermissionReportsModerate); err != nil {
+		return nil, 0, err
+	}
+
+	return s.reportRepo.ListQueue(ctx, filter)
+}
+
+// ClaimReport берет жалобу в работу и возвращает ее вместе с жалобами заявителей.
+// Повторный claim своей жалобы не ошибка.
+func (s *UserService) ClaimReport(ctx context.Context, reportID string) (*domain.UserReport, error) {
+	actor, err := s.requirePermission(ctx, domain.PermissionReportsModerate)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.reportRepo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ok, err := s.reportRepo.Claim(ctx, reportID, actor.UserID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrReportClosed
+	}
+
+	if report.Status == domain.ReportStatusOpen {
+		report.ClaimedAt = &now
+	}
+	report.Status = domain.ReportStatusClaimed
+	report.ClaimedBy = actor.UserID
+	report.UpdatedAt = now
+
+	report.Entries, err = s.reportRepo.ListEntries(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := domain.NewUserActivity(report.ReportedUserID, domain.ActivityTypeReport, "", "", "")
+	activity.AddDetail("action", "report_claimed")
+	activity.AddDetail("report_id", report.ID)
+	s.logActivity(ctx, activity)
+
+	return report, nil
+}
+
+// ResolveReport выносит решение по жалобе, взятой вызывающим в работу.
+// Жалоба сначала закрывается, и только потом выдается наказание, так что
+// два запроса не накажут за одно нарушение дважды. WARN выдает страйк через
+// IssueWarning, BAN банит через BanUser с категорией жалобы. Выданный бан
+// и страйк привязываются к жалобе. Если наказать не удалось, жалоба
+// возвращается в работу к модератору.
+func (s *UserService) ResolveReport(ctx context.Context, reportID string, resolution *domain.ReportResolution) (*domain.UserReport, error) {
+	actor, err := s.requirePermission(ctx, domain.PermissionReportsModerate)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resolution.Action {
+	case domain.ReportActionDismiss, domain.ReportActionWarn, domain.ReportActionBan:
+	default:
+		return nil, domain.ErrInvalidReportResolution
+	}
+
+	report, err := s.reportRepo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if !report.Status.IsOpen() {
+		return nil, domain.ErrReportClosed
+	}
+	if !report.IsClaimedBy(actor.UserID) {
+		return nil, domain.ErrReportNotClaimed
+	}
+
+	now := time.Now()
+	report.Status = domain.ReportStatusResolved
+	report.Action = resolution.Action
+	report.Resolution = resolution.Note
+	report.ResolvedBy = actor.UserID
+	report.ResolvedAt = &now
+	report.UpdatedAt = now
+
+	ok, err := s.reportRepo.Resolve(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrReportClosed
+	}
+
+	if err := s.punishReported(ctx, report, resolution); err != nil {
+		if reopenErr := s.reportRepo.Reopen(ctx, report.ID, actor.UserID); reopenErr != nil {
+			fmt.Printf("Warning: failed to reopen report: %v\n", reopenErr)
+		}
+		return nil, err
+	}
+
+	if report.BannedAt != nil || report.StrikeID != "" {
+		if err := s.reportRepo.SetOutcome(ctx, report); err != nil {
+			fmt.Printf("Warning: failed to set report outcome: %v\n", err)
+		}
+	}
+
+	s.logReportResolved(ctx, report)
+
+	return report, nil
+}
+
+// punishReported выдает наказание по решению и записывает его в report
+func (s *UserService) punishReported(ctx context.Context, report *domain.UserReport, resolution *domain.ReportResolution) error {
+	reason := resolution.Note
+	if reason == "" {
+		reason = fmt.Sprintf("report %s: %s", report.ID, report.Category)
+	}
+
+	switch resolution.Action {
+	case domain.ReportActionWarn:
+		result, err := s.IssueWarning(ctx, report.ReportedUserID, reason)
+		if err != nil {
+			return err
+		}
+		report.StrikeID = result.Strike.ID
+		if result.Banned {
+			bannedAt := result.User.BanInfo.BannedAt.Truncate(time.Microsecond)
+			report.BannedAt = &bannedAt
+		}
+	case domain.ReportActionBan:
+		user, err := s.banUser(ctx, report.ReportedUserID, reason, report.Category.BanCategory(), resolution.BanDuration, report.ID)
+		if err != nil {
+			return err
+		}
+		bannedAt := user.BanInfo.BannedAt.Truncate(time.Microsecond)
+		report.BannedAt = &bannedAt
+	}
+
+	return nil
+}
+
+// logReportResolved пишет решение по жалобе в историю банов и в активность.
+// В истории банов решение связывает жалобу с выданным баном или страйком.
+func (s *UserService) logReportResolved(ctx context.Context, report *domain.UserReport) {
+	if report.Action != domain.ReportActionDismiss {
+		details := map[string]interface{}{
+			"report_id": report.ID,
+			"action":    report.Action,
+			"actor_id":  report.ResolvedBy,
+			"user_id":   report.ReportedUserID,
+		}
+		if report.BannedAt != nil {
+			details["banned_at"] = *report.BannedAt
+		}
+		if report.StrikeID != "" {
+			details["strike_id"] = report.StrikeID
+		}
+		if err := s.auditRepo.LogBanChange(ctx, report.ReportedUserID, "report_resolved", details); err != nil {
+			fmt.Printf("Warning: failed to log ban change: %v\n", err)
+		}
+	}
+
+	activity := domain.NewUserActivity(report.ReportedUserID, domain.ActivityTypeReport, "", "", "")
+	activity.AddDetail("action", "report_resolved")
+	activity.AddDetail("report_id", report.ID)
+	activity.AddDetail("decision", report.Action)
+	s.logActivity(ctx, activity)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"time"
+	"userservice/internal/domain"
+	"userservice/pkg/jwt"
+)
+
+// IssueServiceToken обменивает client credentials сервисного аккаунта на access токен,
+// действительный только для audience
+func (s *UserService) IssueServiceToken(ctx context.Context, clientID, clientSecret, audience string) (*domain.ServiceToken, error) {
+	if audience == "" {
+		return nil, domain.NewRequiredFieldError("audience")
+	}
+
+	account, err := s.serviceAccounts.FindByClientID(ctx, clientID)
+	if err != nil {
+		if err == domain.ErrServiceAccountNotFound {
+			return nil, domain.ErrInvalidClientCredentials
+		}
+		return nil, err
+	}
+
+	hash := jwt.HashToken(clientSecret)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(account.SecretHash)) != 1 || account.Disabled {
+		s.logServiceActivity(ctx, clientID, "failed", audience)
+		return nil, domain.ErrInvalidClientCredentials
+	}
+
+	if !account.AllowsAudience(audience) {
+		s.logServiceActivity(ctx, clientID, "audience_not_allowed", audience)
+		return nil, domain.ErrAudienceNotAllowed
+	}
+
+	permissions := make([]string, 0, len(account.Permissions))
+	for _, perm := range account.Permissions {
+		permissions = append(permissions, string(perm))
+	}
+
+	ttl := s.config.ServiceAccounts.TokenTTL
+	token, err := s.jwtManager.GenerateServiceToken(account.ClientID, permissions, []string{audience}, ttl)
+	if err != nil {
+		return nil, domain.ErrTokenGeneration
+	}
+
+	s.logServiceActivity(ctx, clientID, "success", audience)
+
+	return &domain.ServiceToken{
+		AccessToken: token,
+		ExpiresAt:   time.Now().Add(ttl),
+		Audience:    audience,
+		Permissions: account.Permissions,
+	}, nil
+}
+
+// logServiceActivity пишет в аудит выдачу токена сервису. Запись не привязана
+// к пользователю, исполнитель - сервисный аккаунт.
+func (s *UserService) logServiceActivity(ctx context.Context, clientID, result, audience string) {
+	activity := domain.NewUserActivity("", domain.ActivityTypeServiceToken, "", "", "")
+	activity.ActorID = clientID
+	activity.ActorKind = domain.PrincipalKindService
+	activity.AddDetail("result", result)
+	activity.AddDetail("audience", audience)
+	s.logActivity(ctx, activity)
+}
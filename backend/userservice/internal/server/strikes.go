@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"userservice/internal/domain"
+)
+
+// IssueWarning выдает пользователю страйк. Если число действующих страйков
+// достигло ступени эскалации, пользователь банится автоматически от имени system.
+func (s *UserService) IssueWarning(ctx context.Context, userID, reason string) (*domain.WarningResult, error) {
+	actor, err := s.requireUserPermission(ctx, userID, domain.PermissionUsersBan)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason == "" {
+		return nil, domain.NewRequiredFieldError("reason")
+	}
+
+	if actor.IsSelf(userID) {
+		return nil, domain.ErrSelfWarnNotAllowed
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !actor.Role.CanManage(user.Role) {
+		return nil, domain.NewPermissionDeniedError(domain.PermissionUsersBan, actor.Role)
+	}
+
+	strike := domain.NewStrike(userID, reason, actor.UserID, s.config.Moderation.StrikeTTL)
+	if err := s.auditRepo.AddStrike(ctx, strike); err != nil {
+		return nil, err
+	}
+
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeWarning, "", "", "")
+	activity.AddDetail("strike_id", strike.ID)
+	activity.AddDetail("reason", reason)
+	activity.AddDetail("issued_by", actor.UserID)
+	s.logActivity(ctx, activity)
+
+	result := &domain.WarningResult{Strike: strike, User: user}
+
+	strikes, err := s.auditRepo.ListActiveStrikes(ctx, userID, time.Now())
+	if err != nil {
+		fmt.Printf("Warning: failed to count strikes: %v\n", err)
+		user.Password = ""
+		return result, nil
+	}
+	result.ActiveStrikes = len(strikes)
+
+	// Уже забаненного пользователя не трогаем: бан не сокращается и не продлевается
+	if step := s.escalationLadder().StepFor(len(strikes)); step != nil && !user.IsBanned() {
+		var duration *time.Duration
+		if step.Duration > 0 {
+			duration = &step.Duration
+		}
+
+		banReason := fmt.Sprintf("%d active strikes, last: %s", len(strikes), reason)
+		banInfo := domain.NewBanInfo(banReason, systemActor, domain.BanCategorySystem, duration)
+		if err := s.applyBan(ctx, user, banInfo, duration); err != nil {
+			return nil, err
+		}
+		result.Banned = true
+	}
+
+	user.Password = ""
+	return result, nil
+}
+
+// ListStrikes возвращает действующие страйки пользователя
+func (s *UserService) ListStrikes(ctx context.Context, userID string) ([]*domain.Strike, error) {
+	if _, err := s.requireSelfOr(ctx, userID, domain.PermissionUsersBan); err != nil {
+		return nil, err
+	}
+
+	return s.auditRepo.ListActiveStrikes(ctx, userID, time.Now())
+}
+
+// escalationLadder собирает ступени эскалации из конфигурации.
+// Ступени без порога страйков пропускаются.
+func (s *UserService) escalationLadder() domain.EscalationLadder {
+	ladder := make(domain.EscalationLadder, 0, len(s.config.Moderation.Escalation))
+	for _, step := range s.config.Moderation.Escalation {
+		if step.Strikes <= 0 {
+			continue
+		}
+		ladder = append(ladder, domain.EscalationStep{Strikes: step.Strikes, Duration: step.Duration})
+	}
+	return ladder
+}
@@ -2,363 +2,1464 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
 	"errors"
 	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
 	"time"
 	"userservice/internal/config"
 	"userservice/internal/domain"
 	"userservice/pkg/jwt"
+	"userservice/pkg/secretbox"
+	"userservice/pkg/totp"
 
-	"golang.org/x/crypto/bcrypt"
+	"github.com/go-webauthn/webauthn/webauthn"
 )
 
 type UserService struct {
 	userRepo   domain.UserRepository
 	auditRepo  domain.AuditRepository
+	tokenRepo  domain.RefreshTokenRepository
+	revoked    domain.TokenRevocationStore
+	attempts   domain.LoginAttemptStore
+	resetRepo  domain.PasswordResetRepository
+	otpRepo    domain.PhoneOTPRepository
+	mfaRepo    domain.MFARepository
+	mailer     domain.Mailer
+	sms        domain.SMSSender
+	secrets    *secretbox.Box // Шифрует TOTP секреты
+	passwords  domain.PasswordHasher
 	jwtManager *jwt.JWTManager
-	config     *config.Config
+
+	passwordHistory domain.PasswordHistoryRepository
+	policy          *domain.PasswordPolicy
+	breached        domain.BreachedPasswordChecker // nil, если база утекших паролей не настроена
+
+	webauthnRepo domain.WebAuthnRepository
+	webAuthn     *webauthn.WebAuthn
+	orgRepo      domain.OrganizationRepository
+	apiKeyRepo   domain.APIKeyRepository
+	config       *config.Config
+
+	serviceAccounts domain.ServiceAccountRepository
+	appealRepo      domain.BanAppealRepository
+	reportRepo      domain.ReportRepository
+
+	denyListRepo      domain.DenyListRepository
+	disposableDomains domain.EmailDomainSet
 }
 
 // HealthCheck implements [domain.UserService].
-func (s *UserService) HealthCheck() (bool, error) {
+func (s *UserService) HealthCheck(ctx context.Context) (bool, error) {
 	panic("unimplemented")
 }
 
-func NewUserService(userRepo domain.UserRepository, auditRepo domain.AuditRepository, jwtManager *jwt.JWTManager, cfg *config.Config) *UserService {
+func NewUserService(userRepo domain.UserRepository, auditRepo domain.AuditRepository, tokenRepo domain.RefreshTokenRepository, revoked domain.TokenRevocationStore, attempts domain.LoginAttemptStore, resetRepo domain.PasswordResetRepository, otpRepo domain.PhoneOTPRepository, mfaRepo domain.MFARepository, mailer domain.Mailer, sms domain.SMSSender, secrets *secretbox.Box, passwords domain.PasswordHasher, passwordHistory domain.PasswordHistoryRepository, policy *domain.PasswordPolicy, breached domain.BreachedPasswordChecker, jwtManager *jwt.JWTManager, webauthnRepo domain.WebAuthnRepository, webAuthn *webauthn.WebAuthn, orgRepo domain.OrganizationRepository, apiKeyRepo domain.APIKeyRepository, serviceAccounts domain.ServiceAccountRepository, appealRepo domain.BanAppealRepository, reportRepo domain.ReportRepository, denyListRepo domain.DenyListRepository, disposableDomains domain.EmailDomainSet, cfg *config.Config) *UserService {
 	return &UserService{
 		userRepo:   userRepo,
 		auditRepo:  auditRepo,
+		tokenRepo:  tokenRepo,
+		revoked:    revoked,
+		attempts:   attempts,
+		resetRepo:  resetRepo,
+		otpRepo:    otpRepo,
+		mfaRepo:    mfaRepo,
+		mailer:     mailer,
+		sms:        sms,
+		secrets:    secrets,
+		passwords:  passwords,
 		jwtManager: jwtManager,
 		config:     cfg,
+
+		passwordHistory: passwordHistory,
+		policy:          policy,
+		breached:        breached,
+
+		webauthnRepo: webauthnRepo,
+		webAuthn:     webAuthn,
+		orgRepo:      orgRepo,
+		apiKeyRepo:   apiKeyRepo,
+
+		serviceAccounts: serviceAccounts,
+		appealRepo:      appealRepo,
+		reportRepo:      reportRepo,
+
+		denyListRepo:      denyListRepo,
+		disposableDomains: disposableDomains,
+	}
+}
+
+func (s *UserService) Register(ctx context.Context, user *domain.User, ip, deviceID string) (*domain.User, error) {
+	// Запрещенные адрес, домен или устройство не могут завести новый аккаунт
+	check := &domain.DenyCheck{IP: ip, EmailDomain: domain.EmailDomain(user.Email), DeviceID: deviceID}
+	if err := s.checkDenyLists(ctx, "register", user.Email, check); err != nil {
+		return nil, err
+	}
+	if err := s.checkDisposableEmail(ctx, user.Email, check); err != nil {
+		return nil, err
+	}
+
+	// Проверяем, существует ли пользователь
+	exists, err := s.userRepo.Exists(ctx, user.Email, user.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrUserAlreadyExists
+	}
+
+	if user.Phone != "" {
+		phone, err := domain.NormalizePhone(user.Phone, s.config.PhoneVerification.DefaultCountryCode)
+		if err != nil {
+			return nil, err
+		}
+		user.Phone = phone
+	}
+	user.EmailVerifiedAt = nil
+	user.PhoneVerifiedAt = nil
+
+	if err := s.validateNewPassword(ctx, user, user.Password); err != nil {
+		return nil, err
+	}
+
+	// Хешируем пароль. Переданная строка всегда считается паролем,
+	// даже если похожа на хеш.
+	hashedPassword, err := s.passwords.Hash(user.Password)
+	if err != nil {
+		return nil, err
+	}
+	user.Password = hashedPassword
+
+	// Устанавливаем значения по умолчанию
+	user.ID = domain.GenerateUUID()
+	user.Status = domain.UserStatusActive
+	if s.config.EmailVerification.Enabled {
+		// Пользователь станет активным после подтверждения email
+		user.Status = domain.UserStatusPending
+	}
+	user.Role = domain.UserRoleUser
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	// Создаем пользователя
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// Сохраняем метаданные в MongoDB
+	if len(user.Metadata) > 0 {
+		if err := s.auditRepo.SaveMetadata(ctx, user.ID, user.Metadata); err != nil {
+			// Логируем ошибку, но не прерываем регистрацию
+			fmt.Printf("Warning: failed to save metadata: %v\n", err)
+		}
+	}
+
+	if user.Status == domain.UserStatusPending {
+		if err := s.sendVerificationEmail(ctx, user); err != nil {
+			// Письмо можно запросить повторно через ResendVerification
+			fmt.Printf("Warning: failed to send verification email: %v\n", err)
+		}
+	}
+
+	// Логируем активность
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypeLogin, "", "", "")
+	s.logActivity(ctx, activity)
+
+	// Не возвращаем пароль
+	user.Password = ""
+	return user, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	// Участники одной организации видят профили друг друга
+	if _, err := s.requireSelfOr(ctx, id, domain.PermissionUsersRead); err != nil && !s.sharesTenant(ctx, id) {
+		return nil, err
+	}
+
+	return s.getUser(ctx, id)
+}
+
+// getUser возвращает пользователя с метаданными без проверки прав
+func (s *UserService) getUser(ctx context.Context, id string) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Проверяем, не забанен ли пользователь
+	if user.IsBanned() {
+		return nil, domain.ErrUserBanned
+	}
+
+	// Получаем метаданные из MongoDB
+	metadata, err := s.auditRepo.GetMetadata(ctx, id)
+	if err == nil && len(metadata) > 0 {
+		user.Metadata = metadata
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+func (s *UserService) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	// Получаем существующего пользователя
+	existingUser, err := s.userRepo.FindByID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	actor, err := s.requireManage(ctx, existingUser, domain.PermissionUsersWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	// Email и пароль от имени пользователя не меняются
+	if user.Password != "" || user.Email != existingUser.Email {
+		if err := denyImpersonation(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Смена роли: назначать можно только роли ниже своей, ADMIN - только SUPER_ADMIN
+	roleChanged := user.Role != "" && user.Role != domain.UserRoleUnspecified && user.Role != existingUser.Role
+	if roleChanged {
+		if !actor.Role.CanAssign(user.Role) || !actor.Role.CanManage(existingUser.Role) {
+			return nil, domain.NewPermissionDeniedError(domain.PermissionRolesAssign, actor.Role)
+		}
+		existingUser.Role = user.Role
+	}
+
+	// Обновляем только разрешенные поля
+	existingUser.Name = user.Name
+	emailChanged := user.Email != existingUser.Email
+	if emailChanged {
+		// Новый адрес требует повторного подтверждения
+		existingUser.Email = user.Email
+		existingUser.EmailVerifiedAt = nil
+		if existingUser.Status == domain.UserStatusActive {
+			existingUser.Status = domain.UserStatusPending
+		} else if existingUser.BanInfo != nil && existingUser.BanInfo.RestoredStatus() == domain.UserStatusActive {
+			// После снятия бана пользователь тоже должен подтвердить новый адрес
+			existingUser.BanInfo.PreviousStatus = domain.UserStatusPending
+		}
+	}
+	if user.Phone != "" {
+		phone, err := domain.NormalizePhone(user.Phone, s.config.PhoneVerification.DefaultCountryCode)
+		if err != nil {
+			return nil, err
+		}
+		user.Phone = phone
+	}
+	if user.Phone != existingUser.Phone {
+		// Новый номер требует повторного подтверждения
+		existingUser.PhoneVerifiedAt = nil
+	}
+	existingUser.Phone = user.Phone
+	existingUser.ServiceEmail = user.ServiceEmail
+	existingUser.UpdatedAt = time.Now()
+
+	// Если передан пароль, хешируем его
+	oldHash := ""
+	if user.Password != "" {
+		if err := s.validateNewPassword(ctx, existingUser, user.Password); err != nil {
+			return nil, err
+		}
+
+		hashedPassword, err := s.passwords.Hash(user.Password)
+		if err != nil {
+			return nil, err
+		}
+		oldHash = existingUser.Password
+		existingUser.Password = hashedPassword
+	}
+
+	if err := s.userRepo.Update(ctx, existingUser); err != nil {
+		return nil, err
+	}
+
+	if oldHash != "" {
+		s.rememberPassword(ctx, existingUser.ID, oldHash)
+	}
+
+	// Роль записана в токенах, поэтому выданные со старой ролью отзываем
+	if roleChanged {
+		if err := s.revokeAllSessions(ctx, existingUser.ID); err != nil {
+			fmt.Printf("Warning: failed to revoke sessions: %v\n", err)
+		}
+	}
+
+	if emailChanged && existingUser.Status == domain.UserStatusPending {
+		if err := s.sendVerificationEmail(ctx, existingUser); err != nil {
+			// Письмо можно запросить повторно через ResendVerification
+			fmt.Printf("Warning: failed to send verification email: %v\n", err)
+		}
+	}
+
+	// Обновляем метаданные в MongoDB
+	if len(user.Metadata) > 0 {
+		if err := s.auditRepo.UpdateMetadata(ctx, user.ID, user.Metadata); err != nil {
+			fmt.Printf("Warning: failed to update metadata: %v\n", err)
+		}
+	}
+
+	// Логируем активность
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypeProfileUpdate, "", "", "")
+	activity.AddDetail("fields_updated", "name, email, phone")
+	if emailChanged {
+		activity.AddDetail("email_verification", "reset")
+	}
+	if roleChanged {
+		activity.AddDetail("role", string(existingUser.Role))
+		activity.AddDetail("role_changed_by", actor.UserID)
+	}
+	s.logActivity(ctx, activity)
+
+	existingUser.Password = ""
+	return existingUser, nil
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, id string) error {
+	if err := denyImpersonation(ctx); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.requireManage(ctx, user, domain.PermissionUsersDelete); err != nil {
+		return err
+	}
+
+	// Мягкое удаление. Снятие бана не должно вернуть удаленному статус до бана.
+	user.Status = domain.UserStatusDeleted
+	if user.BanInfo != nil {
+		user.BanInfo.PreviousStatus = domain.UserStatusDeleted
+	}
+	user.UpdatedAt = time.Now()
+
+	return s.userRepo.Update(ctx, user)
+}
+
+func (s *UserService) ListUsers(ctx context.Context, filter *domain.UserFilter) ([]*domain.User, int64, error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// С токеном организации список всегда ограничен ее участниками
+	if p.TenantID != "" {
+		if _, _, err := s.requireOrgMember(ctx, p.TenantID); err != nil {
+			return nil, 0, err
+		}
+		filter.OrganizationID = p.TenantID
+	} else if !p.Can(domain.PermissionUsersRead) {
+		return nil, 0, domain.NewPermissionDeniedError(domain.PermissionUsersRead, p.Role)
+	}
+
+	users, total, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Убираем пароли
+	for _, user := range users {
+		user.Password = ""
+	}
+
+	return users, total, nil
+}
+
+func (s *UserService) Authenticate(ctx context.Context, email, password, ip, deviceID string) (*domain.User, *domain.TokenPair, error) {
+	// Заблокированный аккаунт не пускаем даже с верным паролем
+	if err := s.checkLockout(ctx, email, ip); err != nil {
+		return nil, nil, err
+	}
+
+	// Запрет проверяем до пароля: с запрещенного адреса нельзя и подбирать пароли
+	check := &domain.DenyCheck{IP: ip, EmailDomain: domain.EmailDomain(email), DeviceID: deviceID}
+	if err := s.checkDenyLists(ctx, "login", email, check); err != nil {
+		return nil, nil, err
+	}
+
+	// Находим пользователя
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		s.recordLoginFailure(ctx, nil, email, ip, "unknown_email")
+		return nil, nil, domain.ErrInvalidCredentials
+	}
+
+	// Проверяем пароль
+	ok, err := s.passwords.Verify(password, user.Password)
+	if err != nil {
+		fmt.Printf("Warning: failed to verify password: %v\n", err)
+	}
+	if !ok {
+		s.recordLoginFailure(ctx, user, email, ip, "invalid_password")
+		return nil, nil, domain.ErrInvalidCredentials
+	}
+
+	// Открытый пароль доступен только сейчас: обновляем устаревший хеш
+	s.rehashPassword(ctx, user, password)
+
+	// Пароль верный - счетчик аккаунта начинается заново.
+	// Счетчик IP не сбрасываем, иначе его обнулял бы собственный аккаунт атакующего.
+	if err := s.attempts.ResetFailures(ctx, domain.AccountLockoutKey(email)); err != nil {
+		fmt.Printf("Warning: failed to reset login failures: %v\n", err)
+	}
+
+	if err := s.checkLoginAllowed(user); err != nil {
+		// Забаненный пользователь с верным паролем может обжаловать бан
+		if err == domain.ErrUserBanned {
+			return nil, nil, s.bannedError(user)
+		}
+		return nil, nil, err
+	}
+
+	// При включенной 2FA токены выдаются только после VerifyMFA.
+	// Данные пользователя до второго фактора не возвращаем.
+	mfaEnabled, err := s.isMFAEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mfaEnabled {
+		mfaToken, err := s.jwtManager.GeneratePurposeToken(user.ID, user.Email, jwt.PurposeMFAChallenge, s.config.MFA.ChallengeTTL)
+		if err != nil {
+			return nil, nil, domain.ErrTokenGeneration
+		}
+		return nil, &domain.TokenPair{MFARequired: true, MFAToken: mfaToken}, nil
+	}
+
+	return s.completeLogin(ctx, user, "password")
+}
+
+func (s *UserService) VerifyMFA(ctx context.Context, mfaToken, code string) (*domain.User, *domain.TokenPair, error) {
+	claims, err := s.jwtManager.ValidatePurposeToken(mfaToken, jwt.PurposeMFAChallenge)
+	if err != nil {
+		return nil, nil, domain.ErrMFAChallengeInvalid
+	}
+
+	// Отзыв всех сессий аннулирует и незавершенные входы
+	if err := s.checkRevoked(ctx, claims); err != nil {
+		if err == domain.ErrTokenRevoked {
+			return nil, nil, domain.ErrMFAChallengeInvalid
+		}
+		return nil, nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, nil, domain.ErrMFAChallengeInvalid
+	}
+
+	if user.IsBanned() {
+		return nil, nil, s.bannedError(user)
+	}
+
+	// Подбор кода ограничен так же, как подбор пароля
+	if err := s.checkMFALockout(ctx, user.ID); err != nil {
+		return nil, nil, err
+	}
+
+	method, err := s.verifySecondFactor(ctx, user.ID, code)
+	if err != nil {
+		if err == domain.ErrMFACodeInvalid {
+			s.recordMFAFailure(ctx, user, claims)
+		}
+		return nil, nil, err
+	}
+
+	if err := s.attempts.ResetFailures(ctx, domain.MFALockoutKey(user.ID)); err != nil {
+		fmt.Printf("Warning: failed to reset login failures: %v\n", err)
+	}
+
+	// Токен входа одноразовый
+	if err := s.revoked.RevokeToken(ctx, claims.ID, time.Until(claims.ExpiresAt.Time)); err != nil {
+		fmt.Printf("Warning: failed to revoke mfa token: %v\n", err)
+	}
+
+	return s.completeLogin(ctx, user, method)
+}
+
+// checkLoginAllowed проверяет, может ли пользователь войти после проверки учетных данных
+func (s *UserService) checkLoginAllowed(user *domain.User) error {
+	// Проверяем, не забанен ли пользователь
+	if user.IsBanned() {
+		return domain.ErrUserBanned
+	}
+
+	if user.Status == domain.UserStatusPending && s.config.EmailVerification.Required {
+		return domain.ErrEmailNotVerified
 	}
+
+	return nil
+}
+
+// rehashPassword перехеширует пароль, если хеш сделан другим алгоритмом
+// или с устаревшими параметрами. Ошибка не мешает входу.
+func (s *UserService) rehashPassword(ctx context.Context, user *domain.User, password string) {
+	if !s.passwords.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashedPassword, err := s.passwords.Hash(password)
+	if err != nil {
+		fmt.Printf("Warning: failed to rehash password: %v\n", err)
+		return
+	}
+
+	user.Password = hashedPassword
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		fmt.Printf("Warning: failed to save rehashed password: %v\n", err)
+	}
+}
+
+// completeLogin выдает токены после успешной проверки всех факторов.
+// method - последний проверенный фактор: password, totp, recovery_code или webauthn.
+func (s *UserService) completeLogin(ctx context.Context, user *domain.User, method string) (*domain.User, *domain.TokenPair, error) {
+	// Выдаем access токен и refresh токен нового семейства
+	tokens, err := s.issueTokens(ctx, user, "", "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Обновляем время последнего входа
+	now := time.Now()
+	user.LastLoginAt = &now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		fmt.Printf("Warning: failed to update last login: %v\n", err)
+	}
+
+	// Логируем активность
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypeLogin, "", "", "")
+	activity.AddDetail("method", method)
+	s.logActivity(ctx, activity)
+
+	// Убираем пароль из ответа
+	user.Password = ""
+	return user, tokens, nil
+}
+
+// RefreshToken ротирует refresh токен. Как и вход, продление проверяется
+// по спискам запрета: иначе запрет не действовал бы на уже выданные токены.
+func (s *UserService) RefreshToken(ctx context.Context, refreshToken, ip, deviceID string) (*domain.User, *domain.TokenPair, error) {
+	stored, err := s.tokenRepo.FindByHash(ctx, jwt.HashToken(refreshToken))
+	if err != nil {
+		return nil, nil, domain.ErrRefreshTokenInvalid
+	}
+
+	if stored.IsRevoked() || stored.IsExpired() {
+		return nil, nil, domain.ErrRefreshTokenInvalid
+	}
+
+	// Повторное предъявление уже использованного токена означает,
+	// что он скомпрометирован: отзываем все семейство
+	if stored.IsRotated() {
+		s.revokeTokenFamily(ctx, stored)
+		return nil, nil, domain.ErrRefreshTokenInvalid
+	}
+
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, nil, domain.ErrRefreshTokenInvalid
+	}
+
+	if user.IsBanned() {
+		if err := s.tokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			fmt.Printf("Warning: failed to revoke token family: %v\n", err)
+		}
+		return nil, nil, domain.ErrUserBanned
+	}
+
+	check := &domain.DenyCheck{IP: ip, EmailDomain: domain.EmailDomain(user.Email), DeviceID: deviceID}
+	if err := s.checkDenyLists(ctx, "refresh", user.Email, check); err != nil {
+		return nil, nil, err
+	}
+
+	// Участника могли исключить из организации после выдачи токена
+	tenantID := stored.TenantID
+	if tenantID != "" {
+		if _, err := s.orgRepo.FindMember(ctx, tenantID, user.ID); err != nil {
+			tenantID = ""
+		}
+	}
+
+	tokens, next, err := s.newTokenPair(user, stored.FamilyID, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Конкурентный запрос успел использовать этот же токен раньше нас
+	rotated, err := s.tokenRepo.MarkRotated(ctx, stored.ID, next.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !rotated {
+		s.revokeTokenFamily(ctx, stored)
+		return nil, nil, domain.ErrRefreshTokenInvalid
+	}
+
+	if err := s.tokenRepo.Create(ctx, next); err != nil {
+		return nil, nil, err
+	}
+
+	user.Password = ""
+	return user, tokens, nil
+}
+
+// issueTokens выдает пару токенов и сохраняет refresh токен.
+// Пустой familyID начинает новое семейство refresh токенов,
+// tenantID - организация, в контексте которой выдаются токены.
+func (s *UserService) issueTokens(ctx context.Context, user *domain.User, familyID, tenantID string) (*domain.TokenPair, error) {
+	tokens, refresh, err := s.newTokenPair(user, familyID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenRepo.Create(ctx, refresh); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// newTokenPair генерирует пару токенов и запись refresh токена без сохранения
+func (s *UserService) newTokenPair(user *domain.User, familyID, tenantID string) (*domain.TokenPair, *domain.RefreshToken, error) {
+	accessToken, err := s.jwtManager.GenerateToken(user.ID, user.Email, string(user.Role), tenantID)
+	if err != nil {
+		return nil, nil, domain.ErrTokenGeneration
+	}
+
+	refreshToken, err := s.jwtManager.GenerateRefreshToken()
+	if err != nil {
+		return nil, nil, domain.ErrTokenGeneration
+	}
+
+	refresh := domain.NewRefreshToken(user.ID, familyID, tenantID, jwt.HashToken(refreshToken), s.jwtManager.RefreshExpiry())
+
+	return &domain.TokenPair{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		ExpiresAt:        time.Now().Add(s.jwtManager.Expiry()),
+		RefreshExpiresAt: refresh.ExpiresAt,
+	}, refresh, nil
+}
+
+// revokeTokenFamily отзывает семейство refresh токенов при обнаружении повторного использования
+func (s *UserService) revokeTokenFamily(ctx context.Context, token *domain.RefreshToken) {
+	if err := s.tokenRepo.RevokeFamily(ctx, token.FamilyID); err != nil {
+		fmt.Printf("Warning: failed to revoke token family: %v\n", err)
+	}
+
+	activity := domain.NewUserActivity(token.UserID, domain.ActivityTypeLogout, "", "", "")
+	activity.AddDetail("reason", "refresh_token_reuse")
+	activity.AddDetail("family_id", token.FamilyID)
+	s.logActivity(ctx, activity)
+}
+
+func (s *UserService) ValidateToken(ctx context.Context, token string) (*domain.User, error) {
+	if domain.IsAPIKey(token) {
+		p, err := s.AuthenticateAPIKey(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		return s.getUser(ctx, p.UserID)
+	}
+
+	claims, err := s.jwtManager.ValidateToken(token)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if err := s.checkRevoked(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *UserService) Logout(ctx context.Context, accessToken, refreshToken string) error {
+	claims, err := s.jwtManager.ValidateToken(accessToken)
+	if err != nil {
+		return domain.ErrInvalidToken
+	}
+
+	// Завершить можно только собственную сессию
+	if _, err := s.requireSelf(ctx, claims.UserID); err != nil {
+		return err
+	}
+
+	// Токен в списке отзыва нужен только до истечения его срока
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if err := s.revoked.RevokeToken(ctx, claims.ID, ttl); err != nil {
+		return err
+	}
+
+	if refreshToken != "" {
+		stored, err := s.tokenRepo.FindByHash(ctx, jwt.HashToken(refreshToken))
+		if err == nil && stored.UserID == claims.UserID {
+			if err := s.tokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Логируем активность
+	activity := domain.NewUserActivity(claims.UserID, domain.ActivityTypeLogout, "", "", "")
+	s.logActivity(ctx, activity)
+
+	return nil
+}
+
+func (s *UserService) RevokeAllSessions(ctx context.Context, userID string) error {
+	if err := denyImpersonation(ctx); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.requireManage(ctx, user, domain.PermissionSessionsRevoke); err != nil {
+		return err
+	}
+
+	if err := s.revokeAllSessions(ctx, userID); err != nil {
+		return err
+	}
+
+	// Логируем активность
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeLogout, "", "", "")
+	activity.AddDetail("all_sessions", true)
+	s.logActivity(ctx, activity)
+
+	return nil
+}
+
+// revokeAllSessions отзывает все выданные пользователю access и refresh токены
+func (s *UserService) revokeAllSessions(ctx context.Context, userID string) error {
+	// iat хранится с точностью до секунды, поэтому отсекаем по началу текущей
+	// секунды: токены, выданные сразу после отзыва, остаются действительными
+	now := time.Now().Truncate(time.Second)
+	if err := s.revoked.RevokeAllForUser(ctx, userID, now, s.jwtManager.Expiry()); err != nil {
+		return err
+	}
+
+	return s.tokenRepo.RevokeAllForUser(ctx, userID)
+}
+
+// checkRevoked проверяет, не отозван ли access токен
+func (s *UserService) checkRevoked(ctx context.Context, claims *jwt.Claims) error {
+	if claims.ID != "" {
+		revoked, err := s.revoked.IsTokenRevoked(ctx, claims.ID)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return domain.ErrTokenRevoked
+		}
+	}
+
+	revokedBefore, err := s.revoked.RevokedBefore(ctx, claims.UserID)
+	if err != nil {
+		return err
+	}
+	if !revokedBefore.IsZero() && (claims.IssuedAt == nil || claims.IssuedAt.Time.Before(revokedBefore)) {
+		return domain.ErrTokenRevoked
+	}
+
+	return nil
+}
+
+func (s *UserService) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error {
+	if err := denyImpersonation(ctx); err != nil {
+		return err
+	}
+
+	if _, err := s.requireSelf(ctx, userID); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Проверяем текущий пароль
+	if ok, _ := s.passwords.Verify(currentPassword, user.Password); !ok {
+		return domain.ErrInvalidCredentials
+	}
+
+	if err := s.validateNewPassword(ctx, user, newPassword); err != nil {
+		return err
+	}
+
+	// Хешируем новый пароль
+	hashedPassword, err := s.passwords.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	oldHash := user.Password
+	user.Password = hashedPassword
+	user.UpdatedAt = time.Now()
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	s.rememberPassword(ctx, user.ID, oldHash)
+
+	// Старые сессии не должны пережить смену пароля
+	if err := s.revokeAllSessions(ctx, userID); err != nil {
+		fmt.Printf("Warning: failed to revoke sessions: %v\n", err)
+	}
+
+	// Логируем активность
+	activity := domain.NewUserActivity(userID, domain.ActivityTypePasswordChange, "", "", "")
+	s.logActivity(ctx, activity)
+
+	return nil
+}
+
+func (s *UserService) VerifyEmail(ctx context.Context, token string) (*domain.User, error) {
+	claims, err := s.jwtManager.ValidatePurposeToken(token, jwt.PurposeEmailVerification)
+	if err != nil {
+		return nil, domain.ErrVerificationInvalid
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, domain.ErrVerificationInvalid
+	}
+
+	// Ссылка, отправленная на прежний адрес, не подтверждает новый
+	if user.Email != claims.Email {
+		return nil, domain.ErrVerificationInvalid
+	}
+
+	// Повторный переход по ссылке не считается ошибкой
+	if user.IsEmailVerified() {
+		user.Password = ""
+		return user, nil
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	if user.Status == domain.UserStatusPending {
+		user.Status = domain.UserStatusActive
+	} else if user.BanInfo != nil && user.BanInfo.PreviousStatus == domain.UserStatusPending {
+		user.BanInfo.PreviousStatus = domain.UserStatusActive
+	}
+	user.UpdatedAt = now
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// Логируем активность
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypeEmailVerification, "", "", "")
+	activity.AddDetail("email", user.Email)
+	s.logActivity(ctx, activity)
+
+	user.Password = ""
+	return user, nil
+}
+
+func (s *UserService) ResendVerification(ctx context.Context, email string) error {
+	// Не раскрываем, существует ли аккаунт и подтвержден ли он
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil || user.IsEmailVerified() {
+		return nil
+	}
+
+	return s.sendVerificationEmail(ctx, user)
+}
+
+// sendVerificationEmail отправляет пользователю подписанную ссылку подтверждения email
+func (s *UserService) sendVerificationEmail(ctx context.Context, user *domain.User) error {
+	ttl := s.config.EmailVerification.TokenTTL
+	token, err := s.jwtManager.GeneratePurposeToken(user.ID, user.Email, jwt.PurposeEmailVerification, ttl)
+	if err != nil {
+		return domain.ErrTokenGeneration
+	}
+
+	msg := &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Подтверждение email",
+		Body: fmt.Sprintf(
+			"Чтобы подтвердить адрес, перейдите по ссылке:\n%s?token=%s\n\nСсылка действует %s.\n",
+			s.config.EmailVerification.URL, url.QueryEscape(token), ttl,
+		),
+	}
+
+	return s.mailer.Send(ctx, msg)
+}
+
+func (s *UserService) SendPhoneOTP(ctx context.Context, userID string) (time.Time, error) {
+	if _, err := s.requireSelf(ctx, userID); err != nil {
+		return time.Time{}, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if user.Phone == "" {
+		return time.Time{}, domain.ErrInvalidPhone
+	}
+	if user.IsPhoneVerified() {
+		return time.Time{}, domain.ErrPhoneAlreadyVerified
+	}
+
+	// Ограничиваем частоту отправки SMS
+	last, err := s.otpRepo.FindLatest(ctx, user.ID)
+	if err == nil && time.Since(last.CreatedAt) < s.config.PhoneVerification.ResendInterval {
+		return time.Time{}, domain.ErrOTPTooFrequent
+	}
+
+	// Действует только последний отправленный код
+	if err := s.otpRepo.InvalidateForUser(ctx, user.ID); err != nil {
+		return time.Time{}, err
+	}
+
+	code, err := generateOTPCode(s.config.PhoneVerification.CodeLength)
+	if err != nil {
+		return time.Time{}, domain.ErrTokenGeneration
+	}
+
+	otp := domain.NewPhoneOTP(user.ID, user.Phone, hashPhoneOTP(user.ID, user.Phone, code), s.config.PhoneVerification.CodeTTL)
+	if err := s.otpRepo.Create(ctx, otp); err != nil {
+		return time.Time{}, err
+	}
+
+	msg := &domain.SMSMessage{
+		To:   user.Phone,
+		Body: fmt.Sprintf("Код подтверждения: %s. Никому его не сообщайте.", code),
+	}
+	if err := s.sms.Send(ctx, msg); err != nil {
+		return time.Time{}, err
+	}
+
+	// Логируем активность
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypePhoneVerification, "", "", "")
+	activity.AddDetail("action", "otp_sent")
+	s.logActivity(ctx, activity)
+
+	return otp.ExpiresAt, nil
+}
+
+func (s *UserService) VerifyPhoneOTP(ctx context.Context, userID, code string) (*domain.User, error) {
+	if _, err := s.requireSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	otp, err := s.otpRepo.FindLatest(ctx, user.ID)
+	if err != nil {
+		return nil, domain.ErrOTPInvalid
+	}
+
+	// Код, отправленный на прежний номер, не подтверждает новый
+	if !otp.IsUsable() || otp.Phone != user.Phone {
+		return nil, domain.ErrOTPInvalid
+	}
+
+	maxAttempts := s.config.PhoneVerification.MaxAttempts
+	if otp.Attempts >= maxAttempts {
+		return nil, domain.ErrOTPAttemptsExceeded
+	}
+
+	expected := hashPhoneOTP(user.ID, user.Phone, code)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(otp.CodeHash)) != 1 {
+		attempts, err := s.otpRepo.IncrementAttempts(ctx, otp.ID)
+		if err != nil {
+			return nil, err
+		}
+		if attempts >= maxAttempts {
+			return nil, domain.ErrOTPAttemptsExceeded
+		}
+		return nil, domain.ErrOTPInvalid
+	}
+
+	// Код одноразовый: второй конкурентный запрос получит false
+	consumed, err := s.otpRepo.MarkConsumed(ctx, otp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, domain.ErrOTPInvalid
+	}
+
+	now := time.Now()
+	user.PhoneVerifiedAt = &now
+	user.UpdatedAt = now
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// Логируем активность
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypePhoneVerification, "", "", "")
+	activity.AddDetail("action", "verified")
+	activity.AddDetail("phone", user.Phone)
+	s.logActivity(ctx, activity)
+
+	user.Password = ""
+	return user, nil
 }
 
-func (s *UserService) Register(user *domain.User) (*domain.User, error) {
-	ctx := context.Background()
+func (s *UserService) EnrollTOTP(ctx context.Context, userID string) (string, string, error) {
+	if err := denyImpersonation(ctx); err != nil {
+		return "", "", err
+	}
 
-	// Проверяем, существует ли пользователь
-	exists, err := s.userRepo.Exists(ctx, user.Email, user.Name)
-	if err != nil {
-		return nil, err
+	if _, err := s.requireSelf(ctx, userID); err != nil {
+		return "", "", err
 	}
-	if exists {
-		return nil, domain.ErrUserAlreadyExists
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return "", "", err
 	}
 
-	// Хешируем пароль (если он еще не хешированный)
-	// Проверяем, не хешированный ли уже пароль
-	if !isHashedPassword(user.Password) {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-		if err != nil {
-			return nil, err
-		}
-		user.Password = string(hashedPassword)
+	enabled, err := s.isMFAEnabled(ctx, user.ID)
+	if err != nil {
+		return "", "", err
+	}
+	if enabled {
+		return "", "", domain.ErrMFAAlreadyEnabled
 	}
 
-	// Устанавливаем значения по умолчанию
-	user.ID = domain.GenerateUUID()
-	user.Status = domain.UserStatusActive
-	user.Role = domain.UserRoleUser
-	user.CreatedAt = time.Now()
-	user.UpdatedAt = time.Now()
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", domain.ErrTokenGeneration
+	}
 
-	// Создаем пользователя
-	if err := s.userRepo.Create(ctx, user); err != nil {
-		return nil, err
+	sealed, err := s.secrets.Seal(secret)
+	if err != nil {
+		return "", "", err
 	}
 
-	// Сохраняем метаданные в MongoDB
-	if len(user.Metadata) > 0 {
-		if err := s.auditRepo.SaveMetadata(ctx, user.ID, user.Metadata); err != nil {
-			// Логируем ошибку, но не прерываем регистрацию
-			fmt.Printf("Warning: failed to save metadata: %v\n", err)
-		}
+	// Повторная настройка до подтверждения заменяет прежний секрет
+	now := time.Now()
+	cred := &domain.TOTPCredential{
+		UserID:          user.ID,
+		SecretEncrypted: sealed,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.mfaRepo.SaveTOTP(ctx, cred); err != nil {
+		return "", "", err
 	}
 
 	// Логируем активность
-	activity := domain.NewUserActivity(user.ID, domain.ActivityTypeLogin, "", "", "")
-	if err := s.auditRepo.LogActivity(ctx, activity); err != nil {
-		fmt.Printf("Warning: failed to log activity: %v\n", err)
-	}
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypeMFAChange, "", "", "")
+	activity.AddDetail("action", "totp_enrollment_started")
+	s.logActivity(ctx, activity)
 
-	// Не возвращаем пароль
-	user.Password = ""
-	return user, nil
+	return secret, totp.URI(s.config.MFA.Issuer, user.Email, secret), nil
 }
 
-func (s *UserService) GetUser(id string) (*domain.User, error) {
-	ctx := context.Background()
-
-	user, err := s.userRepo.FindByID(ctx, id)
-	if err != nil {
+func (s *UserService) ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	if err := denyImpersonation(ctx); err != nil {
 		return nil, err
 	}
 
-	// Проверяем, не забанен ли пользователь
-	if user.IsBanned() {
-		return nil, domain.ErrUserBanned
+	if _, err := s.requireSelf(ctx, userID); err != nil {
+		return nil, err
 	}
 
-	// Получаем метаданные из MongoDB
-	metadata, err := s.auditRepo.GetMetadata(ctx, id)
-	if err == nil && len(metadata) > 0 {
-		user.Metadata = metadata
+	cred, err := s.mfaRepo.FindTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	user.Password = ""
-	return user, nil
-}
-
-func (s *UserService) UpdateUser(user *domain.User) (*domain.User, error) {
-	ctx := context.Background()
+	if cred.IsConfirmed() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
 
-	// Получаем существующего пользователя
-	existingUser, err := s.userRepo.FindByID(ctx, user.ID)
+	secret, err := s.secrets.Open(cred.SecretEncrypted)
 	if err != nil {
 		return nil, err
 	}
 
-	// Обновляем только разрешенные поля
-	existingUser.Name = user.Name
-	existingUser.Email = user.Email
-	existingUser.Phone = user.Phone
-	existingUser.ServiceEmail = user.ServiceEmail
-	existingUser.UpdatedAt = time.Now()
-
-	// Если передан пароль, хешируем его
-	if user.Password != "" && !isHashedPassword(user.Password) {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-		if err != nil {
-			return nil, err
-		}
-		existingUser.Password = string(hashedPassword)
+	step, ok := totp.Validate(secret, strings.TrimSpace(code), time.Now(), 1)
+	if !ok {
+		return nil, domain.ErrMFACodeInvalid
 	}
 
-	if err := s.userRepo.Update(ctx, existingUser); err != nil {
+	if err := s.mfaRepo.ConfirmTOTP(ctx, userID, step); err != nil {
 		return nil, err
 	}
 
-	// Обновляем метаданные в MongoDB
-	if len(user.Metadata) > 0 {
-		if err := s.auditRepo.UpdateMetadata(ctx, user.ID, user.Metadata); err != nil {
-			fmt.Printf("Warning: failed to update metadata: %v\n", err)
-		}
+	codes, err := s.regenerateRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Логируем активность
-	activity := domain.NewUserActivity(user.ID, domain.ActivityTypeProfileUpdate, "", "", "")
-	activity.AddDetail("fields_updated", "name, email, phone")
-	if err := s.auditRepo.LogActivity(ctx, activity); err != nil {
-		fmt.Printf("Warning: failed to log activity: %v\n", err)
-	}
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeMFAChange, "", "", "")
+	activity.AddDetail("action", "totp_enabled")
+	s.logActivity(ctx, activity)
 
-	existingUser.Password = ""
-	return existingUser, nil
+	return codes, nil
 }
 
-func (s *UserService) DeleteUser(id string) error {
-	ctx := context.Background()
+func (s *UserService) DisableTOTP(ctx context.Context, userID, code string) error {
+	if err := denyImpersonation(ctx); err != nil {
+		return err
+	}
 
-	user, err := s.userRepo.FindByID(ctx, id)
+	if _, err := s.requireSelf(ctx, userID); err != nil {
+		return err
+	}
+
+	// Отключение требует действующего второго фактора
+	method, err := s.verifySecondFactor(ctx, userID, code)
 	if err != nil {
 		return err
 	}
 
-	// Мягкое удаление
-	user.Status = domain.UserStatusDeleted
-	user.UpdatedAt = time.Now()
+	if err := s.mfaRepo.DeleteTOTP(ctx, userID); err != nil {
+		return err
+	}
 
-	return s.userRepo.Update(ctx, user)
+	// Логируем активность
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeMFAChange, "", "", "")
+	activity.AddDetail("action", "totp_disabled")
+	activity.AddDetail("mfa_method", method)
+	s.logActivity(ctx, activity)
+
+	return nil
 }
 
-func (s *UserService) ListUsers(filter *domain.UserFilter) ([]*domain.User, int64, error) {
-	ctx := context.Background()
+// isMFAEnabled проверяет, подтверждена ли у пользователя 2FA
+func (s *UserService) isMFAEnabled(ctx context.Context, userID string) (bool, error) {
+	cred, err := s.mfaRepo.FindTOTP(ctx, userID)
+	if err != nil {
+		if err == domain.ErrMFANotEnrolled {
+			return false, nil
+		}
+		return false, err
+	}
 
-	users, total, err := s.userRepo.List(ctx, filter)
+	return cred.IsConfirmed(), nil
+}
+
+// verifySecondFactor принимает TOTP код или код восстановления
+// и возвращает, какой из них был использован
+func (s *UserService) verifySecondFactor(ctx context.Context, userID, code string) (string, error) {
+	cred, err := s.mfaRepo.FindTOTP(ctx, userID)
 	if err != nil {
-		return nil, 0, err
+		return "", err
+	}
+	if !cred.IsConfirmed() {
+		return "", domain.ErrMFANotEnrolled
 	}
 
-	// Убираем пароли
-	for _, user := range users {
-		user.Password = ""
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits && strings.Trim(code, "0123456789") == "" {
+		secret, err := s.secrets.Open(cred.SecretEncrypted)
+		if err != nil {
+			return "", err
+		}
+
+		step, ok := totp.Validate(secret, code, time.Now(), 1)
+		if !ok {
+			return "", domain.ErrMFACodeInvalid
+		}
+
+		// Один и тот же код нельзя использовать дважды
+		used, err := s.mfaRepo.UseStep(ctx, userID, step)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			return "", domain.ErrMFACodeInvalid
+		}
+
+		return "totp", nil
 	}
 
-	return users, total, nil
+	used, err := s.mfaRepo.UseRecoveryCode(ctx, userID, jwt.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return "", err
+	}
+	if !used {
+		return "", domain.ErrMFACodeInvalid
+	}
+
+	return "recovery_code", nil
 }
 
-func (s *UserService) Authenticate(email, password string) (*domain.User, string, error) {
-	ctx := context.Background()
+// regenerateRecoveryCodes выпускает новый набор кодов восстановления взамен прежнего
+func (s *UserService) regenerateRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	codes := make([]string, s.config.MFA.RecoveryCodes)
+	hashes := make([]string, len(codes))
 
-	// Находим пользователя
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, domain.ErrTokenGeneration
+		}
+		codes[i] = code
+		hashes[i] = jwt.HashToken(normalizeRecoveryCode(code))
+	}
+
+	if err := s.mfaRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *UserService) RequestPasswordReset(ctx context.Context, email string) error {
+	// Не раскрываем, существует ли аккаунт с таким email
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
-		return nil, "", domain.ErrInvalidCredentials
+		return nil
 	}
 
-	// Проверяем пароль
-	if !isHashedPassword(password) {
-		// Пароль не хешированный, сравниваем с хешем
-		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-			return nil, "", domain.ErrInvalidCredentials
-		}
-	} else {
-		// Пароль уже хешированный, просто сравниваем
-		if user.Password != password {
-			return nil, "", domain.ErrInvalidCredentials
-		}
+	if user.IsBanned() {
+		return nil
 	}
 
-	// Проверяем, не забанен ли пользователь
-	if user.IsBanned() {
-		return nil, "", domain.ErrUserBanned
+	// Действует только последняя отправленная ссылка
+	if err := s.resetRepo.InvalidateForUser(ctx, user.ID); err != nil {
+		return err
 	}
 
-	// Генерируем JWT токен
-	token, err := s.jwtManager.GenerateToken(user.ID, user.Email, string(user.Role))
+	token, err := jwt.GenerateOpaqueToken(32)
 	if err != nil {
-		return nil, "", err
+		return domain.ErrTokenGeneration
 	}
 
-	// Обновляем время последнего входа
-	now := time.Now()
-	user.LastLoginAt = &now
-	if err := s.userRepo.Update(ctx, user); err != nil {
-		fmt.Printf("Warning: failed to update last login: %v\n", err)
+	ttl := s.config.PasswordReset.TokenTTL
+	if err := s.resetRepo.Create(ctx, domain.NewPasswordResetToken(user.ID, jwt.HashToken(token), ttl)); err != nil {
+		return err
 	}
 
-	// Логируем активность
-	activity := domain.NewUserActivity(user.ID, domain.ActivityTypeLogin, "", "", "")
-	if err := s.auditRepo.LogActivity(ctx, activity); err != nil {
-		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	msg := &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Сброс пароля",
+		Body: fmt.Sprintf(
+			"Чтобы задать новый пароль, перейдите по ссылке:\n%s?token=%s\n\nСсылка действует %s. Если вы не запрашивали сброс, просто проигнорируйте это письмо.\n",
+			s.config.PasswordReset.URL, url.QueryEscape(token), ttl,
+		),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return err
 	}
 
-	// Убираем пароль из ответа
-	user.Password = ""
-	return user, token, nil
+	// Логируем активность
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypePasswordChange, "", "", "")
+	activity.AddDetail("action", "reset_requested")
+	s.logActivity(ctx, activity)
+
+	return nil
 }
 
-func (s *UserService) ValidateToken(token string) (*domain.User, error) {
-	claims, err := s.jwtManager.ValidateToken(token)
+func (s *UserService) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	stored, err := s.resetRepo.FindByHash(ctx, jwt.HashToken(token))
 	if err != nil {
-		return nil, domain.ErrInvalidToken
+		return domain.ErrResetTokenInvalid
 	}
 
-	user, err := s.GetUser(claims.UserID)
-	if err != nil {
-		return nil, err
+	if !stored.IsUsable() {
+		return domain.ErrResetTokenInvalid
 	}
 
-	return user, nil
-}
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return domain.ErrResetTokenInvalid
+	}
 
-func (s *UserService) ChangePassword(userID, currentPassword, newPassword string) error {
-	ctx := context.Background()
+	// Токен не расходуется, пока пароль не прошел проверку
+	if err := s.validateNewPassword(ctx, user, newPassword); err != nil {
+		return err
+	}
 
-	user, err := s.userRepo.FindByID(ctx, userID)
+	// Токен одноразовый: второй конкурентный запрос получит false
+	used, err := s.resetRepo.MarkUsed(ctx, stored.ID)
 	if err != nil {
 		return err
 	}
-
-	// Проверяем текущий пароль
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
-		return domain.ErrInvalidCredentials
+	if !used {
+		return domain.ErrResetTokenInvalid
 	}
 
-	// Хешируем новый пароль
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	hashedPassword, err := s.passwords.Hash(newPassword)
 	if err != nil {
 		return err
 	}
 
-	user.Password = string(hashedPassword)
+	oldHash := user.Password
+	user.Password = hashedPassword
 	user.UpdatedAt = time.Now()
 
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return err
 	}
+	s.rememberPassword(ctx, user.ID, oldHash)
 
-	// Логируем активность
-	activity := domain.NewUserActivity(userID, domain.ActivityTypePasswordChange, "", "", "")
-	if err := s.auditRepo.LogActivity(ctx, activity); err != nil {
-		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	if err := s.resetRepo.InvalidateForUser(ctx, user.ID); err != nil {
+		fmt.Printf("Warning: failed to invalidate reset tokens: %v\n", err)
+	}
+
+	// Сброс пароля завершает все существующие сессии
+	if err := s.revokeAllSessions(ctx, user.ID); err != nil {
+		fmt.Printf("Warning: failed to revoke sessions: %v\n", err)
 	}
 
+	// Логируем активность
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypePasswordChange, "", "", "")
+	activity.AddDetail("action", "reset_confirmed")
+	s.logActivity(ctx, activity)
+
 	return nil
 }
 
-func (s *UserService) ResetPassword(email string) error {
-	// В реальном проекте здесь была бы логика отправки email с ссылкой для сброса пароля
-	// Для примера просто возвращаем nil
-	return nil
+// BanUser банит пользователя от имени вызывающего.
+// Модератор не может забанить модератора или администратора, а себя - никто.
+// Категория SYSTEM зарезервирована за автоматическими банами сервиса.
+func (s *UserService) BanUser(ctx context.Context, userID, reason string, category domain.BanCategory, duration *time.Duration) (*domain.User, error) {
+	return s.banUser(ctx, userID, reason, category, duration, "")
 }
 
-func (s *UserService) BanUser(userID, reason, bannedBy string, duration *time.Duration) (*domain.User, error) {
-	ctx := context.Background()
+// banUser банит пользователя как BanUser. reportID - жалоба, по которой выдан бан.
+func (s *UserService) banUser(ctx context.Context, userID, reason string, category domain.BanCategory, duration *time.Duration, reportID string) (*domain.User, error) {
+	actor, err := s.requireUserPermission(ctx, userID, domain.PermissionUsersBan)
+	if err != nil {
+		return nil, err
+	}
+
+	if category != domain.BanCategoryUnspecified && (!category.IsValid() || category == domain.BanCategorySystem) {
+		return nil, domain.ErrInvalidBanCategory
+	}
+
+	if actor.IsSelf(userID) {
+		return nil, domain.ErrSelfBanNotAllowed
+	}
 
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Проверяем, что пользователь не является администратором
-	if user.IsAdmin() {
-		return nil, errors.New("cannot ban admin user")
+	// Банить можно только пользователей с ролью ниже своей
+	if !actor.Role.CanManage(user.Role) {
+		return nil, domain.NewAdminBanNotAllowedError(user.Role)
+	}
+
+	if user.IsBanned() {
+		return nil, domain.NewBanAlreadyActiveError(userID)
 	}
 
 	// Создаем информацию о бане
-	banInfo := domain.NewBanInfo(reason, bannedBy, duration)
+	banInfo := domain.NewBanInfo(reason, actor.UserID, category, duration)
+	banInfo.ReportID = reportID
+	if err := s.applyBan(ctx, user, banInfo, duration); err != nil {
+		return nil, err
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+// applyBan сохраняет бан, завершает сессии пользователя и пишет аудит.
+// Исполнителем считается banInfo.BannedBy.
+func (s *UserService) applyBan(ctx context.Context, user *domain.User, banInfo *domain.BanInfo, duration *time.Duration) error {
+	// Статус до бана вернется при его снятии. Если прошлый бан истек,
+	// но еще не снят фоновой задачей, берем статус до прошлого бана.
+	banInfo.PreviousStatus = user.Status
+	if user.BanInfo != nil {
+		banInfo.PreviousStatus = user.BanInfo.RestoredStatus()
+	}
+
 	user.BanInfo = banInfo
+	user.Status = banInfo.Status()
 
-	// Устанавливаем соответствующий статус
-	if duration != nil {
-		user.Status = domain.UserStatusBannedTemporarily
-	} else {
-		user.Status = domain.UserStatusBannedPermanently
+	if err := s.userRepo.Ban(ctx, user.ID, banInfo); err != nil {
+		return err
 	}
 
-	if err := s.userRepo.Ban(ctx, userID, banInfo); err != nil {
-		return nil, err
+	// Забаненный пользователь теряет все активные сессии
+	if err := s.revokeAllSessions(ctx, user.ID); err != nil {
+		fmt.Printf("Warning: failed to revoke sessions: %v\n", err)
 	}
 
 	// Логируем в MongoDB
 	details := map[string]interface{}{
-		"reason":    reason,
-		"banned_by": bannedBy,
+		"reason":    banInfo.Reason,
+		"banned_by": banInfo.BannedBy,
+		"actor_id":  banInfo.BannedBy,
 		"duration":  duration,
-		"user_id":   userID,
+		"category":  banInfo.Category,
+		"user_id":   user.ID,
+	}
+	if banInfo.ReportID != "" {
+		details["report_id"] = banInfo.ReportID
 	}
-	if err := s.auditRepo.LogBanChange(ctx, userID, "ban", details); err != nil {
+	if err := s.auditRepo.LogBanChange(ctx, user.ID, "ban", details); err != nil {
 		fmt.Printf("Warning: failed to log ban change: %v\n", err)
 	}
 
 	// Логируем активность
-	activity := domain.NewUserActivity(userID, domain.ActivityTypeBan, "", "", "")
-	activity.AddDetail("reason", reason)
-	activity.AddDetail("banned_by", bannedBy)
-	if err := s.auditRepo.LogActivity(ctx, activity); err != nil {
-		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	activity := domain.NewUserActivity(user.ID, domain.ActivityTypeBan, "", "", "")
+	activity.AddDetail("reason", banInfo.Reason)
+	activity.AddDetail("banned_by", banInfo.BannedBy)
+	if banInfo.Category != domain.BanCategoryUnspecified {
+		activity.AddDetail("category", banInfo.Category)
+	}
+	if banInfo.BannedBy == systemActor {
+		activity.ActorID = systemActor
+		activity.ActorKind = domain.PrincipalKindSystem
 	}
+	s.logActivity(ctx, activity)
 
-	user.Password = ""
-	return user, nil
+	return nil
 }
 
-func (s *UserService) UnbanUser(userID, unbannedBy string) (*domain.User, error) {
-	ctx := context.Background()
+// UnbanUser снимает бан от имени вызывающего с учетом иерархии ролей
+func (s *UserService) UnbanUser(ctx context.Context, userID string) (*domain.User, error) {
+	actor, err := s.requireUserPermission(ctx, userID, domain.PermissionUsersBan)
+	if err != nil {
+		return nil, err
+	}
 
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	if !actor.Role.CanManage(user.Role) {
+		return nil, domain.NewPermissionDeniedError(domain.PermissionUsersBan, actor.Role)
+	}
+
 	if user.BanInfo == nil || !user.BanInfo.IsBanned {
-		return nil, errors.New("user is not banned")
+		return nil, domain.ErrBanNotFound
 	}
 
-	// Разбаниваем пользователя
+	// Разбаниваем пользователя, возвращая статус до бана
 	user.BanInfo.Unban()
-	user.Status = domain.UserStatusActive
+	user.Status = user.BanInfo.RestoredStatus()
 
 	if err := s.userRepo.Unban(ctx, userID); err != nil {
 		return nil, err
@@ -366,8 +1467,10 @@ func (s *UserService) UnbanUser(userID, unbannedBy string) (*domain.User, error)
 
 	// Логируем в MongoDB
 	details := map[string]interface{}{
-		"unbanned_by": unbannedBy,
-		"user_id":     userID,
+		"unbanned_by":     actor.UserID,
+		"actor_id":        actor.UserID,
+		"user_id":         userID,
+		"restored_status": user.Status,
 	}
 	if err := s.auditRepo.LogBanChange(ctx, userID, "unban", details); err != nil {
 		fmt.Printf("Warning: failed to log ban change: %v\n", err)
@@ -375,17 +1478,50 @@ func (s *UserService) UnbanUser(userID, unbannedBy string) (*domain.User, error)
 
 	// Логируем активность
 	activity := domain.NewUserActivity(userID, domain.ActivityTypeUnban, "", "", "")
-	activity.AddDetail("unbanned_by", unbannedBy)
-	if err := s.auditRepo.LogActivity(ctx, activity); err != nil {
-		fmt.Printf("Warning: failed to log activity: %v\n", err)
-	}
+	activity.AddDetail("unbanned_by", actor.UserID)
+	s.logActivity(ctx, activity)
 
 	user.Password = ""
 	return user, nil
 }
 
-func (s *UserService) UpdateSubscription(userID string, subscription *domain.SubscriptionInfo) (*domain.User, error) {
-	ctx := context.Background()
+// GetBanStatistics возвращает число действующих банов по всем категориям,
+// включая баны без категории
+func (s *UserService) GetBanStatistics(ctx context.Context) ([]*domain.BanCategoryStats, error) {
+	if _, err := s.requirePermission(ctx, domain.PermissionUsersBan); err != nil {
+		return nil, err
+	}
+
+	counted, err := s.userRepo.BanStatistics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byCategory := make(map[domain.BanCategory]*domain.BanCategoryStats, len(counted))
+	for _, st := range counted {
+		byCategory[st.Category] = st
+	}
+
+	// Категории без банов тоже попадают в отчет, с нулями
+	stats := make([]*domain.BanCategoryStats, 0, len(domain.BanCategories)+1)
+	for _, category := range append([]domain.BanCategory{domain.BanCategoryUnspecified}, domain.BanCategories...) {
+		st, ok := byCategory[category]
+		if !ok {
+			st = &domain.BanCategoryStats{Category: category}
+		}
+		stats = append(stats, st)
+	}
+
+	return stats, nil
+}
+
+// UpdateSubscription применяет к подписке пользователя переданные изменения.
+// Смена статуса проверяется по допустимым переходам, смена уровня действующей
+// подписки - только через UPGRADING или DOWNGRADING (см. SubscriptionInfo.Apply).
+func (s *UserService) UpdateSubscription(ctx context.Context, userID string, update *domain.SubscriptionUpdate) (*domain.User, error) {
+	if _, err := s.requireUserPermission(ctx, userID, domain.PermissionSubscriptionsWrite); err != nil {
+		return nil, err
+	}
 
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
@@ -394,10 +1530,22 @@ func (s *UserService) UpdateSubscription(userID string, subscription *domain.Sub
 
 	// Сохраняем старые значения для истории
 	var oldLevel domain.SubscriptionLevel
-	var oldStatus domain.SubscriptionStatus
+	oldStatus := domain.SubscriptionStatusUnspecified
+	subscription := &domain.SubscriptionInfo{
+		Status:            domain.SubscriptionStatusUnspecified,
+		SubscriptionStart: time.Now(),
+	}
 	if user.Subscription != nil {
 		oldLevel = user.Subscription.Level
 		oldStatus = user.Subscription.Status
+		current := *user.Subscription
+		subscription = &current
+	} else if update.Status == nil {
+		return nil, domain.NewRequiredFieldError("status")
+	}
+
+	if err := subscription.Apply(update); err != nil {
+		return nil, err
 	}
 
 	user.Subscription = subscription
@@ -414,7 +1562,7 @@ func (s *UserService) UpdateSubscription(userID string, subscription *domain.Sub
 		oldStatus,
 		subscription.Status,
 		"Subscription updated",
-		"system",
+		actorID(ctx),
 	)
 	if err := s.auditRepo.LogSubscriptionChange(ctx, entry); err != nil {
 		fmt.Printf("Warning: failed to log subscription change: %v\n", err)
@@ -424,16 +1572,25 @@ func (s *UserService) UpdateSubscription(userID string, subscription *domain.Sub
 	activity := domain.NewUserActivity(userID, domain.ActivityTypeSubscriptionStart, "", "", "")
 	activity.AddDetail("level", string(subscription.Level))
 	activity.AddDetail("status", string(subscription.Status))
-	if err := s.auditRepo.LogActivity(ctx, activity); err != nil {
-		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	if subscription.PendingLevel != "" {
+		activity.AddDetail("pending_level", string(subscription.PendingLevel))
 	}
+	s.logActivity(ctx, activity)
 
 	user.Password = ""
 	return user, nil
 }
 
-func (s *UserService) CancelSubscription(userID, reason string, immediate bool) (*domain.User, error) {
-	ctx := context.Background()
+func (s *UserService) CancelSubscription(ctx context.Context, userID, reason string, immediate bool) (*domain.User, error) {
+	p, err := s.requireSelfOr(ctx, userID, domain.PermissionSubscriptionsWrite)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsSelf(userID) {
+		if err := requireSelfWrite(p); err != nil {
+			return nil, err
+		}
+	}
 
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
@@ -449,7 +1606,9 @@ func (s *UserService) CancelSubscription(userID, reason string, immediate bool)
 	oldStatus := user.Subscription.Status
 
 	// Отменяем подписку
-	user.Subscription.Cancel(reason, immediate)
+	if err := user.Subscription.Cancel(reason, immediate); err != nil {
+		return nil, err
+	}
 
 	if err := s.userRepo.UpdateSubscription(ctx, userID, user.Subscription); err != nil {
 		return nil, err
@@ -463,7 +1622,7 @@ func (s *UserService) CancelSubscription(userID, reason string, immediate bool)
 		oldStatus,
 		user.Subscription.Status,
 		reason,
-		"system",
+		actorID(ctx),
 	)
 	if err := s.auditRepo.LogSubscriptionChange(ctx, entry); err != nil {
 		fmt.Printf("Warning: failed to log subscription change: %v\n", err)
@@ -473,16 +1632,18 @@ func (s *UserService) CancelSubscription(userID, reason string, immediate bool)
 	activity := domain.NewUserActivity(userID, domain.ActivityTypeSubscriptionEnd, "", "", "")
 	activity.AddDetail("reason", reason)
 	activity.AddDetail("immediate", immediate)
-	if err := s.auditRepo.LogActivity(ctx, activity); err != nil {
-		fmt.Printf("Warning: failed to log activity: %v\n", err)
-	}
+	s.logActivity(ctx, activity)
 
 	user.Password = ""
 	return user, nil
 }
 
-func (s *UserService) CheckSubscriptionAccess(userID string, requiredLevel domain.SubscriptionLevel, feature string) (bool, error) {
-	user, err := s.GetUser(userID)
+func (s *UserService) CheckSubscriptionAccess(ctx context.Context, userID string, requiredLevel domain.SubscriptionLevel, feature string) (bool, error) {
+	if _, err := s.requireSelfOr(ctx, userID, domain.PermissionSubscriptionsRead); err != nil {
+		return false, err
+	}
+
+	user, err := s.getUser(ctx, userID)
 	if err != nil {
 		return false, err
 	}
@@ -492,7 +1653,7 @@ func (s *UserService) CheckSubscriptionAccess(userID string, requiredLevel domai
 	}
 
 	// Проверяем уровень подписки
-	if user.Subscription.Level < requiredLevel {
+	if user.Subscription.Level.Rank() < requiredLevel.Rank() {
 		return false, domain.ErrSubscriptionRequired
 	}
 
@@ -504,7 +1665,7 @@ func (s *UserService) CheckSubscriptionAccess(userID string, requiredLevel domai
 	return true, nil
 }
 
-func (s *UserService) ValidateEmail(email string) error {
+func (s *UserService) ValidateEmail(ctx context.Context, email string) error {
 	// Простая проверка email
 	if len(email) < 3 || len(email) > 255 {
 		return errors.New("email must be between 3 and 255 characters")
@@ -513,11 +1674,17 @@ func (s *UserService) ValidateEmail(email string) error {
 	return nil
 }
 
-func (s *UserService) ValidatePassword(password string) error {
-	if len(password) < 8 {
-		return errors.New("password must be at least 8 characters")
+// ValidatePassword проверяет пароль по политике и базе утекших паролей.
+// Сходство с данными пользователя и история проверяются при смене пароля.
+func (s *UserService) ValidatePassword(ctx context.Context, password string) error {
+	violations := s.policy.Check(password)
+	if v := s.checkBreached(ctx, password); v != nil {
+		violations = append(violations, *v)
+	}
+
+	if len(violations) > 0 {
+		return domain.NewPasswordPolicyError(violations)
 	}
-	// В реальном проекте добавьте более сложные правила
 	return nil
 }
 
@@ -537,11 +1704,38 @@ func (s *UserService) ValidatePassword(password string) error {
 // 	return true, nil
 // }
 
-// isHashedPassword проверяет, является ли пароль уже хешированным
-func isHashedPassword(password string) bool {
-	// BCrypt хеши начинаются с $2a$, $2b$, $2x$ или $2y$
-	if len(password) == 60 && (password[:4] == "$2a$" || password[:4] == "$2b$" || password[:4] == "$2x$" || password[:4] == "$2y$") {
-		return true
+// generateOTPCode генерирует цифровой код заданной длины
+func generateOTPCode(length int) (string, error) {
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+	return string(code), nil
+}
+
+// hashPhoneOTP хеширует код вместе с пользователем и номером,
+// чтобы код был действителен только для номера, на который отправлен
+func hashPhoneOTP(userID, phone, code string) string {
+	return jwt.HashToken(userID + ":" + phone + ":" + code)
+}
+
+// generateRecoveryCode генерирует код восстановления вида abcde-fghij
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
 	}
-	return false
+
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeRecoveryCode убирает разделители и регистр, чтобы код можно было вводить как удобно
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
 }
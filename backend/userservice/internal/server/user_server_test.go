@@ -0,0 +1,396 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"userservice/internal/domain"
+	"userservice/pkg/jwt"
+)
+
+// fakeTokenRepo хранит refresh токены в памяти с теми же условиями,
+// что и PostgreSQL репозиторий
+type fakeTokenRepo struct {
+	domain.RefreshTokenRepository
+
+	mu     sync.Mutex
+	tokens map[string]*domain.RefreshToken // По хешу токена
+}
+
+func (r *fakeTokenRepo) Create(ctx context.Context, token *domain.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	saved := *token
+	r.tokens[token.TokenHash] = &saved
+	return nil
+}
+
+func (r *fakeTokenRepo) FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token, ok := r.tokens[tokenHash]
+	if !ok {
+		return nil, errors.New("refresh token not found")
+	}
+	found := *token
+	return &found, nil
+}
+
+func (r *fakeTokenRepo) MarkRotated(ctx context.Context, id, replacedBy string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.tokens {
+		if token.ID == id && token.RotatedAt == nil && token.RevokedAt == nil {
+			now := time.Now()
+			token.RotatedAt = &now
+			token.ReplacedBy = replacedBy
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeTokenRepo) RevokeFamily(ctx context.Context, familyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *fakeTokenRepo) RevokeAllForUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+// byID возвращает сохраненный токен по ID
+func (r *fakeTokenRepo) byID(id string) *domain.RefreshToken {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.tokens {
+		if token.ID == id {
+			return token
+		}
+	}
+	return nil
+}
+
+type fakeUserRepo struct {
+	domain.UserRepository
+	users map[string]*domain.User
+}
+
+func (r *fakeUserRepo) FindByID(ctx context.Context, id string) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, domain.NewUserNotFoundError(id)
+	}
+	found := *user
+	return &found, nil
+}
+
+func (r *fakeUserRepo) Update(ctx context.Context, user *domain.User) error {
+	saved := *user
+	r.users[user.ID] = &saved
+	return nil
+}
+
+type fakeAuditRepo struct {
+	domain.AuditRepository
+	activities []*domain.UserActivity
+}
+
+func (r *fakeAuditRepo) LogActivity(ctx context.Context, activity *domain.UserActivity) error {
+	r.activities = append(r.activities, activity)
+	return nil
+}
+
+func (r *fakeAuditRepo) LogBanChange(ctx context.Context, userID, action string, details map[string]interface{}) error {
+	return nil
+}
+
+type fakeDenyListRepo struct {
+	domain.DenyListRepository
+	deniedIP string
+}
+
+func (r *fakeDenyListRepo) Match(ctx context.Context, check *domain.DenyCheck, now time.Time) (*domain.DenyListEntry, error) {
+	if r.deniedIP != "" && check.IP == r.deniedIP {
+		return &domain.DenyListEntry{ID: "entry-1", Kind: domain.DenyListKindIP, Value: r.deniedIP}, nil
+	}
+	return nil, nil
+}
+
+type fakeRevocationStore struct {
+	domain.TokenRevocationStore
+	revokedBefore map[string]time.Time
+}
+
+func (s *fakeRevocationStore) RevokeAllForUser(ctx context.Context, userID string, at time.Time, ttl time.Duration) error {
+	s.revokedBefore[userID] = at
+	return nil
+}
+
+func (s *fakeRevocationStore) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	return false, nil
+}
+
+func (s *fakeRevocationStore) RevokedBefore(ctx context.Context, userID string) (time.Time, error) {
+	return s.revokedBefore[userID], nil
+}
+
+// refreshEnv - сервис с хранилищами в памяти и выданной первой парой токенов
+type refreshEnv struct {
+	service  *UserService
+	tokens   *fakeTokenRepo
+	users    *fakeUserRepo
+	audit    *fakeAuditRepo
+	denyList *fakeDenyListRepo
+	user     *domain.User
+	issued   *domain.TokenPair
+}
+
+func newRefreshEnv(t *testing.T) *refreshEnv {
+	t.Helper()
+
+	user := &domain.User{ID: "user-1", Email: "user@example.com", Role: domain.UserRoleUser, Status: domain.UserStatusActive}
+	env := &refreshEnv{
+		tokens:   &fakeTokenRepo{tokens: make(map[string]*domain.RefreshToken)},
+		users:    &fakeUserRepo{users: map[string]*domain.User{user.ID: user}},
+		audit:    &fakeAuditRepo{},
+		denyList: &fakeDenyListRepo{},
+		user:     user,
+	}
+	env.service = &UserService{
+		userRepo:     env.users,
+		auditRepo:    env.audit,
+		tokenRepo:    env.tokens,
+		denyListRepo: env.denyList,
+		jwtManager:   jwt.NewJWTManager("test-secret", "user-service", "api", time.Hour, 24*time.Hour),
+	}
+
+	issued, err := env.service.issueTokens(context.Background(), user, "", "")
+	if err != nil {
+		t.Fatalf("issueTokens: %v", err)
+	}
+	env.issued = issued
+	return env
+}
+
+// stored возвращает сохраненную запись выданного refresh токена
+func (e *refreshEnv) stored(t *testing.T, refreshToken string) *domain.RefreshToken {
+	t.Helper()
+
+	token, err := e.tokens.FindByHash(context.Background(), jwt.HashToken(refreshToken))
+	if err != nil {
+		t.Fatalf("FindByHash: %v", err)
+	}
+	return token
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	ctx := context.Background()
+
+	first := newRefreshEnv(t)
+	_, rotated, err := first.service.RefreshToken(ctx, first.issued.RefreshToken, "203.0.113.1", "")
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+
+	if rotated.RefreshToken == first.issued.RefreshToken {
+		t.Fatal("refresh token was not rotated")
+	}
+
+	old := first.stored(t, first.issued.RefreshToken)
+	next := first.stored(t, rotated.RefreshToken)
+	if !old.IsRotated() || old.ReplacedBy != next.ID {
+		t.Errorf("old token: rotated = %v, replaced by %q, want %q", old.IsRotated(), old.ReplacedBy, next.ID)
+	}
+	if next.FamilyID != old.FamilyID {
+		t.Errorf("new token family = %s, want %s", next.FamilyID, old.FamilyID)
+	}
+	if next.IsRotated() || next.IsRevoked() {
+		t.Error("new token is not usable")
+	}
+}
+
+func TestRefreshTokenRejected(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// prepare возвращает refresh токен, который предъявит клиент
+		prepare       func(t *testing.T, env *refreshEnv) string
+		ip            string
+		wantErr       error
+		familyRevoked bool
+	}{
+		{
+			name: "unknown token",
+			prepare: func(t *testing.T, env *refreshEnv) string {
+				return "unknown"
+			},
+			wantErr: domain.ErrRefreshTokenInvalid,
+		},
+		{
+			name: "reused token revokes family",
+			prepare: func(t *testing.T, env *refreshEnv) string {
+				if _, _, err := env.service.RefreshToken(ctx, env.issued.RefreshToken, "", ""); err != nil {
+					t.Fatalf("RefreshToken: %v", err)
+				}
+				return env.issued.RefreshToken
+			},
+			wantErr:       domain.ErrRefreshTokenInvalid,
+			familyRevoked: true,
+		},
+		{
+			name: "revoked token",
+			prepare: func(t *testing.T, env *refreshEnv) string {
+				stored := env.stored(t, env.issued.RefreshToken)
+				if err := env.tokens.RevokeFamily(ctx, stored.FamilyID); err != nil {
+					t.Fatalf("RevokeFamily: %v", err)
+				}
+				return env.issued.RefreshToken
+			},
+			wantErr:       domain.ErrRefreshTokenInvalid,
+			familyRevoked: true,
+		},
+		{
+			name: "expired token",
+			prepare: func(t *testing.T, env *refreshEnv) string {
+				stored := env.stored(t, env.issued.RefreshToken)
+				stored.ExpiresAt = time.Now().Add(-time.Minute)
+				if err := env.tokens.Create(ctx, stored); err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+				return env.issued.RefreshToken
+			},
+			wantErr: domain.ErrRefreshTokenInvalid,
+		},
+		{
+			name: "banned user",
+			prepare: func(t *testing.T, env *refreshEnv) string {
+				env.user.BanInfo = &domain.BanInfo{IsBanned: true, BannedAt: time.Now()}
+				return env.issued.RefreshToken
+			},
+			wantErr:       domain.ErrUserBanned,
+			familyRevoked: true,
+		},
+		{
+			name: "denied ip",
+			prepare: func(t *testing.T, env *refreshEnv) string {
+				env.denyList.deniedIP = "198.51.100.7"
+				return env.issued.RefreshToken
+			},
+			ip:      "198.51.100.7",
+			wantErr: domain.ErrDeniedByDenyList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newRefreshEnv(t)
+			token := tt.prepare(t, env)
+
+			_, tokens, err := env.service.RefreshToken(ctx, token, tt.ip, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RefreshToken error = %v, want %v", err, tt.wantErr)
+			}
+			if tokens != nil {
+				t.Error("tokens issued for a rejected refresh")
+			}
+
+			// Ни один токен семейства, включая выданный при ротации, больше не действует
+			familyID := env.stored(t, env.issued.RefreshToken).FamilyID
+			for _, stored := range env.tokens.tokens {
+				if stored.FamilyID != familyID {
+					continue
+				}
+				if stored.IsRevoked() != tt.familyRevoked {
+					t.Errorf("token %s revoked = %v, want %v", stored.ID, stored.IsRevoked(), tt.familyRevoked)
+				}
+			}
+		})
+	}
+}
+
+func TestRefreshTokenReuseAfterRotation(t *testing.T) {
+	ctx := context.Background()
+	env := newRefreshEnv(t)
+
+	_, rotated, err := env.service.RefreshToken(ctx, env.issued.RefreshToken, "", "")
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+
+	// Украденный старый токен предъявлен после ротации: отзываем и новый
+	if _, _, err := env.service.RefreshToken(ctx, env.issued.RefreshToken, "", ""); !errors.Is(err, domain.ErrRefreshTokenInvalid) {
+		t.Fatalf("reused token error = %v, want %v", err, domain.ErrRefreshTokenInvalid)
+	}
+	if _, _, err := env.service.RefreshToken(ctx, rotated.RefreshToken, "", ""); !errors.Is(err, domain.ErrRefreshTokenInvalid) {
+		t.Fatalf("rotated token error = %v, want %v", err, domain.ErrRefreshTokenInvalid)
+	}
+
+	next := env.stored(t, rotated.RefreshToken)
+	if env.tokens.byID(next.ID).RevokedAt == nil {
+		t.Error("token issued by rotation is not revoked")
+	}
+
+	var logged bool
+	for _, activity := range env.audit.activities {
+		if activity.Details["reason"] == "refresh_token_reuse" {
+			logged = true
+		}
+	}
+	if !logged {
+		t.Error("refresh token reuse is not logged")
+	}
+}
+
+func TestUpdateUserRoleChangeRevokesSessions(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        domain.UserRole
+		wantRevoked bool
+	}{
+		{name: "role changed", role: domain.UserRoleModerator, wantRevoked: true},
+		{name: "same role", role: domain.UserRoleUser, wantRevoked: false},
+		{name: "role not set", role: domain.UserRoleUnspecified, wantRevoked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newRefreshEnv(t)
+			revoked := &fakeRevocationStore{revokedBefore: make(map[string]time.Time)}
+			env.service.revoked = revoked
+
+			admin := &domain.Principal{UserID: "admin-1", Role: domain.UserRoleAdmin}
+			ctx := domain.ContextWithPrincipal(context.Background(), admin)
+			update := &domain.User{ID: env.user.ID, Email: env.user.Email, Role: tt.role}
+			if _, err := env.service.UpdateUser(ctx, update); err != nil {
+				t.Fatalf("UpdateUser: %v", err)
+			}
+
+			if _, ok := revoked.revokedBefore[env.user.ID]; ok != tt.wantRevoked {
+				t.Errorf("access tokens revoked = %v, want %v", ok, tt.wantRevoked)
+			}
+			if got := env.stored(t, env.issued.RefreshToken).IsRevoked(); got != tt.wantRevoked {
+				t.Errorf("refresh token revoked = %v, want %v", got, tt.wantRevoked)
+			}
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"userservice/internal/domain"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// webauthnUser адаптирует пользователя и его ключи к интерфейсу webauthn.User
+type webauthnUser struct {
+	user  *domain.User
+	creds []*domain.WebAuthnCredential
+}
+
+func (u *webauthnUser) WebAuthnID() []byte {
+	return []byte(u.user.ID)
+}
+
+func (u *webauthnUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u *webauthnUser) WebAuthnDisplayName() string {
+	return u.user.Name
+}
+
+func (u *webauthnUser) WebAuthnCredentials() []webauthn.Credential {
+	creds := make([]webauthn.Credential, 0, len(u.creds))
+	for _, c := range u.creds {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(c.Transports))
+		for _, t := range c.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(t))
+		}
+
+		creds = append(creds, webauthn.Credential{
+			ID:              c.CredentialID,
+			PublicKey:       c.PublicKey,
+			AttestationType: c.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				UserVerified:   c.UserVerified,
+				BackupEligible: c.BackupEligible,
+				BackupState:    c.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    c.AAGUID,
+				SignCount: c.SignCount,
+			},
+		})
+	}
+	return creds
+}
+
+// credentialByID находит запись ключа по ID, выданному аутентификатором
+func (u *webauthnUser) credentialByID(credentialID []byte) *domain.WebAuthnCredential {
+	for _, c := range u.creds {
+		if bytes.Equal(c.CredentialID, credentialID) {
+			return c
+		}
+	}
+	return nil
+}
+
+// loadWebAuthnUser загружает пользователя вместе с его ключами
+func (s *UserService) loadWebAuthnUser(ctx context.Context, userID string) (*webauthnUser, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := s.webauthnRepo.ListCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webauthnUser{user: user, creds: creds}, nil
+}
+
+// saveWebAuthnSession сохраняет состояние церемонии до ее завершения
+func (s *UserService) saveWebAuthnSession(ctx context.Context, userID string, ceremony domain.WebAuthnCeremony, session *webauthn.SessionData) (string, error) {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return "", err
+	}
+
+	stored := domain.NewWebAuthnSession(userID, ceremony, data, s.config.WebAuthn.SessionTTL)
+	if err := s.webauthnRepo.CreateSession(ctx, stored); err != nil {
+		return "", err
+	}
+
+	return stored.ID, nil
+}
+
+// consumeWebAuthnSession возвращает состояние церемонии. Повторно сессию использовать нельзя.
+func (s *UserService) consumeWebAuthnSession(ctx context.Context, sessionID string, ceremony domain.WebAuthnCeremony) (*domain.WebAuthnSession, *webauthn.SessionData, error) {
+	stored, err := s.webauthnRepo.ConsumeSession(ctx, sessionID, ceremony)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var session webauthn.SessionData
+	if err := json.Unmarshal(stored.Data, &session); err != nil {
+		return nil, nil, domain.ErrWebAuthnSessionInvalid
+	}
+
+	return stored, &session, nil
+}
+
+func (s *UserService) BeginWebAuthnRegistration(ctx context.Context, userID string) (string, []byte, error) {
+	if err := denyImpersonation(ctx); err != nil {
+		return "", nil, err
+	}
+
+	if _, err := s.requireSelf(ctx, userID); err != nil {
+		return "", nil, err
+	}
+
+	wu, err := s.loadWebAuthnUser(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Не даем зарегистрировать один аутентификатор дважды
+	exclusions := webauthn.Credentials(wu.WebAuthnCredentials()).CredentialDescriptors()
+	creation, session, err := s.webAuthn.BeginRegistration(wu,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sessionID, err := s.saveWebAuthnSession(ctx, wu.user.ID, domain.WebAuthnCeremonyRegistration, session)
+	if err != nil {
+		return "", nil, err
+	}
+
+	options, err := json.Marshal(creation)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sessionID, options, nil
+}
+
+func (s *UserService) FinishWebAuthnRegistration(ctx context.Context, userID, sessionID, name string, response []byte) (*domain.WebAuthnCredential, error) {
+	if err := denyImpersonation(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.requireSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	stored, session, err := s.consumeWebAuthnSession(ctx, sessionID, domain.WebAuthnCeremonyRegistration)
+	if err != nil {
+		return nil, err
+	}
+	if stored.UserID != userID {
+		return nil, domain.ErrWebAuthnSessionInvalid
+	}
+
+	wu, err := s.loadWebAuthnUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		return nil, domain.ErrWebAuthnVerificationFailed
+	}
+
+	credential, err := s.webAuthn.CreateCredential(wu, *session, parsed)
+	if err != nil {
+		return nil, domain.ErrWebAuthnVerificationFailed
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = fmt.Sprintf("Ключ %d", len(wu.creds)+1)
+	}
+
+	transports := make([]string, 0, len(credential.Transport))
+	for _, t := range credential.Transport {
+		transports = append(transports, string(t))
+	}
+
+	cred := &domain.WebAuthnCredential{
+		ID:              domain.GenerateUUID(),
+		UserID:          userID,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Transports:      transports,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		UserVerified:    credential.Flags.UserVerified,
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+		Name:            name,
+		CreatedAt:       time.Now(),
+	}
+
+	if err := s.webauthnRepo.CreateCredential(ctx, cred); err != nil {
+		return nil, err
+	}
+
+	// Логируем активность
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeMFAChange, "", "", "")
+	activity.AddDetail("action", "webauthn_registered")
+	activity.AddDetail("credential_id", cred.ID)
+	s.logActivity(ctx, activity)
+
+	return cred, nil
+}
+
+func (s *UserService) BeginWebAuthnLogin(ctx context.Context, email string) (string, []byte, error) {
+	var (
+		wu  *webauthnUser
+		err error
+	)
+	if email != "" {
+		if user, err := s.userRepo.FindByEmail(ctx, email); err == nil {
+			if wu, err = s.loadWebAuthnUser(ctx, user.ID); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	var (
+		assertion *protocol.CredentialAssertion
+		session   *webauthn.SessionData
+		userID    string
+	)
+	// Ключ заменяет пароль и второй фактор, одного касания недостаточно
+	uv := webauthn.WithUserVerification(protocol.VerificationRequired)
+	if wu != nil && len(wu.creds) > 0 {
+		assertion, session, err = s.webAuthn.BeginLogin(wu, uv)
+		userID = wu.user.ID
+	} else {
+		// Вход по passkey без email. Сюда же попадают неизвестные email
+		// и пользователи без ключей, чтобы не раскрывать, существует ли аккаунт.
+		assertion, session, err = s.webAuthn.BeginDiscoverableLogin(uv)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	sessionID, err := s.saveWebAuthnSession(ctx, userID, domain.WebAuthnCeremonyLogin, session)
+	if err != nil {
+		return "", nil, err
+	}
+
+	options, err := json.Marshal(assertion)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sessionID, options, nil
+}
+
+func (s *UserService) FinishWebAuthnLogin(ctx context.Context, sessionID string, response []byte, ip, deviceID string) (*domain.User, *domain.TokenPair, error) {
+	stored, session, err := s.consumeWebAuthnSession(ctx, sessionID, domain.WebAuthnCeremonyLogin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return nil, nil, domain.ErrWebAuthnVerificationFailed
+	}
+
+	var (
+		wu         *webauthnUser
+		credential *webauthn.Credential
+	)
+	if stored.UserID != "" {
+		if wu, err = s.loadWebAuthnUser(ctx, stored.UserID); err != nil {
+			return nil, nil, domain.ErrWebAuthnVerificationFailed
+		}
+		credential, err = s.webAuthn.ValidateLogin(wu, *session, parsed)
+	} else {
+		// Пользователь определяется по userHandle, сохраненному в passkey
+		handler := func(rawID, userHandle []byte) (webauthn.User, error) {
+			loaded, err := s.loadWebAuthnUser(ctx, string(userHandle))
+			if err != nil {
+				return nil, err
+			}
+			wu = loaded
+			return wu, nil
+		}
+		_, credential, err = s.webAuthn.ValidatePasskeyLogin(handler, *session, parsed)
+	}
+	if err != nil {
+		return nil, nil, domain.ErrWebAuthnVerificationFailed
+	}
+
+	cred := wu.credentialByID(credential.ID)
+	if cred == nil {
+		return nil, nil, domain.ErrWebAuthnVerificationFailed
+	}
+
+	// Сессии, начатые до включения обязательной проверки, тоже не пропускаем без нее
+	if !credential.Flags.UserVerified {
+		return nil, nil, domain.ErrWebAuthnVerificationFailed
+	}
+
+	// Счетчик подписей не вырос - возможна копия аутентификатора
+	if credential.Authenticator.CloneWarning {
+		activity := domain.NewUserActivity(wu.user.ID, domain.ActivityTypeLogin, "", "", "")
+		activity.AddDetail("result", "rejected")
+		activity.AddDetail("reason", "webauthn_sign_count_regression")
+		activity.AddDetail("credential_id", cred.ID)
+		s.logActivity(ctx, activity)
+		return nil, nil, domain.ErrWebAuthnVerificationFailed
+	}
+
+	if err := s.webauthnRepo.UpdateCredentialUsage(ctx, cred.ID, credential.Authenticator.SignCount, credential.Flags.BackupState, time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	check := &domain.DenyCheck{IP: ip, EmailDomain: domain.EmailDomain(wu.user.Email), DeviceID: deviceID}
+	if err := s.checkDenyLists(ctx, "webauthn_login", wu.user.Email, check); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.checkLoginAllowed(wu.user); err != nil {
+		// Как и при входе по паролю, забаненный владелец ключа может обжаловать бан
+		if err == domain.ErrUserBanned {
+			return nil, nil, s.bannedError(wu.user)
+		}
+		return nil, nil, err
+	}
+
+	// Ключ с проверкой пользователя сам является вторым фактором, TOTP не запрашиваем
+	return s.completeLogin(ctx, wu.user, "webauthn")
+}
+
+func (s *UserService) ListWebAuthnCredentials(ctx context.Context, userID string) ([]*domain.WebAuthnCredential, error) {
+	if _, err := s.requireSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.webauthnRepo.ListCredentials(ctx, userID)
+}
+
+func (s *UserService) RenameWebAuthnCredential(ctx context.Context, userID, credentialID, name string) (*domain.WebAuthnCredential, error) {
+	if _, err := s.requireSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, domain.NewRequiredFieldError("name")
+	}
+
+	return s.webauthnRepo.RenameCredential(ctx, userID, credentialID, name)
+}
+
+func (s *UserService) DeleteWebAuthnCredential(ctx context.Context, userID, credentialID string) error {
+	if err := denyImpersonation(ctx); err != nil {
+		return err
+	}
+
+	if _, err := s.requireSelf(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.webauthnRepo.DeleteCredential(ctx, userID, credentialID); err != nil {
+		return err
+	}
+
+	// Логируем активность
+	activity := domain.NewUserActivity(userID, domain.ActivityTypeMFAChange, "", "", "")
+	activity.AddDetail("action", "webauthn_deleted")
+	activity.AddDetail("credential_id", credentialID)
+	s.logActivity(ctx, activity)
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Package breached проверяет пароли по локальной базе утекших паролей
+// в формате Have I Been Pwned: SHA-1 хеш в hex и число появлений в утечках.
+//
+// Поддерживаются два варианта базы:
+//   - каталог с файлами диапазонов (k-anonymity): файл назван первыми 5 символами
+//     хеша (ABCDE или ABCDE.txt) и содержит строки SUFFIX:COUNT;
+//   - один файл со строками HASH:COUNT (или просто HASH), который целиком
+//     загружается в память и индексируется по тем же 5-символьным префиксам.
+package breached
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const prefixLength = 5
+
+// Checker ищет хеш пароля в базе утекших паролей
+type Checker struct {
+	dir      string                    // Каталог файлов диапазонов
+	ranges   map[string]map[string]int // Префикс -> суффикс -> число появлений
+	minCount int
+}
+
+// Open открывает базу по пути к каталогу или файлу.
+// Пароли, встречавшиеся в утечках реже minCount раз, не считаются утекшими.
+func Open(path string, minCount int) (*Checker, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached passwords: %w", err)
+	}
+
+	c := &Checker{minCount: minCount}
+	if info.IsDir() {
+		c.dir = path
+		return c, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached passwords: %w", err)
+	}
+	defer f.Close()
+
+	c.ranges = make(map[string]map[string]int)
+	err = scan(f, func(hash string, count int) {
+		prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+		if c.ranges[prefix] == nil {
+			c.ranges[prefix] = make(map[string]int)
+		}
+		c.ranges[prefix][suffix] = count
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load breached passwords: %w", err)
+	}
+
+	return c, nil
+}
+
+// IsBreached проверяет, встречался ли пароль в утечках
+func (c *Checker) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	if c.ranges != nil {
+		count, ok := c.ranges[prefix][suffix]
+		return ok && count >= c.minCount, nil
+	}
+
+	return c.lookupRange(prefix, suffix)
+}
+
+// lookupRange ищет суффикс в файле диапазона каталога
+func (c *Checker) lookupRange(prefix, suffix string) (bool, error) {
+	var f *os.File
+	var err error
+	for _, name := range []string{prefix, prefix + ".txt"} {
+		f, err = os.Open(filepath.Join(c.dir, name))
+		if err == nil || !errors.Is(err, os.ErrNotExist) {
+			break
+		}
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		// Нет файла - нет утекших паролей с таким префиксом
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	found := false
+	err = scan(f, func(s string, count int) {
+		if s == suffix && count >= c.minCount {
+			found = true
+		}
+	})
+	return found, err
+}
+
+// scan читает строки вида HASH[:COUNT]. Строка без счетчика считается встреченной один раз.
+func scan(r io.Reader, fn func(hash string, count int)) error {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash, rawCount, hasCount := strings.Cut(line, ":")
+		count := 1
+		if hasCount {
+			n, err := strconv.Atoi(strings.TrimSpace(rawCount))
+			if err != nil {
+				return fmt.Errorf("invalid line %q", line)
+			}
+			count = n
+		}
+
+		hash = strings.ToUpper(strings.TrimSpace(hash))
+		if len(hash) < prefixLength {
+			return fmt.Errorf("invalid line %q", line)
+		}
+		fn(hash, count)
+	}
+	return sc.Err()
+}
@@ -1,61 +1,329 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type JWTManager struct {
-	secretKey string
-	expiry    time.Duration
+	secretKey     string
+	keys          *KeyManager // Если задан, токены подписываются асимметричным ключом
+	issuer        string      // iss всех токенов
+	audience      string      // aud access токенов пользователей
+	expiry        time.Duration
+	refreshExpiry time.Duration
 }
 
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID   string `json:"user_id"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	TenantID string `json:"tid,omitempty"`     // Активная организация, пусто вне организации
+	Purpose  string `json:"purpose,omitempty"` // Пусто у access токенов
+	Ref      string `json:"ref,omitempty"`     // Объект, к которому привязан одноцелевой токен
+
+	// Заполнены только у токенов сервисных аккаунтов
+	ClientID    string   `json:"client_id,omitempty"`
+	Permissions []string `json:"perms,omitempty"`
+
+	// Реальный исполнитель при имперсонации (RFC 8693)
+	Actor *Actor `json:"act,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
-func NewJWTManager(secretKey string, expiry time.Duration) *JWTManager {
+// Actor - администратор, действующий от имени пользователя из UserID
+type Actor struct {
+	Subject string `json:"sub"`
+}
+
+// Назначения одноцелевых токенов
+const (
+	PurposeEmailVerification = "email_verification"
+	PurposeMFAChallenge      = "mfa_challenge" // Пароль проверен, ожидается второй фактор
+	PurposeBanAppeal         = "ban_appeal"    // Пароль забаненного пользователя проверен, можно подать апелляцию
+)
+
+// Значения заголовка typ. По нему и по aud проверяющие офлайн по JWKS
+// отличают access токены от одноцелевых, подписанных тем же ключом.
+const (
+	TypeAccess  = "at+jwt" // RFC 9068
+	TypePurpose = "purpose+jwt"
+)
+
+// ErrWrongPurpose - токен выпущен для другой цели
+var ErrWrongPurpose = errors.New("token issued for another purpose")
+
+// ErrServiceToken - токен выпущен сервисному аккаунту, а не пользователю
+var ErrServiceToken = errors.New("token issued to a service account")
+
+// NewJWTManager создает менеджер, подписывающий токены общим секретом (HS256).
+// issuer попадает в iss всех токенов, audience - в aud access токенов пользователей.
+func NewJWTManager(secretKey, issuer, audience string, expiry, refreshExpiry time.Duration) *JWTManager {
 	return &JWTManager{
-		secretKey: secretKey,
-		expiry:    expiry,
+		secretKey:     secretKey,
+		issuer:        issuer,
+		audience:      audience,
+		expiry:        expiry,
+		refreshExpiry: refreshExpiry,
 	}
 }
 
-func (m *JWTManager) GenerateToken(userID, email, role string) (string, error) {
+// NewKeyedJWTManager создает менеджер, подписывающий токены ключами из KeyManager
+// (RS256 или EdDSA) с заголовком kid. Такие токены можно проверять офлайн по JWKS.
+func NewKeyedJWTManager(keys *KeyManager, issuer, audience string, expiry, refreshExpiry time.Duration) *JWTManager {
+	return &JWTManager{
+		keys:          keys,
+		issuer:        issuer,
+		audience:      audience,
+		expiry:        expiry,
+		refreshExpiry: refreshExpiry,
+	}
+}
+
+// Expiry возвращает время жизни access токена
+func (m *JWTManager) Expiry() time.Duration {
+	return m.expiry
+}
+
+// RefreshExpiry возвращает время жизни refresh токена
+func (m *JWTManager) RefreshExpiry() time.Duration {
+	return m.refreshExpiry
+}
+
+// GenerateToken выпускает access токен. tenantID - активная организация пользователя.
+func (m *JWTManager) GenerateToken(userID, email, role, tenantID string) (string, error) {
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:   userID,
+		Email:    email,
+		Role:     role,
+		TenantID: tenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(), // jti - для отзыва конкретного токена
+			Issuer:    m.issuer,
+			Audience:  jwt.ClaimStrings{m.audience},
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.expiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(m.secretKey))
+	return m.sign(claims, TypeAccess)
 }
 
-func (m *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+// sign подписывает claims активным ключом или общим секретом
+// и записывает тип токена в заголовок typ
+func (m *JWTManager) sign(claims jwt.Claims, typ string) (string, error) {
+	if m.keys == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		token.Header["typ"] = typ
+		return token.SignedString([]byte(m.secretKey))
+	}
+
+	key := m.keys.activeKey()
+	if key == nil {
+		return "", errors.New("no active signing key")
+	}
+
+	token := jwt.NewWithClaims(signingMethod(key.alg), claims)
+	token.Header["typ"] = typ
+	token.Header["kid"] = key.kid
+	return token.SignedString(key.private)
+}
+
+// keyFunc выбирает ключ проверки подписи по заголовкам токена
+func (m *JWTManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	if m.keys == nil {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
 		}
 		return []byte(m.secretKey), nil
-	})
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := m.keys.lookupOrReload(kid)
+	if !ok {
+		return nil, errors.New("unknown signing key")
+	}
+
+	// Алгоритм берем из ключа, а не из заголовка токена
+	if token.Method.Alg() != signingMethod(key.alg).Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
 
+	return key.private.Public(), nil
+}
+
+// ValidateToken проверяет access токен пользователя
+func (m *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
+	claims, err := m.parse(tokenString, TypeAccess)
 	if err != nil {
+		if errors.Is(err, errWrongType) {
+			return nil, ErrWrongPurpose
+		}
 		return nil, err
 	}
 
+	// Одноцелевые токены подписаны тем же ключом, но не дают доступа к API
+	if claims.Purpose != "" {
+		return nil, ErrWrongPurpose
+	}
+
+	// Токены сервисов проверяются ValidateServiceToken с учетом audience
+	if claims.ClientID != "" {
+		return nil, ErrServiceToken
+	}
+
+	if !slices.Contains(claims.Audience, m.audience) {
+		return nil, errors.New("token issued for another audience")
+	}
+
+	return claims, nil
+}
+
+// GenerateImpersonationToken выпускает access токен пользователя userID,
+// в котором actorID записан как реальный исполнитель
+func (m *JWTManager) GenerateImpersonationToken(userID, email, role, actorID string, ttl time.Duration) (string, error) {
+	claims := &Claims{
+		UserID: userID,
+		Email:  email,
+		Role:   role,
+		Actor:  &Actor{Subject: actorID},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Issuer:    m.issuer,
+			Audience:  jwt.ClaimStrings{m.audience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return m.sign(claims, TypeAccess)
+}
+
+// GenerateServiceToken выпускает access токен сервисного аккаунта,
+// действительный только для сервисов из audience
+func (m *JWTManager) GenerateServiceToken(clientID string, permissions, audience []string, ttl time.Duration) (string, error) {
+	claims := &Claims{
+		ClientID:    clientID,
+		Permissions: permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   clientID,
+			Issuer:    m.issuer,
+			Audience:  audience,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return m.sign(claims, TypeAccess)
+}
+
+// ValidateServiceToken проверяет токен сервисного аккаунта, выпущенный для audience
+func (m *JWTManager) ValidateServiceToken(tokenString, audience string) (*Claims, error) {
+	claims, err := m.parse(tokenString, TypeAccess, jwt.WithAudience(audience))
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.ClientID == "" || claims.Purpose != "" {
+		return nil, errors.New("not a service token")
+	}
+
+	return claims, nil
+}
+
+// GeneratePurposeToken выпускает короткоживущий токен для одной операции
+// (подтверждение email и т.п.). ValidateToken такие токены не принимает,
+// а офлайн-проверка отличает их по typ и по отсутствию aud access токенов.
+func (m *JWTManager) GeneratePurposeToken(userID, email, purpose string, ttl time.Duration) (string, error) {
+	return m.GenerateBoundPurposeToken(userID, email, purpose, "", ttl)
+}
+
+// GenerateBoundPurposeToken выпускает одноцелевой токен, привязанный к объекту ref
+// (например, к конкретному бану). Проверяющий сравнивает claims.Ref с текущим объектом.
+func (m *JWTManager) GenerateBoundPurposeToken(userID, email, purpose, ref string, ttl time.Duration) (string, error) {
+	claims := &Claims{
+		UserID:  userID,
+		Email:   email,
+		Purpose: purpose,
+		Ref:     ref,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Issuer:    m.issuer,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return m.sign(claims, TypePurpose)
+}
+
+// ValidatePurposeToken проверяет одноцелевой токен и его назначение
+func (m *JWTManager) ValidatePurposeToken(tokenString, purpose string) (*Claims, error) {
+	claims, err := m.parse(tokenString, TypePurpose)
+	if err != nil {
+		if errors.Is(err, errWrongType) {
+			return nil, ErrWrongPurpose
+		}
+		return nil, err
+	}
+
+	if claims.Purpose != purpose {
+		return nil, ErrWrongPurpose
+	}
+
+	return claims, nil
+}
+
+// errWrongType - заголовок typ не совпадает с ожидаемым типом токена
+var errWrongType = errors.New("unexpected token type")
+
+// parse проверяет подпись, срок действия, издателя и тип токена
+func (m *JWTManager) parse(tokenString, typ string, opts ...jwt.ParserOption) (*Claims, error) {
+	opts = append(opts, jwt.WithIssuer(m.issuer), jwt.WithExpirationRequired())
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.keyFunc, opts...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if header, _ := token.Header["typ"].(string); header != typ {
+		return nil, errWrongType
+	}
+
 	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
 		return claims, nil
 	}
 
 	return nil, errors.New("invalid token")
 }
+
+// GenerateRefreshToken создает непрозрачный случайный refresh токен.
+// В хранилище должен попадать только его хеш (см. HashToken).
+func (m *JWTManager) GenerateRefreshToken() (string, error) {
+	return GenerateOpaqueToken(32)
+}
+
+// GenerateOpaqueToken создает случайную строку из size байт в base64url
+func GenerateOpaqueToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashToken возвращает SHA-256 хеш непрозрачного токена для хранения в БД
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,247 @@
+package jwt
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newTestManagers(t *testing.T) map[string]*JWTManager {
+	t.Helper()
+
+	keys, err := NewKeyManager(t.TempDir(), AlgorithmEdDSA, 0)
+	if err != nil {
+		t.Fatalf("NewKeyManager: %v", err)
+	}
+
+	return map[string]*JWTManager{
+		"HS256": NewJWTManager("test-secret", "user-service", "api", time.Hour, 24*time.Hour),
+		"EdDSA": NewKeyedJWTManager(keys, "user-service", "api", time.Hour, 24*time.Hour),
+	}
+}
+
+func TestValidateTokenRejectsNonAccessTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func(m *JWTManager) (string, error)
+		wantErr  error
+	}{
+		{
+			name: "access token",
+			generate: func(m *JWTManager) (string, error) {
+				return m.GenerateToken("user-1", "user@example.com", "USER", "")
+			},
+		},
+		{
+			name: "impersonation token",
+			generate: func(m *JWTManager) (string, error) {
+				return m.GenerateImpersonationToken("user-1", "user@example.com", "USER", "admin-1", time.Minute)
+			},
+		},
+		{
+			name: "email verification token",
+			generate: func(m *JWTManager) (string, error) {
+				return m.GeneratePurposeToken("user-1", "user@example.com", PurposeEmailVerification, time.Minute)
+			},
+			wantErr: ErrWrongPurpose,
+		},
+		{
+			name: "mfa challenge token",
+			generate: func(m *JWTManager) (string, error) {
+				return m.GeneratePurposeToken("user-1", "user@example.com", PurposeMFAChallenge, time.Minute)
+			},
+			wantErr: ErrWrongPurpose,
+		},
+		{
+			name: "ban appeal token",
+			generate: func(m *JWTManager) (string, error) {
+				return m.GeneratePurposeToken("user-1", "user@example.com", PurposeBanAppeal, time.Minute)
+			},
+			wantErr: ErrWrongPurpose,
+		},
+		{
+			name: "service token",
+			generate: func(m *JWTManager) (string, error) {
+				return m.GenerateServiceToken("billing", []string{"subscriptions.read"}, []string{"userservice"}, time.Minute)
+			},
+			wantErr: ErrServiceToken,
+		},
+	}
+
+	for alg, m := range newTestManagers(t) {
+		for _, tt := range tests {
+			t.Run(alg+"/"+tt.name, func(t *testing.T) {
+				token, err := tt.generate(m)
+				if err != nil {
+					t.Fatalf("generate: %v", err)
+				}
+
+				claims, err := m.ValidateToken(token)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ValidateToken error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr == nil && claims.UserID != "user-1" {
+					t.Errorf("UserID = %q, want %q", claims.UserID, "user-1")
+				}
+			})
+		}
+	}
+}
+
+func TestValidatePurposeToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		issued  string
+		want    string
+		wantErr bool
+	}{
+		{name: "same purpose", issued: PurposeEmailVerification, want: PurposeEmailVerification},
+		{name: "other purpose", issued: PurposeMFAChallenge, want: PurposeEmailVerification, wantErr: true},
+		{name: "access token", issued: "", want: PurposeBanAppeal, wantErr: true},
+	}
+
+	for alg, m := range newTestManagers(t) {
+		for _, tt := range tests {
+			t.Run(alg+"/"+tt.name, func(t *testing.T) {
+				token, err := m.GeneratePurposeToken("user-1", "user@example.com", tt.issued, time.Minute)
+				if err != nil {
+					t.Fatalf("GeneratePurposeToken: %v", err)
+				}
+
+				_, err = m.ValidatePurposeToken(token, tt.want)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("ValidatePurposeToken error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErr && !errors.Is(err, ErrWrongPurpose) {
+					t.Errorf("ValidatePurposeToken error = %v, want %v", err, ErrWrongPurpose)
+				}
+			})
+		}
+	}
+}
+
+func TestValidateServiceToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func(m *JWTManager) (string, error)
+		audience string
+		wantErr  bool
+	}{
+		{
+			name: "issued for audience",
+			generate: func(m *JWTManager) (string, error) {
+				return m.GenerateServiceToken("billing", nil, []string{"userservice"}, time.Minute)
+			},
+			audience: "userservice",
+		},
+		{
+			name: "issued for another audience",
+			generate: func(m *JWTManager) (string, error) {
+				return m.GenerateServiceToken("billing", nil, []string{"gateway"}, time.Minute)
+			},
+			audience: "userservice",
+			wantErr:  true,
+		},
+		{
+			name: "user access token",
+			generate: func(m *JWTManager) (string, error) {
+				return m.GenerateToken("user-1", "user@example.com", "USER", "")
+			},
+			audience: "userservice",
+			wantErr:  true,
+		},
+	}
+
+	for alg, m := range newTestManagers(t) {
+		for _, tt := range tests {
+			t.Run(alg+"/"+tt.name, func(t *testing.T) {
+				token, err := tt.generate(m)
+				if err != nil {
+					t.Fatalf("generate: %v", err)
+				}
+
+				_, err = m.ValidateServiceToken(token, tt.audience)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("ValidateServiceToken error = %v, wantErr %v", err, tt.wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestValidateTokenRejectsExpired(t *testing.T) {
+	for alg, m := range newTestManagers(t) {
+		t.Run(alg, func(t *testing.T) {
+			token, err := m.GeneratePurposeToken("user-1", "user@example.com", PurposeEmailVerification, -time.Minute)
+			if err != nil {
+				t.Fatalf("GeneratePurposeToken: %v", err)
+			}
+
+			if _, err := m.ValidatePurposeToken(token, PurposeEmailVerification); err == nil {
+				t.Fatal("ValidatePurposeToken accepted an expired token")
+			}
+		})
+	}
+}
+
+func TestValidateTokenPinsIssuerAndAudience(t *testing.T) {
+	tests := []struct {
+		name     string
+		issuer   string
+		audience string
+		wantErr  bool
+	}{
+		{name: "same issuer and audience", issuer: "user-service", audience: "api"},
+		{name: "other issuer", issuer: "other-service", audience: "api", wantErr: true},
+		{name: "other audience", issuer: "user-service", audience: "admin-api", wantErr: true},
+	}
+
+	m := NewJWTManager("test-secret", "user-service", "api", time.Hour, 24*time.Hour)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := NewJWTManager("test-secret", tt.issuer, tt.audience, time.Hour, 24*time.Hour)
+			token, err := issuer.GenerateToken("user-1", "user@example.com", "USER", "")
+			if err != nil {
+				t.Fatalf("GenerateToken: %v", err)
+			}
+
+			if _, err := m.ValidateToken(token); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateToken error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Проверяющий по JWKS без знания о purpose должен отвергать одноцелевые
+// токены, если требует aud и typ access токенов
+func TestOfflineVerificationRejectsPurposeTokens(t *testing.T) {
+	for alg, m := range newTestManagers(t) {
+		t.Run(alg, func(t *testing.T) {
+			access, err := m.GenerateToken("user-1", "user@example.com", "USER", "")
+			if err != nil {
+				t.Fatalf("GenerateToken: %v", err)
+			}
+			challenge, err := m.GeneratePurposeToken("user-1", "user@example.com", PurposeMFAChallenge, time.Minute)
+			if err != nil {
+				t.Fatalf("GeneratePurposeToken: %v", err)
+			}
+
+			verify := func(token string) (*jwt.Token, error) {
+				return jwt.Parse(token, m.keyFunc, jwt.WithIssuer("user-service"), jwt.WithAudience("api"))
+			}
+
+			parsed, err := verify(access)
+			if err != nil {
+				t.Fatalf("access token: %v", err)
+			}
+			if parsed.Header["typ"] != TypeAccess {
+				t.Errorf("access token typ = %v, want %s", parsed.Header["typ"], TypeAccess)
+			}
+			if _, err := verify(challenge); err == nil {
+				t.Error("mfa challenge token passed offline verification as an access token")
+			}
+		})
+	}
+}
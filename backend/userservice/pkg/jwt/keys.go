@@ -0,0 +1,396 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Поддерживаемые асимметричные алгоритмы подписи
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// rotationCheckInterval - как часто перечитывается каталог ключей
+// и проверяется необходимость ротации
+const rotationCheckInterval = time.Minute
+
+// missReloadInterval - как часто можно перечитывать каталог из-за токена
+// с неизвестным kid. Ограничивает нагрузку от токенов с мусорным kid.
+const missReloadInterval = 10 * time.Second
+
+// kidTimeLayout - формат времени создания в начале kid выпущенных ключей
+const kidTimeLayout = "20060102T150405Z"
+
+// signingKey - ключевая пара, загруженная из каталога ключей
+type signingKey struct {
+	kid       string
+	alg       string
+	private   crypto.Signer
+	createdAt time.Time
+	path      string
+}
+
+// KeyManager хранит набор ключей подписи из каталога на диске.
+// Новый ключ сначала только публикуется в JWKS и начинает подписывать
+// через publishDelay, когда его успели получить все, кто кеширует JWKS.
+// Подписывает самый новый опубликованный ключ нужного алгоритма, проверять
+// подпись можно любым загруженным ключом, пока он не удален из каталога.
+type KeyManager struct {
+	mu           sync.RWMutex
+	dir          string
+	alg          string
+	publishDelay time.Duration
+	keys         map[string]*signingKey
+	active       *signingKey
+	latest       *signingKey // Самый новый ключ алгоритма, возможно еще не активный
+
+	reloadMu       sync.Mutex
+	lastMissReload time.Time
+}
+
+// NewKeyManager загружает ключи из каталога.
+// Если подходящего ключа нет, генерирует первый: он активен сразу,
+// так как раньше него токены никто не проверял.
+func NewKeyManager(dir, alg string, publishDelay time.Duration) (*KeyManager, error) {
+	if alg != AlgorithmRS256 && alg != AlgorithmEdDSA {
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", alg)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create keys dir: %w", err)
+	}
+
+	m := &KeyManager{
+		dir:          dir,
+		alg:          alg,
+		publishDelay: publishDelay,
+		keys:         make(map[string]*signingKey),
+	}
+
+	if err := m.Reload(); err != nil {
+		return nil, err
+	}
+
+	if m.activeKey() == nil {
+		if err := m.Rotate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Reload перечитывает ключи из каталога
+func (m *KeyManager) Reload() error {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read keys dir: %w", err)
+	}
+
+	keys := make(map[string]*signingKey)
+	var active, latest *signingKey
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".pem" {
+			continue
+		}
+
+		path := filepath.Join(m.dir, entry.Name())
+		key, err := loadSigningKey(path)
+		if err != nil {
+			log.Printf("Skipping signing key %s: %v", entry.Name(), err)
+			continue
+		}
+
+		keys[key.kid] = key
+		if key.alg != m.alg {
+			continue
+		}
+		if latest == nil || key.newerThan(latest) {
+			latest = key
+		}
+		published := time.Since(key.createdAt) >= m.publishDelay
+		if published && (active == nil || key.newerThan(active)) {
+			active = key
+		}
+	}
+
+	// Опубликованных ключей нет (первый запуск или старые ключи удалены вручную):
+	// подписываем самым новым, иначе сервис не сможет выдавать токены
+	if active == nil {
+		active = latest
+	}
+
+	m.mu.Lock()
+	m.keys = keys
+	m.active = active
+	m.latest = latest
+	m.mu.Unlock()
+
+	return nil
+}
+
+// Rotate генерирует новый ключ. Он сразу попадает в JWKS, а подписывать
+// начинает через publishDelay. Старые ключи остаются в наборе для проверки
+// уже выданных токенов.
+func (m *KeyManager) Rotate() error {
+	var private crypto.Signer
+	switch m.alg {
+	case AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return fmt.Errorf("failed to generate rsa key: %w", err)
+		}
+		private = key
+	case AlgorithmEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return fmt.Errorf("failed to generate ed25519 key: %w", err)
+		}
+		private = key
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return fmt.Errorf("failed to marshal private key: %w", err)
+	}
+
+	suffix, err := GenerateOpaqueToken(4)
+	if err != nil {
+		return err
+	}
+
+	// kid начинается с времени создания, чтобы файлы сортировались по возрасту
+	kid := time.Now().UTC().Format(kidTimeLayout) + "-" + suffix
+	path := filepath.Join(m.dir, kid+".pem")
+
+	// Пишем через временный файл, чтобы соседние реплики не прочитали ключ наполовину
+	tmp := path + ".tmp"
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write signing key: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write signing key: %w", err)
+	}
+
+	log.Printf("Generated new signing key %s (%s)", kid, m.alg)
+	return m.Reload()
+}
+
+// Prune удаляет из каталога неактивные ключи старше maxAge.
+// maxAge должен быть больше времени жизни токенов, иначе выданные
+// токены перестанут проходить проверку.
+func (m *KeyManager) Prune(maxAge time.Duration) error {
+	m.mu.RLock()
+	var stale []*signingKey
+	for _, key := range m.keys {
+		if key != m.active && time.Since(key.createdAt) > maxAge {
+			stale = append(stale, key)
+		}
+	}
+	m.mu.RUnlock()
+
+	if len(stale) == 0 {
+		return nil
+	}
+
+	for _, key := range stale {
+		if err := os.Remove(key.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove signing key %s: %w", key.kid, err)
+		}
+		log.Printf("Removed retired signing key %s", key.kid)
+	}
+
+	return m.Reload()
+}
+
+// StartRotation периодически перечитывает каталог ключей и выпускает новый
+// ключ, когда самый новый старше interval. Ключ подписывает до активации
+// следующего, то есть до interval+publishDelay от создания, поэтому удаляются
+// ключи старше interval+publishDelay+retention.
+// Несколько реплик с общим каталогом подхватывают ключи друг друга.
+func (m *KeyManager) StartRotation(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(rotationCheckInterval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.Reload(); err != nil {
+					log.Printf("Failed to reload signing keys: %v", err)
+					continue
+				}
+
+				latest := m.latestKey()
+				if latest == nil || time.Since(latest.createdAt) >= interval {
+					if err := m.Rotate(); err != nil {
+						log.Printf("Failed to rotate signing key: %v", err)
+						continue
+					}
+				}
+
+				if err := m.Prune(interval + m.publishDelay + retention); err != nil {
+					log.Printf("Failed to prune signing keys: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// activeKey возвращает текущий ключ подписи
+func (m *KeyManager) activeKey() *signingKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.active
+}
+
+// latestKey возвращает самый новый ключ, возможно еще не активный
+func (m *KeyManager) latestKey() *signingKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.latest
+}
+
+// lookup находит ключ по kid
+func (m *KeyManager) lookup(kid string) (*signingKey, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	key, ok := m.keys[kid]
+	return key, ok
+}
+
+// lookupOrReload находит ключ по kid, а если его нет - перечитывает каталог
+// не чаще missReloadInterval: ключ могла выпустить соседняя реплика
+// между плановыми перечитываниями.
+func (m *KeyManager) lookupOrReload(kid string) (*signingKey, bool) {
+	if key, ok := m.lookup(kid); ok {
+		return key, true
+	}
+
+	m.reloadMu.Lock()
+	if time.Since(m.lastMissReload) < missReloadInterval {
+		m.reloadMu.Unlock()
+		return nil, false
+	}
+	m.lastMissReload = time.Now()
+	m.reloadMu.Unlock()
+
+	if err := m.Reload(); err != nil {
+		log.Printf("Failed to reload signing keys: %v", err)
+		return nil, false
+	}
+
+	return m.lookup(kid)
+}
+
+// publicKeys возвращает все загруженные ключи
+func (m *KeyManager) publicKeys() []*signingKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]*signingKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// signingMethod возвращает метод подписи golang-jwt для алгоритма
+func signingMethod(alg string) jwt.SigningMethod {
+	if alg == AlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// loadSigningKey читает закрытый ключ в формате PEM (PKCS#8 или PKCS#1)
+func loadSigningKey(path string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed any
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+	key := &signingKey{
+		kid:       kid,
+		createdAt: keyCreatedAt(kid, info.ModTime()),
+		path:      path,
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.alg = AlgorithmRS256
+		key.private = k
+	case ed25519.PrivateKey:
+		key.alg = AlgorithmEdDSA
+		key.private = k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+
+	return key, nil
+}
+
+// keyCreatedAt возвращает время создания ключа из его kid. mtime меняется
+// при копировании и восстановлении каталога из бэкапа, поэтому используется
+// только для ключей, положенных вручную под произвольным именем.
+func keyCreatedAt(kid string, modTime time.Time) time.Time {
+	prefix, _, _ := strings.Cut(kid, "-")
+	if createdAt, err := time.Parse(kidTimeLayout, prefix); err == nil {
+		return createdAt
+	}
+	return modTime
+}
+
+// newerThan сравнивает ключи по времени создания. kid хранит время
+// с точностью до секунды, при равенстве реплики выбирают один ключ по kid.
+func (k *signingKey) newerThan(other *signingKey) bool {
+	if !k.createdAt.Equal(other.createdAt) {
+		return k.createdAt.After(other.createdAt)
+	}
+	return k.kid > other.kid
+}
@@ -0,0 +1,251 @@
+package jwt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setKeyAge сдвигает время создания ключа: возраст ключа берется из kid,
+// поэтому файл переименовывается. Возвращает новый kid.
+func setKeyAge(t *testing.T, m *KeyManager, kid string, age time.Duration) string {
+	t.Helper()
+
+	_, suffix, _ := strings.Cut(kid, "-")
+	aged := time.Now().Add(-age).UTC().Format(kidTimeLayout) + "-" + suffix
+	if err := os.Rename(filepath.Join(m.dir, kid+".pem"), filepath.Join(m.dir, aged+".pem")); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if err := m.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	return aged
+}
+
+// rotate выпускает новый ключ и возвращает его kid
+func rotate(t *testing.T, m *KeyManager) string {
+	t.Helper()
+
+	before := make(map[string]bool)
+	for _, key := range m.publicKeys() {
+		before[key.kid] = true
+	}
+
+	if err := m.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+
+	for _, key := range m.publicKeys() {
+		if !before[key.kid] {
+			return key.kid
+		}
+	}
+	t.Fatal("Rotate did not add a key")
+	return ""
+}
+
+func TestNewKeyManagerActivatesFirstKey(t *testing.T) {
+	m, err := NewKeyManager(t.TempDir(), AlgorithmEdDSA, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeyManager: %v", err)
+	}
+
+	active := m.activeKey()
+	if active == nil {
+		t.Fatal("no active key")
+	}
+	if active != m.latestKey() {
+		t.Errorf("active key %s is not the generated key %s", active.kid, m.latestKey().kid)
+	}
+}
+
+func TestRotatePublishesBeforeSigning(t *testing.T) {
+	tests := []struct {
+		name         string
+		publishDelay time.Duration
+		newKeyAge    time.Duration
+		wantNew      bool
+	}{
+		{name: "no delay", publishDelay: 0, wantNew: true},
+		{name: "just published", publishDelay: 10 * time.Minute, wantNew: false},
+		{name: "delay not passed", publishDelay: 10 * time.Minute, newKeyAge: 5 * time.Minute, wantNew: false},
+		{name: "delay passed", publishDelay: 10 * time.Minute, newKeyAge: 11 * time.Minute, wantNew: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewKeyManager(t.TempDir(), AlgorithmEdDSA, tt.publishDelay)
+			if err != nil {
+				t.Fatalf("NewKeyManager: %v", err)
+			}
+			oldKid := setKeyAge(t, m, m.activeKey().kid, 24*time.Hour)
+			newKid := setKeyAge(t, m, rotate(t, m), tt.newKeyAge)
+
+			want := oldKid
+			if tt.wantNew {
+				want = newKid
+			}
+			if got := m.activeKey().kid; got != want {
+				t.Errorf("active key = %s, want %s", got, want)
+			}
+
+			// Новый ключ публикуется в JWKS сразу, старый остается для проверки
+			for _, kid := range []string{oldKid, newKid} {
+				if _, ok := m.lookup(kid); !ok {
+					t.Errorf("key %s is not published", kid)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyCreatedAt(t *testing.T) {
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		kid  string
+		want time.Time
+	}{
+		{name: "generated kid", kid: "20240601T120000Z-a1b2c3d4", want: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)},
+		{name: "manual kid", kid: "legacy-key", want: modTime},
+		{name: "no suffix", kid: "20240601T120000Z", want: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keyCreatedAt(tt.kid, modTime); !got.Equal(tt.want) {
+				t.Errorf("keyCreatedAt(%q) = %v, want %v", tt.kid, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokensSurviveRotation(t *testing.T) {
+	keys, err := NewKeyManager(t.TempDir(), AlgorithmEdDSA, 0)
+	if err != nil {
+		t.Fatalf("NewKeyManager: %v", err)
+	}
+	m := NewKeyedJWTManager(keys, "user-service", "api", time.Hour, 24*time.Hour)
+
+	before, err := m.GenerateToken("user-1", "user@example.com", "USER", "")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	rotate(t, keys)
+
+	after, err := m.GenerateToken("user-1", "user@example.com", "USER", "")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	for name, token := range map[string]string{"before rotation": before, "after rotation": after} {
+		if _, err := m.ValidateToken(token); err != nil {
+			t.Errorf("token issued %s: %v", name, err)
+		}
+	}
+}
+
+func TestLookupReloadsKeysOfOtherReplicas(t *testing.T) {
+	dir := t.TempDir()
+
+	a, err := NewKeyManager(dir, AlgorithmEdDSA, 0)
+	if err != nil {
+		t.Fatalf("NewKeyManager: %v", err)
+	}
+	b, err := NewKeyManager(dir, AlgorithmEdDSA, 0)
+	if err != nil {
+		t.Fatalf("NewKeyManager: %v", err)
+	}
+
+	// Реплика b выпускает ключ, о котором a еще не знает
+	kid := rotate(t, b)
+	if _, ok := a.lookup(kid); ok {
+		t.Fatalf("key %s is known before reload", kid)
+	}
+
+	if _, ok := a.lookupOrReload(kid); !ok {
+		t.Fatalf("key %s is not found after reload", kid)
+	}
+
+	// Повторный промах не перечитывает каталог раньше missReloadInterval
+	if _, ok := a.lookupOrReload("unknown"); ok {
+		t.Fatal("unknown key found")
+	}
+	lastReload := a.lastMissReload
+	if _, ok := a.lookupOrReload("unknown"); ok {
+		t.Fatal("unknown key found")
+	}
+	if !a.lastMissReload.Equal(lastReload) {
+		t.Error("keys dir reloaded again within missReloadInterval")
+	}
+}
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		name        string
+		ages        []time.Duration // Возраст ключей от старого к новому
+		maxAge      time.Duration
+		wantRemoved []int // Индексы удаленных ключей
+	}{
+		{
+			name:   "all keys fresh",
+			ages:   []time.Duration{3 * time.Hour, 2 * time.Hour, time.Hour},
+			maxAge: 4 * time.Hour,
+		},
+		{
+			name:        "old keys removed",
+			ages:        []time.Duration{3 * time.Hour, 2 * time.Hour, time.Hour},
+			maxAge:      90 * time.Minute,
+			wantRemoved: []int{0, 1},
+		},
+		{
+			name:        "active key kept",
+			ages:        []time.Duration{3 * time.Hour, 2 * time.Hour, time.Hour},
+			maxAge:      30 * time.Minute,
+			wantRemoved: []int{0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewKeyManager(t.TempDir(), AlgorithmEdDSA, 0)
+			if err != nil {
+				t.Fatalf("NewKeyManager: %v", err)
+			}
+
+			kids := []string{m.activeKey().kid}
+			for len(kids) < len(tt.ages) {
+				kids = append(kids, rotate(t, m))
+			}
+			for i, kid := range kids {
+				kids[i] = setKeyAge(t, m, kid, tt.ages[i])
+			}
+
+			if err := m.Prune(tt.maxAge); err != nil {
+				t.Fatalf("Prune: %v", err)
+			}
+
+			removed := make(map[int]bool)
+			for _, i := range tt.wantRemoved {
+				removed[i] = true
+			}
+			for i, kid := range kids {
+				_, ok := m.lookup(kid)
+				if ok == removed[i] {
+					t.Errorf("key %d (%s): present = %v, want %v", i, kid, ok, !removed[i])
+				}
+				if _, err := os.Stat(filepath.Join(m.dir, kid+".pem")); (err == nil) == removed[i] {
+					t.Errorf("key %d (%s): file present = %v, want %v", i, kid, err == nil, !removed[i])
+				}
+			}
+
+			if got, want := m.activeKey().kid, kids[len(kids)-1]; got != want {
+				t.Errorf("active key = %s, want %s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Package password хеширует пароли с помощью bcrypt или Argon2id.
+// Хеши хранятся в формате PHC ($argon2id$v=19$m=...,t=...,p=...$salt$hash)
+// или в стандартном формате bcrypt ($2a$...), поэтому алгоритм и параметры
+// всегда определяются по самому хешу.
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Поддерживаемые алгоритмы
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// ErrUnknownHash - хеш в неизвестном формате
+var ErrUnknownHash = errors.New("unknown password hash format")
+
+// scheme - реализация одного алгоритма хеширования
+type scheme interface {
+	hash(password string) (string, error)
+	verify(password, encoded string) (bool, error)
+	needsRehash(encoded string) bool
+	recognizes(encoded string) bool
+}
+
+// Hasher хеширует новые пароли выбранным алгоритмом и проверяет
+// пароли, захешированные любым поддерживаемым алгоритмом
+type Hasher struct {
+	current scheme
+	schemes []scheme
+}
+
+// NewHasher создает Hasher. algorithm определяет, чем хешируются новые пароли.
+func NewHasher(algorithm string, bcryptCost int, argon Argon2idParams) (*Hasher, error) {
+	b, err := newBcrypt(bcryptCost)
+	if err != nil {
+		return nil, err
+	}
+	a, err := newArgon2id(argon)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Hasher{schemes: []scheme{a, b}}
+	switch strings.ToLower(algorithm) {
+	case AlgorithmArgon2id, "":
+		h.current = a
+	case AlgorithmBcrypt:
+		h.current = b
+	default:
+		return nil, fmt.Errorf("unsupported password algorithm: %s", algorithm)
+	}
+
+	return h, nil
+}
+
+// Hash хеширует пароль текущим алгоритмом
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.current.hash(password)
+}
+
+// Verify проверяет пароль по хешу. Неверный пароль - это false без ошибки.
+func (h *Hasher) Verify(password, encoded string) (bool, error) {
+	for _, sch := range h.schemes {
+		if sch.recognizes(encoded) {
+			return sch.verify(password, encoded)
+		}
+	}
+	return false, ErrUnknownHash
+}
+
+// NeedsRehash сообщает, что хеш сделан другим алгоритмом или с устаревшими параметрами
+func (h *Hasher) NeedsRehash(encoded string) bool {
+	if !h.current.recognizes(encoded) {
+		return true
+	}
+	return h.current.needsRehash(encoded)
+}
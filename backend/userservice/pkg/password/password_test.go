@@ -0,0 +1,134 @@
+package password
+
+import "testing"
+
+// testArgon - облегченные параметры, чтобы тесты не тратили 64 МиБ на хеш
+var testArgon = Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1}
+
+func newTestHasher(t *testing.T, algorithm string, bcryptCost int, argon Argon2idParams) *Hasher {
+	t.Helper()
+
+	h, err := NewHasher(algorithm, bcryptCost, argon)
+	if err != nil {
+		t.Fatalf("NewHasher: %v", err)
+	}
+	return h
+}
+
+func TestHasherNeedsRehash(t *testing.T) {
+	tests := []struct {
+		name    string
+		hashed  *Hasher // Чем захеширован пароль
+		current *Hasher // Текущие настройки
+		want    bool
+	}{
+		{
+			name:    "argon2id with current params",
+			hashed:  newTestHasher(t, AlgorithmArgon2id, 4, testArgon),
+			current: newTestHasher(t, AlgorithmArgon2id, 4, testArgon),
+			want:    false,
+		},
+		{
+			name:    "argon2id with less memory",
+			hashed:  newTestHasher(t, AlgorithmArgon2id, 4, Argon2idParams{Memory: 32, Iterations: 1, Parallelism: 1}),
+			current: newTestHasher(t, AlgorithmArgon2id, 4, testArgon),
+			want:    true,
+		},
+		{
+			name:    "argon2id with other iterations",
+			hashed:  newTestHasher(t, AlgorithmArgon2id, 4, Argon2idParams{Memory: 64, Iterations: 2, Parallelism: 1}),
+			current: newTestHasher(t, AlgorithmArgon2id, 4, testArgon),
+			want:    true,
+		},
+		{
+			name:    "bcrypt when argon2id is current",
+			hashed:  newTestHasher(t, AlgorithmBcrypt, 4, testArgon),
+			current: newTestHasher(t, AlgorithmArgon2id, 4, testArgon),
+			want:    true,
+		},
+		{
+			name:    "bcrypt with current cost",
+			hashed:  newTestHasher(t, AlgorithmBcrypt, 4, testArgon),
+			current: newTestHasher(t, AlgorithmBcrypt, 4, testArgon),
+			want:    false,
+		},
+		{
+			name:    "bcrypt with lower cost",
+			hashed:  newTestHasher(t, AlgorithmBcrypt, 4, testArgon),
+			current: newTestHasher(t, AlgorithmBcrypt, 5, testArgon),
+			want:    true,
+		},
+		{
+			name:    "bcrypt with higher cost",
+			hashed:  newTestHasher(t, AlgorithmBcrypt, 5, testArgon),
+			current: newTestHasher(t, AlgorithmBcrypt, 4, testArgon),
+			want:    false,
+		},
+		{
+			name:    "argon2id when bcrypt is current",
+			hashed:  newTestHasher(t, AlgorithmArgon2id, 4, testArgon),
+			current: newTestHasher(t, AlgorithmBcrypt, 4, testArgon),
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.hashed.Hash("correct horse battery staple")
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+
+			if got := tt.current.NeedsRehash(encoded); got != tt.want {
+				t.Errorf("NeedsRehash(%s) = %v, want %v", encoded, got, tt.want)
+			}
+
+			// Пароль проверяется при любых настройках, иначе его нельзя перехешировать
+			ok, err := tt.current.Verify("correct horse battery staple", encoded)
+			if err != nil || !ok {
+				t.Errorf("Verify = %v, %v, want true", ok, err)
+			}
+		})
+	}
+}
+
+func TestHasherNeedsRehashMalformed(t *testing.T) {
+	h := newTestHasher(t, AlgorithmArgon2id, 4, testArgon)
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{name: "empty", encoded: ""},
+		{name: "unknown format", encoded: "plaintext"},
+		{name: "argon2id without key", encoded: "$argon2id$v=19$m=64,t=1,p=1$c2FsdA"},
+		{name: "argon2id with bad params", encoded: "$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5"},
+		{name: "argon2id of another version", encoded: "$argon2id$v=16$m=64,t=1,p=1$c2FsdA$a2V5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !h.NeedsRehash(tt.encoded) {
+				t.Errorf("NeedsRehash(%q) = false, want true", tt.encoded)
+			}
+		})
+	}
+}
+
+func TestHasherVerifyWrongPassword(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmArgon2id, AlgorithmBcrypt} {
+		t.Run(algorithm, func(t *testing.T) {
+			h := newTestHasher(t, algorithm, 4, testArgon)
+
+			encoded, err := h.Hash("correct horse battery staple")
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+
+			ok, err := h.Verify("wrong password", encoded)
+			if err != nil || ok {
+				t.Errorf("Verify = %v, %v, want false without error", ok, err)
+			}
+		})
+	}
+}